@@ -2,9 +2,14 @@ package main
 
 import (
 	"fmt"
+	"gat/pkg/audit"
 	"gat/pkg/config"
+	"gat/pkg/git"
 	"gat/pkg/platform"
 	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -15,12 +20,24 @@ var (
 	username    string
 	email       string
 	token       string
-	sshIdentity string
+	sshCert     string
 	platformID  string
 	host        string
 	authMethod  string
+	workingDir  string
+	gnupgHome   string
+	gpgKey      string
+	description string
+	tokenExpiry string
 	overwrite   bool
 	setupSSH    bool
+	generateKey bool
+	strictEmail bool
+	tokenPasswd bool
+
+	sshIdentities []string
+	mirrorRemotes []string
+	tags          []string
 )
 
 var addCmd = &cobra.Command{
@@ -36,6 +53,29 @@ var addCmd = &cobra.Command{
 			return fmt.Errorf("❌ %v", err)
 		}
 
+		// Store identities under the home directory in the portable ~ form;
+		// the shell may already have expanded a ~ typed by the user
+		var identities []string
+		for _, identity := range sshIdentities {
+			if identity != "" && !slices.Contains(identities, ssh.CollapseIdentityPath(identity)) {
+				identities = append(identities, ssh.CollapseIdentityPath(identity))
+			}
+		}
+		sshCert = ssh.CollapseIdentityPath(sshCert)
+
+		expiry, err := config.ParseTokenExpiry(tokenExpiry)
+		if err != nil {
+			return err
+		}
+
+		// --generate-key creates the identity at the default path unless one is given
+		if generateKey && !cmd.Flags().Changed("ssh-identity") {
+			if err := cmd.Flags().Set("ssh-identity", ssh.ProfileKeyPath(profileName)); err != nil {
+				return err
+			}
+			identities = []string{ssh.ProfileKeyPath(profileName)}
+		}
+
 		// Determine initial auth method based on flags if provided
 		initialAuthMethod := strings.ToLower(authMethod)
 		// Note: Validation of initialAuthMethod happens later if creating new or explicitly set
@@ -74,11 +114,7 @@ var addCmd = &cobra.Command{
 				profileToSave.Username = username
 			}
 			if cmd.Flags().Changed("email") {
-				// Validate email format using the regex from config package
-				if !config.ValidEmailRegex.MatchString(email) {
-					// Allow potentially invalid emails but warn
-					fmt.Printf(color.YellowString("⚠️ Warning: Updating profile [%s] with potentially invalid email format: %s\n"), profileName, email)
-				}
+				// Email format is checked by AddProfile
 				profileToSave.Email = email
 			}
 			if cmd.Flags().Changed("platform") {
@@ -94,7 +130,25 @@ var addCmd = &cobra.Command{
 				profileToSave.Host = host
 			}
 			if cmd.Flags().Changed("ssh-identity") {
-				profileToSave.SSHIdentity = sshIdentity
+				profileToSave.SSHIdentities = identities
+			}
+			if cmd.Flags().Changed("ssh-cert") {
+				profileToSave.SSHCertPath = sshCert
+			}
+			if cmd.Flags().Changed("working-dir") {
+				profileToSave.WorkingDirectory = workingDir
+			}
+			if cmd.Flags().Changed("gnupghome") {
+				profileToSave.GnupghomeOverride = gnupgHome
+			}
+			if cmd.Flags().Changed("tag") {
+				profileToSave.Tags = profileTags(tags)
+			}
+			if cmd.Flags().Changed("gpg-key") {
+				profileToSave.GPGKey = gpgKey
+			}
+			if cmd.Flags().Changed("description") {
+				profileToSave.Description = description
 			}
 
 			// Determine effective auth method for update
@@ -124,14 +178,14 @@ var addCmd = &cobra.Command{
 
 			// Handle token update
 			if cmd.Flags().Changed("token") {
-				profileToSave.SetToken(token, validConfig.StoreEncrypted, validConfig.Salt)
+				profileToSave.SetToken(token, validConfig.StoreEncrypted, validConfig.EncryptionSecret())
 			}
 
 		} else {
 			isUpdate = false
 			// Creating a new profile or adding without overwrite
 			if exists && !overwrite {
-				return fmt.Errorf("❌ profile [%s] already exists. Use --overwrite to replace it", profileName)
+				return utils.Errorf(config.ErrProfileExists, "❌ profile [%s] already exists. Use --overwrite to replace it", profileName)
 			}
 
 			// Validate required flags for new profile
@@ -145,15 +199,12 @@ var addCmd = &cobra.Command{
 			if !config.ValidGitHubUsernameRegex.MatchString(username) {
 				return fmt.Errorf("❌ invalid username format: '%s'", username)
 			}
-			// Validate email format for new profile
-			if !config.ValidEmailRegex.MatchString(email) {
-				// Allow potentially invalid emails but warn
-				fmt.Printf(color.YellowString("⚠️ Warning: Adding profile [%s] with potentially invalid email format: %s\n"), profileName, email)
-			}
 
 			// Determine effective auth method for new profile
 			if initialAuthMethod == "" {
-				if sshIdentity != "" {
+				if validConfig.DefaultAuthMethod != "" {
+					effectiveAuthMethod = validConfig.DefaultAuthMethod
+				} else if len(identities) > 0 {
 					effectiveAuthMethod = "ssh"
 				} else {
 					effectiveAuthMethod = "https"
@@ -179,25 +230,131 @@ var addCmd = &cobra.Command{
 
 			// Create the new profile struct from flags
 			profileToSave = config.Profile{
-				Username:    username,
-				Email:       email,
-				SSHIdentity: sshIdentity,
-				Platform:    platformID,
-				Host:        host,
-				AuthMethod:  effectiveAuthMethod,
+				Username:          username,
+				Email:             email,
+				SSHIdentities:     identities,
+				SSHCertPath:       sshCert,
+				Platform:          platformID,
+				Host:              host,
+				AuthMethod:        effectiveAuthMethod,
+				WorkingDirectory:  workingDir,
+				GnupghomeOverride: gnupgHome,
+				GPGKey:            gpgKey,
+				Description:       description,
+				Tags:              profileTags(tags),
 			}
 			// Set token only if provided for new profile
 			if cmd.Flags().Changed("token") {
-				profileToSave.SetToken(token, validConfig.StoreEncrypted, validConfig.Salt)
+				profileToSave.SetToken(token, validConfig.StoreEncrypted, validConfig.EncryptionSecret())
+			}
+		}
+
+		// A new token does not expire when the one it replaces did
+		if cmd.Flags().Changed("token-expiry") {
+			profileToSave.SetTokenExpiry(expiry)
+		} else if cmd.Flags().Changed("token") {
+			profileToSave.SetTokenExpiry(nil)
+		}
+
+		// A GitHub profile on another host is on GitHub Enterprise Server: the
+		// rest of the command (token checks, SSH host alias) uses that host
+		if profileToSave.GetPlatform() == "github" && platform.IsGHESHost(profileToSave.Host) {
+			platform.RegisterSessionPlatform(platform.GHESPlatform(profileToSave.Host))
+			fmt.Printf("ℹ️ Using GitHub Enterprise Server at %s\n", profileToSave.Host)
+		}
+
+		// Platforms with a credential helper authenticate over HTTPS only, and
+		// CodeCommit profiles may give just their region as the host
+		if plat, err := platform.NewRegistry().GetPlatform(profileToSave.GetPlatform()); err == nil {
+			if plat.CredentialHelper != "" && profileToSave.AuthMethod != "https" {
+				return fmt.Errorf("❌ %s profiles authenticate with a credential helper and must use --auth-method https", plat.Name)
+			}
+			if plat.ID == "codecommit" {
+				profileToSave.Host = platform.ExpandCodeCommitRegion(profileToSave.Host)
+			}
+		}
+
+		// --mirror-remote adds to the remotes 'gat switch' keeps in sync with origin
+		for _, remote := range mirrorRemotes {
+			if err := git.ValidateRemoteName(remote); err != nil {
+				return err
+			}
+			if !slices.Contains(profileToSave.MirrorRemotes, remote) {
+				profileToSave.MirrorRemotes = append(profileToSave.MirrorRemotes, remote)
+			}
+		}
+
+		// Check the token format against the platform's rules
+		if cmd.Flags().Changed("token") && token != "" {
+			if plat, err := platform.NewRegistry().GetPlatform(profileToSave.GetPlatform()); err == nil {
+				if err := plat.ValidateToken(token); err != nil {
+					return err
+				}
+				if err := plat.CheckTokenPrefix(token); err != nil {
+					fmt.Println(color.YellowString("⚠️ Warning: %v", err))
+				}
+				if profileToSave.AuthMethod == "https" && len(plat.OAuthScopes) > 0 {
+					fmt.Printf("💡 Ensure your token has scopes: [%s]\n", strings.Join(plat.OAuthScopes, ", "))
+				}
+			}
+		}
+
+		// 'gat switch' enables commit signing with the key, so it must be in
+		// the profile's keyring
+		if cmd.Flags().Changed("gpg-key") && gpgKey != "" {
+			if err := config.ValidateGPGKey(gpgKey); err != nil {
+				return err
+			}
+			gnupgHome, err := profileToSave.GnupgHomePath()
+			if err != nil {
+				return err
+			}
+			found, err := git.GPGKeyExists(gpgKey, gnupgHome)
+			if err != nil {
+				fmt.Println(color.YellowString("⚠️ Could not check the GPG key: %v", err))
+			} else if !found {
+				return fmt.Errorf("❌ no secret key '%s' in the GPG keyring; list them with 'gpg --list-secret-keys --keyid-format long'", gpgKey)
+			}
+		}
+
+		// Protect the token with its own password, asked for by 'gat switch'
+		if tokenPasswd {
+			if !cmd.Flags().Changed("token") || token == "" {
+				return fmt.Errorf("❌ --token-password needs --token")
+			}
+			password := os.Getenv(config.PasswordEnvVar)
+			if password == "" {
+				var err error
+				if password, err = readNewEncryptionPassword("token-password"); err != nil {
+					return err
+				}
+			}
+			if err := profileToSave.SetPasswordProtectedToken(token, password); err != nil {
+				return err
 			}
 		}
 
 		// Add or update the profile in the config map
-		// AddProfile now implicitly handles the overwrite logic based on the flag
-		if err := config.AddProfile(&validConfig, profileName, profileToSave, overwrite); err != nil {
+		// AddProfile now implicitly handles the overwrite logic based on the flag.
+		// --strict-email only applies to this invocation, so the stored setting
+		// is restored before saving.
+		storedStrictEmail := validConfig.StrictEmailValidation
+		if strictEmail {
+			validConfig.StrictEmailValidation = true
+		}
+		err = config.AddProfile(&validConfig, profileName, profileToSave, overwrite)
+		validConfig.StrictEmailValidation = storedStrictEmail
+		if err != nil {
 			return err
 		}
 
+		// Generate the key now that the profile has passed validation
+		if generateKey {
+			if err := generateProfileKey(ssh.KeyOptions{Path: profileToSave.SSHIdentity()}, profileToSave, false); err != nil {
+				return err
+			}
+		}
+
 		// Set as current only if adding the very first profile
 		if !isUpdate && len(validConfig.Profiles) == 1 {
 			validConfig.Current = profileName
@@ -211,9 +368,9 @@ var addCmd = &cobra.Command{
 
 		// Set up SSH configuration if requested AND auth method is SSH
 		// Use profileToSave here as it contains the final state
-		if setupSSH && profileToSave.SSHIdentity != "" && profileToSave.AuthMethod == "ssh" {
+		if setupSSH && len(profileToSave.SSHIdentities) > 0 && profileToSave.AuthMethod == "ssh" {
 			fmt.Println("🔐 Setting up SSH configuration...")
-			if err := ssh.UpdateSSHConfig(profileToSave.Platform, profileName, profileToSave.SSHIdentity); err != nil {
+			if err := ssh.UpdateSSHConfig(profileToSave.Platform, profileName, profileToSave.SSHIdentities, profileToSave.SSHCertPath); err != nil {
 				fmt.Printf(color.YellowString("⚠️ Warning: Failed to update SSH config: %v\n"), err)
 			}
 		}
@@ -224,6 +381,14 @@ var addCmd = &cobra.Command{
 			color.CyanString(profileToSave.Username),
 			color.MagentaString(profileToSave.Platform),
 			color.BlueString(profileToSave.AuthMethod))
+		notifyWebhooks(config.WebhookEventAdd, profileName)
+		logAudit(audit.EventAdd, profileName, utils.Ternary(isUpdate, "updated", "created"))
+
+		if generateKey {
+			if err := printGeneratedKey(profileToSave.SSHIdentity()); err != nil {
+				return err
+			}
+		}
 
 		// Show reminder to switch if the added/updated profile is not the current one
 		if validConfig.Current != profileName {
@@ -234,6 +399,19 @@ var addCmd = &cobra.Command{
 	},
 }
 
+// profileTags returns the tags given with --tag, without empty values and
+// repeats ('--tag ""' clears the tags)
+func profileTags(values []string) []string {
+	var result []string
+	for _, tag := range values {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.ContainsFunc(result, func(seen string) bool { return strings.EqualFold(seen, tag) }) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
 func init() {
 	rootCmd.AddCommand(addCmd)
 
@@ -241,11 +419,23 @@ func init() {
 	addCmd.Flags().StringVar(&username, "username", "", "Git username (must begin and end with alphanumeric characters, can contain hyphens in between)")
 	addCmd.Flags().StringVar(&email, "email", "", "Git email")
 	addCmd.Flags().StringVar(&token, "token", "", "Git personal access token (used for HTTPS)")
-	addCmd.Flags().StringVar(&sshIdentity, "ssh-identity", "", "Path to SSH identity file (used for SSH)")
+	addCmd.Flags().StringVar(&tokenExpiry, "token-expiry", "", "Date the token expires (YYYY-MM-DD), for the warnings of 'gat doctor'")
+	addCmd.Flags().StringArrayVar(&sshIdentities, "ssh-identity", nil, "Path to SSH identity file (used for SSH); '~' is supported. Repeat for more keys; the first is the primary one")
+	addCmd.Flags().StringVar(&sshCert, "ssh-cert", "", "Path to the CA-signed certificate for the SSH identity (e.g. ~/.ssh/id_ed25519-cert.pub)")
 	addCmd.Flags().StringVar(&platformID, "platform", "github", "Git platform (e.g., github, gitlab, bitbucket)")
 	addCmd.Flags().StringVar(&host, "host", "", "Custom hostname for self-hosted instances")
-	addCmd.Flags().StringVar(&authMethod, "auth-method", "", "Authentication method ('ssh' or 'https'). Defaults based on --ssh-identity.")
+	addCmd.Flags().StringVar(&authMethod, "auth-method", "", "Authentication method ('ssh' or 'https'). Defaults to the default-auth-method setting, else based on --ssh-identity.")
+	addCmd.Flags().StringVar(&workingDir, "working-dir", "", "Glob pattern of directories that should use this profile (see 'gat check-dir')")
+	addCmd.Flags().StringVar(&gnupgHome, "gnupghome", "", "GNUPGHOME directory for this profile's GPG keyring (exported on 'gat switch')")
+	addCmd.Flags().StringVar(&gpgKey, "gpg-key", "", "GPG key ID or fingerprint to sign commits with; 'gat switch' sets user.signingkey and commit.gpgsign")
+	addCmd.Flags().StringVar(&description, "description", "", fmt.Sprintf("Markdown description of the profile, shown by 'gat profile show' (up to %d characters)", config.MaxDescriptionLength))
 	addCmd.Flags().BoolVar(&overwrite, "overwrite", false, "Overwrite profile if it already exists")
+	addCmd.Flags().BoolVar(&strictEmail, "strict-email", false, "Reject emails that are not RFC 5321 compliant instead of warning (see 'gat config set strict-email-validation')")
+	addCmd.Flags().BoolVar(&tokenPasswd, "token-password", false, "Encrypt the token with its own password (or GAT_PASSWORD), asked for by 'gat switch'")
+	addCmd.Flags().BoolVar(&generateKey, "generate-key", false, "Generate an SSH key for the profile (same as 'gat ssh generate')")
+	addCmd.Flags().StringArrayVar(&tags, "tag", nil, "Tag for filtering profiles, e.g. 'gat list --tag work' (repeatable; replaces the profile's tags, \"\" removes them)")
+	addCmd.RegisterFlagCompletionFunc("tag", completeTagFlag)
+	addCmd.Flags().StringArrayVar(&mirrorRemotes, "mirror-remote", nil, "Remote besides origin that 'gat switch' rewrites to the profile's auth method (repeatable)")
 	addCmd.Flags().BoolVar(&setupSSH, "setup-ssh", true, "Set up SSH host alias in ~/.ssh/gat_config if using SSH auth method")
 
 	// Mark required flags - REMOVED these as validation is handled inside RunE
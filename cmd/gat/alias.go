@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// aliasCmd represents the alias command
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "🏷️ Manage short names for profiles",
+	Long: `🏷️ Aliases are alternative names for profiles. Every command that takes a
+profile name also accepts an alias:
+
+  gat alias add corp github-personal-contractor-2024
+  gat switch corp`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Default behavior is to list aliases
+		return listAliasCmd.RunE(cmd, args)
+	},
+}
+
+// addAliasCmd represents the add subcommand of alias
+var addAliasCmd = &cobra.Command{
+	Use:   "add <alias> <profile>",
+	Short: "Add or change an alias for a profile",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		alias, target := args[0], args[1]
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		previous, exists := validConfig.Aliases[alias]
+		if err := config.AddAlias(&validConfig, alias, target); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		if exists && previous != target {
+			fmt.Printf("✅ Alias %s now points to %s (was %s)\n", color.GreenString(alias), color.GreenString(target), previous)
+		} else {
+			fmt.Printf("✅ Alias %s -> %s\n", color.GreenString(alias), color.GreenString(target))
+		}
+		return nil
+	},
+}
+
+// listAliasCmd represents the list subcommand of alias
+var listAliasCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all aliases",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if len(validConfig.Aliases) == 0 {
+			fmt.Println("😶 No aliases defined. Add one with 'gat alias add <alias> <profile>'")
+			return nil
+		}
+
+		aliases := make([]string, 0, len(validConfig.Aliases))
+		for alias := range validConfig.Aliases {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+
+		fmt.Println("🏷️ Profile aliases:")
+		for _, alias := range aliases {
+			target := validConfig.Aliases[alias]
+			resolved := config.ResolveAlias(&validConfig, alias)
+			line := fmt.Sprintf("  %s -> %s", color.GreenString(alias), target)
+			if resolved != target {
+				line += fmt.Sprintf(" (%s)", resolved)
+			}
+			if _, exists := validConfig.Profiles[resolved]; !exists {
+				line += color.YellowString(" ⚠️ profile not found")
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+// removeAliasCmd represents the remove subcommand of alias
+var removeAliasCmd = &cobra.Command{
+	Use:   "remove <alias>",
+	Short: "Remove an alias (the profile is kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if err := config.RemoveAlias(&validConfig, args[0]); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed alias %s\n", color.GreenString(args[0]))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(addAliasCmd)
+	aliasCmd.AddCommand(listAliasCmd)
+	aliasCmd.AddCommand(removeAliasCmd)
+}
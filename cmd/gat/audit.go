@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/audit"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditCount      int
+	auditTail       int
+	auditClearForce bool
+)
+
+// auditMaxHashes is how many commit hashes are listed per identity
+const auditMaxHashes = 5
+
+// auditIdentity groups the audited commits made with one author identity
+type auditIdentity struct {
+	Name    string
+	Email   string
+	Profile string // Matching gat profile, empty if none
+	Commits []string
+}
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "🕵️ Check recent commits against your profiles",
+	Long: `🕵️ Reads the authors of the last commits in the current repository, groups
+them by identity and highlights identities that do not match any gat profile
+(same username and email), such as commits made before switching profiles.
+
+Switches, additions and removals of profiles are recorded in audit.log next to
+the credentials file (~/.gat/audit.log); see 'gat audit list' and
+'gat audit clear'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if auditCount <= 0 {
+			return fmt.Errorf("❌ --count must be positive")
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		commits, err := git.GetRecentCommitIdentities(auditCount)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			fmt.Println("📭 No commits to audit.")
+			return nil
+		}
+
+		identities := groupCommitIdentities(commits, validConfig.Profiles)
+
+		fmt.Printf("🕵️ Audited %d commit(s) by %d author(s):\n\n", len(commits), len(identities))
+		unknown := 0
+		for _, identity := range identities {
+			label := fmt.Sprintf("%s <%s>", identity.Name, identity.Email)
+			if identity.Profile != "" {
+				fmt.Printf("✅ %s — profile '%s'\n", label, color.GreenString(identity.Profile))
+			} else {
+				unknown++
+				fmt.Println(color.YellowString("⚠️ %s — no matching profile", label))
+			}
+
+			hashes := identity.Commits
+			more := ""
+			if len(hashes) > auditMaxHashes {
+				more = fmt.Sprintf(" (+%d more)", len(hashes)-auditMaxHashes)
+				hashes = hashes[:auditMaxHashes]
+			}
+			short := make([]string, len(hashes))
+			for i, hash := range hashes {
+				if len(hash) > 8 {
+					hash = hash[:8]
+				}
+				short[i] = hash
+			}
+			fmt.Printf("   %d commit(s): %s%s\n", len(identity.Commits), strings.Join(short, " "), more)
+		}
+
+		if unknown > 0 {
+			fmt.Println()
+			fmt.Println(color.YellowString("⚠️ %d author(s) do not match any profile.", unknown))
+			fmt.Println("💡 Add a profile for them with 'gat add', or fix the author with 'git commit --amend --reset-author'.")
+		}
+		return nil
+	},
+}
+
+// auditListCmd represents the list subcommand of audit
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the latest profile switches, additions and removals",
+	Long: `Prints the last entries of the audit log: when each profile was switched
+to, added or removed, and by which user.
+
+Examples:
+  gat audit list
+  gat audit list --tail 50`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := audit.NewLogger()
+		if err != nil {
+			return err
+		}
+		entries, err := logger.Tail(auditTail)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			fmt.Println("📭 The audit log is empty.")
+			return nil
+		}
+
+		for _, entry := range entries {
+			line := fmt.Sprintf("%s  %-6s  %s  by %s", entry.Time.Local().Format("2006-01-02 15:04:05"),
+				entry.Event, color.GreenString(entry.Profile), entry.User)
+			if entry.Detail != "" {
+				line += color.CyanString(" (%s)", entry.Detail)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+// auditClearCmd represents the clear subcommand of audit
+var auditClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Empty the audit log",
+	Long:  `Empties the audit log after asking for confirmation (skipped with --force).`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := audit.NewLogger()
+		if err != nil {
+			return err
+		}
+
+		if !auditClearForce {
+			if !stdinIsTerminal() {
+				return fmt.Errorf("❌ clearing the audit log needs confirmation; use --force without a terminal")
+			}
+			prompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Clear the audit log %s", logger.Path),
+				IsConfirm: true,
+			}
+			if _, err := prompt.Run(); err != nil {
+				return fmt.Errorf("❌ clear canceled")
+			}
+		}
+
+		if err := logger.Clear(); err != nil {
+			return err
+		}
+		fmt.Println("✅ Audit log cleared")
+		return nil
+	},
+}
+
+// logAudit records a profile operation of the current user in the audit
+// log, warning on stderr if it cannot
+func logAudit(event, profile, detail string) {
+	logger, err := audit.NewLogger()
+	if err == nil {
+		err = logger.Log(event, profile, audit.CurrentUser(), detail)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, color.YellowString("⚠️ Warning: %v", err))
+	}
+}
+
+// groupCommitIdentities groups commits by author, in order of each author's
+// most recent commit, and matches each author to a profile. An author matches
+// a profile with the same username and (case-insensitively) email.
+func groupCommitIdentities(commits []git.CommitIdentity, profiles map[string]config.Profile) []*auditIdentity {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var identities []*auditIdentity
+	byKey := make(map[string]*auditIdentity)
+	for _, commit := range commits {
+		key := commit.Name + "\x00" + strings.ToLower(commit.Email)
+		identity, ok := byKey[key]
+		if !ok {
+			identity = &auditIdentity{Name: commit.Name, Email: commit.Email}
+			for _, name := range names {
+				profile := profiles[name]
+				if profile.Username == commit.Name && strings.EqualFold(profile.Email, commit.Email) {
+					identity.Profile = name
+					break
+				}
+			}
+			byKey[key] = identity
+			identities = append(identities, identity)
+		}
+		identity.Commits = append(identity.Commits, commit.Hash)
+	}
+	return identities
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditListCmd)
+	auditCmd.AddCommand(auditClearCmd)
+	auditCmd.Flags().IntVarP(&auditCount, "count", "n", 100, "Number of recent commits to audit")
+	auditListCmd.Flags().IntVar(&auditTail, "tail", 20, "Number of entries to show (0 for all)")
+	auditClearCmd.Flags().BoolVar(&auditClearForce, "force", false, "Skip the confirmation prompt")
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkRemote  string
+	checkVerbose bool
+)
+
+// checkCmd represents the check command. It is meant for Git hooks, so it
+// prints nothing on success and a single line to stderr on failure.
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "🚦 Check that Git uses the active profile (for Git hooks)",
+	Long: `🚦 Checks that the email Git commits with (the repository's user.email, else
+the global one) is the active profile's, and exits 1 with a one-line message
+on stderr if it is not. With --remote, the URL must also have the form 'gat
+switch' gives remotes for the profile: its SSH host alias, or HTTPS on its
+host.
+
+Nothing is printed on success unless --verbose is set, so it can guard
+commits and pushes from a hook:
+
+  # .git/hooks/pre-commit
+  gat check
+
+  # .git/hooks/pre-push ($2 is the URL being pushed to)
+  gat check --remote "$2"`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runCheck(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// runCheck runs the checks of 'gat check' on the active profile
+func runCheck() error {
+	// Hooks run non-interactively, and the checks need no token
+	config.PasswordPrompt = nil
+	if !checkVerbose {
+		config.WarningOutput = io.Discard
+	}
+
+	validConfig, _, ioErr := config.LoadConfig()
+	if ioErr != nil {
+		return ioErr
+	}
+	profile, name, err := config.GetCurrentProfile(&validConfig)
+	if err != nil {
+		return err
+	}
+
+	if err := git.ValidateIdentityMatchesProfile(profile); err != nil {
+		return fmt.Errorf("%v (active profile '%s'; run 'gat switch %s')", err, name, name)
+	}
+	if checkVerbose {
+		fmt.Printf("✅ Git commits as %s, the email of profile %s\n", profile.Email, color.GreenString(name))
+	}
+
+	if checkRemote != "" {
+		if err := git.ValidateRemoteMatchesProfile(checkRemote, profile, name); err != nil {
+			return fmt.Errorf("%v (active profile '%s')", err, name)
+		}
+		if checkVerbose {
+			fmt.Printf("✅ Remote %s matches profile %s\n", checkRemote, color.GreenString(name))
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVar(&checkRemote, "remote", "", "Also check that this remote URL matches the active profile")
+	checkCmd.Flags().BoolVarP(&checkVerbose, "verbose", "v", false, "Report the checks that passed")
+}
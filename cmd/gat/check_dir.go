@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var checkDirCmd = &cobra.Command{
+	Use:   "check-dir <path>",
+	Short: "📂 Print the switch command for a directory's profile",
+	Long: `📂 Looks up the profile whose working directory pattern matches the given
+path and prints 'gat switch <name>' if it differs from the active profile.
+Prints nothing otherwise, so it is safe to eval from a shell hook.
+
+Set a profile's pattern with 'gat add <name> --working-dir "/home/user/work/*" --overwrite'.
+
+zsh (~/.zshrc):
+  autoload -U add-zsh-hook
+  gat_chpwd() { eval "$(gat check-dir "$PWD")" }
+  add-zsh-hook chpwd gat_chpwd
+
+bash (~/.bashrc):
+  PROMPT_COMMAND='eval "$(gat check-dir "$PWD")"'"${PROMPT_COMMAND:+;$PROMPT_COMMAND}"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// stdout is eval'd by the shell, so keep warnings off it
+		config.WarningOutput = os.Stderr
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		profileName, err := config.FindProfileForDirectory(&validConfig, args[0])
+		if err != nil {
+			return err
+		}
+		if profileName != "" && profileName != validConfig.Current {
+			fmt.Printf("gat switch %s\n", profileName)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkDirCmd)
+}
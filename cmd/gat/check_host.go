@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkHostIdentityDir string
+)
+
+// checkHostCmd is run by the Match exec block of the match-exec SSH strategy
+// (see ssh.MultiHostConfig) for every SSH connection. It exits 0 after
+// linking the identity of the profile for the host, and 1 for hosts gat does
+// not manage, so ssh skips the block.
+var checkHostCmd = &cobra.Command{
+	Use:    "check-host <host>",
+	Short:  "Link the SSH identity for a host (used by ~/.ssh/gat_config)",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		identity, err := checkHost(args[0])
+		if err != nil || identity == "" {
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			os.Exit(1)
+		}
+		fmt.Println(identity)
+	},
+}
+
+// checkHost links the identity for host and returns its path, or "" if no
+// profile's SSH identity applies to host. Profile aliases (github-work) use
+// their profile, a platform's host uses the active profile.
+func checkHost(host string) (string, error) {
+	if checkHostIdentityDir == "" {
+		return "", fmt.Errorf("❌ --identity-dir is required")
+	}
+
+	// ssh runs this for every connection, so never prompt or print warnings
+	config.PasswordPrompt = nil
+	config.WarningOutput = io.Discard
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	validConfig, _, err := config.LoadConfigWithContext(ctx)
+	if err != nil {
+		return "", nil
+	}
+
+	reg := platform.NewRegistry()
+	profileName := ""
+	for name, profile := range validConfig.Profiles {
+		if platform.GetProfileSSHHost(profile.GetPlatform(), name) == host {
+			profileName = name
+			break
+		}
+	}
+	if profileName == "" {
+		active, exists := validConfig.Profiles[validConfig.Current]
+		if !exists {
+			return "", nil
+		}
+		plat, err := reg.GetPlatform(active.GetPlatform())
+		if err != nil || (plat.DefaultHost != host && active.Host != host) {
+			return "", nil
+		}
+		profileName = validConfig.Current
+	}
+
+	profile := validConfig.Profiles[profileName]
+	if len(profile.SSHIdentities) == 0 || profile.AuthMethod != "ssh" {
+		return "", nil
+	}
+	return ssh.LinkHostIdentity(checkHostIdentityDir, host, profile.SSHIdentity(), profile.SSHCertPath)
+}
+
+func init() {
+	rootCmd.AddCommand(checkHostCmd)
+	checkHostCmd.Flags().StringVar(&checkHostIdentityDir, "identity-dir", "", "Directory of the per-host identity links")
+}
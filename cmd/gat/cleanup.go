@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanupMinAge string
+	cleanupDryRun bool
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "🧹 Prune old backup files",
+	Long: `🧹 Removes files in ~/.gat/backups that were last modified more than
+--min-age ago. Ages accept days ("30d") as well as Go durations ("12h").
+
+Examples:
+  gat cleanup --min-age 30d
+  gat cleanup --min-age 7d --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		minAge, err := parseAge(cleanupMinAge)
+		if err != nil {
+			return err
+		}
+
+		pruned, err := config.PruneBackups(minAge, cleanupDryRun)
+		if err != nil {
+			return err
+		}
+
+		if len(pruned) == 0 {
+			fmt.Printf("✅ No backups older than %s\n", cleanupMinAge)
+			return nil
+		}
+
+		if cleanupDryRun {
+			fmt.Println(color.YellowString("🧪 Dry run mode enabled. No files will be removed."))
+		}
+		for _, path := range pruned {
+			fmt.Printf("  🗑️ %s\n", path)
+		}
+		verb := "Removed"
+		if cleanupDryRun {
+			verb = "Would remove"
+		}
+		fmt.Printf("✅ %s %d backup file(s) older than %s\n", verb, len(pruned), cleanupMinAge)
+		return nil
+	},
+}
+
+// parseAge parses an age such as "30d" (days) or any time.ParseDuration value
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("❌ invalid age '%s': expected a number of days like '30d'", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("❌ invalid age '%s': use days ('30d') or a duration ('12h')", value)
+	}
+	return age, nil
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+
+	cleanupCmd.Flags().StringVar(&cleanupMinAge, "min-age", "30d", "Remove backups older than this age (e.g. '30d', '12h')")
+	cleanupCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "List the backups that would be removed without deleting them")
+}
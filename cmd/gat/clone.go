@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneUpstream string
+	cloneProfile  string
+	cloneBranch   string
+	cloneDepth    int
+)
+
+// cloneCmd represents the clone command
+var cloneCmd = &cobra.Command{
+	Use:   "clone <url> [directory] [-- <git clone options>]",
+	Short: "📦 Clone a repository and set it up for a profile",
+	Long: `📦 Clones a repository with its URL rewritten for a profile (the active one
+unless --profile is given): its SSH host alias for SSH profiles, or HTTPS for
+HTTPS profiles. The host alias is written to ~/.ssh/gat_config first, and the
+profile's Git identity is set in the new repository's .git/config.
+
+Use --upstream <url> to also add an 'upstream' remote (e.g. the repository
+you forked from), rewritten the same way. --depth and --branch are passed to
+git clone, as is anything after '--'.
+
+Examples:
+  gat clone git@github.com:user/repo.git --profile work
+  gat clone https://github.com/user/repo.git --depth 1 --branch main
+  gat clone https://github.com/user/repo.git -- --recurse-submodules`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		positional, _ := splitCloneArgs(cmd, args)
+		return cobra.RangeArgs(1, 2)(cmd, positional)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		positional, gitOptions := splitCloneArgs(cmd, args)
+		url := positional[0]
+		dir := ""
+		if len(positional) > 1 {
+			dir = positional[1]
+		}
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		var profile *config.Profile
+		var profileName string
+		if cloneProfile != "" {
+			if err := config.ValidateProfileName(cloneProfile); err != nil {
+				return fmt.Errorf("❌ %v", err)
+			}
+			profileName = config.ResolveAlias(&validConfig, cloneProfile)
+			if validationErr, isInvalid := validationErrors[profileName]; isInvalid {
+				return fmt.Errorf("❌ cannot clone with profile '%s' because it failed validation: %v", profileName, validationErr)
+			}
+			named, exists := validConfig.Profiles[profileName]
+			if !exists {
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+			}
+			profile = &named
+		} else {
+			// Without an active profile the URLs are used as given
+			current, currentName, err := config.GetCurrentProfile(&validConfig)
+			if err != nil {
+				fmt.Println(color.YellowString("⚠️ No active profile; cloning with the URL as given"))
+			}
+			profile, profileName = current, currentName
+		}
+
+		cloneURL := url
+		if profile != nil {
+			cloneURL = profileRemoteURL(url, profile, profileName)
+			fmt.Printf("👤 Using profile '%s' (auth: %s)\n", profileName, profile.AuthMethod)
+
+			// The clone URL goes through the profile's host alias, so it must exist first
+			if profile.AuthMethod == "ssh" && len(profile.SSHIdentities) > 0 {
+				if err := ssh.UpdateSSHConfig(profile.GetPlatform(), profileName, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+					return err
+				}
+			}
+		}
+
+		if cmd.Flags().Changed("depth") {
+			if cloneDepth < 1 {
+				return fmt.Errorf("❌ --depth must be positive, got %d", cloneDepth)
+			}
+			gitOptions = append([]string{fmt.Sprintf("--depth=%d", cloneDepth)}, gitOptions...)
+		}
+		if cloneBranch != "" {
+			gitOptions = append([]string{"--branch=" + cloneBranch}, gitOptions...)
+		}
+
+		if dir == "" {
+			dir = defaultCloneDir(cloneURL)
+		}
+		fmt.Printf("📦 Cloning %s into %s...\n", cloneURL, dir)
+		if err := git.Clone(cloneURL, dir, gitOptions...); err != nil {
+			return err
+		}
+
+		// SetIdentity and CreateRemote work on the current directory
+		startDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("❌ could not get current directory: %w", err)
+		}
+		if err := os.Chdir(dir); err != nil {
+			return fmt.Errorf("❌ could not enter %s: %w", dir, err)
+		}
+		defer os.Chdir(startDir)
+
+		if profile != nil {
+			if err := git.SetIdentity(profile.Username, profile.Email, true); err != nil {
+				fmt.Println(color.YellowString("⚠️ Could not set the Git identity in %s: %v", dir, err))
+			} else {
+				fmt.Printf("👤 Git identity set in .git/config: %s <%s>\n", color.CyanString(profile.Username), color.CyanString(profile.Email))
+			}
+		}
+
+		if cloneUpstream != "" {
+			upstreamURL := cloneUpstream
+			if profile != nil {
+				upstreamURL = profileRemoteURL(cloneUpstream, profile, profileName)
+			}
+			if err := git.CreateRemote("upstream", upstreamURL); err != nil {
+				return err
+			}
+			fmt.Printf("🔗 Added remote upstream: %s\n", upstreamURL)
+		}
+
+		fmt.Println(color.GreenString("✅ Cloned into %s", dir))
+		return nil
+	},
+}
+
+// splitCloneArgs separates the positional arguments of 'gat clone' from the
+// git clone options given after '--'
+func splitCloneArgs(cmd *cobra.Command, args []string) ([]string, []string) {
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		return args[:dash], args[dash:]
+	}
+	return args, nil
+}
+
+// profileRemoteURL rewrites a remote URL to match the profile's auth method
+func profileRemoteURL(url string, profile *config.Profile, profileName string) string {
+	if profile.AuthMethod == "ssh" {
+		return git.ConvertRemoteToSSH(url, profile, profileName)
+	}
+	return git.ConvertRemoteToHTTPS(url, profile)
+}
+
+// defaultCloneDir returns the directory git clone uses for a URL: the last
+// path component without ".git"
+func defaultCloneDir(url string) string {
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(path.Base(strings.TrimSuffix(remote.Path, "/")), ".git")
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+
+	cloneCmd.Flags().StringVar(&cloneUpstream, "upstream", "", "URL of an 'upstream' remote to add after cloning")
+	cloneCmd.Flags().StringVar(&cloneProfile, "profile", "", "Profile to clone with instead of the active one")
+	cloneCmd.Flags().StringVar(&cloneBranch, "branch", "", "Branch to check out (passed to git clone)")
+	cloneCmd.Flags().IntVar(&cloneDepth, "depth", 0, "Create a shallow clone with this many commits (passed to git clone)")
+	cloneCmd.RegisterFlagCompletionFunc("profile", completeProfileFlag)
+}
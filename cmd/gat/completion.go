@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/platform"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds loading the config for shell completion, so a
+// slow filesystem never stalls the shell
+const completionTimeout = time.Second
+
+var (
+	completionNoDescriptions bool
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "⌨️ Generate a shell completion script",
+	Long: `⌨️ Prints the completion script for a shell. Profile names are completed from
+your current configuration, and platform IDs from the platform registry.
+
+Bash (needs the bash-completion package):
+  source <(gat completion bash)                    # add to ~/.bashrc
+
+Zsh:
+  source <(gat completion zsh)                     # add to ~/.zshrc
+
+Fish:
+  gat completion fish > ~/.config/fish/completions/gat.fish
+
+PowerShell:
+  gat completion powershell | Out-String | Invoke-Expression   # add to $PROFILE`,
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		descriptions := !completionNoDescriptions
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, descriptions)
+		case "zsh":
+			if descriptions {
+				return rootCmd.GenZshCompletion(os.Stdout)
+			}
+			return rootCmd.GenZshCompletionNoDesc(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, descriptions)
+		default:
+			if descriptions {
+				return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+			return rootCmd.GenPowerShellCompletion(os.Stdout)
+		}
+	},
+}
+
+// completeProfilesCmd lists profile names for shell completion scripts
+var completeProfilesCmd = &cobra.Command{
+	Use:    "__complete-profiles",
+	Short:  "List profile names for shell completion",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, name := range completionProfileNames() {
+			fmt.Println(name)
+		}
+	},
+}
+
+// completionConfig loads the config for completions, reporting false if it
+// cannot be loaded within completionTimeout. It never prompts for a password
+// and keeps load warnings out of the completion output.
+func completionConfig() (config.Config, bool) {
+	config.PasswordPrompt = nil
+	config.WarningOutput = io.Discard
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	validConfig, _, err := config.LoadConfigWithContext(ctx)
+	return validConfig, err == nil
+}
+
+// completionProfileNames returns the profile names in display order, or
+// nothing if the config cannot be loaded (see completionConfig)
+func completionProfileNames() []string {
+	validConfig, ok := completionConfig()
+	if !ok {
+		return nil
+	}
+	return config.ListProfileNames(&validConfig)
+}
+
+// completeProfileArg completes a profile name as the first positional argument
+func completeProfileArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completionProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProfileFlag completes a flag whose value is a profile name
+func completeProfileFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completionProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTagFlag completes a flag whose value is a profile tag
+func completeTagFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	validConfig, ok := completionConfig()
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.ListTags(&validConfig), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAuthMethodFlag completes a flag whose value is an auth method
+func completeAuthMethodFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{"ssh", "https"}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRemoteFlag completes a flag whose value is a remote of the current
+// repository
+func completeRemoteFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	remotes, err := git.ListRemotes()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return remotes, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionPlatformIDs returns the sorted IDs of the registered platforms,
+// described by their names, or nothing if platforms.yaml cannot be read
+// (NewRegistry would print the error into the completions)
+func completionPlatformIDs() []string {
+	if _, err := platform.LoadCustomPlatforms(); err != nil {
+		return nil
+	}
+
+	var ids []string
+	for _, plat := range platform.NewRegistry().ListPlatforms() {
+		ids = append(ids, plat.ID+"\t"+plat.Name)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// completePlatformArg completes a platform ID as the first positional argument
+func completePlatformArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completionPlatformIDs(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePlatformFlag completes a flag whose value is a platform ID
+func completePlatformFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completionPlatformIDs(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	// Replaces cobra's default completion command
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(completeProfilesCmd)
+
+	completionCmd.Flags().BoolVar(&completionNoDescriptions, "no-descriptions", false, "Leave descriptions out of the completions")
+
+	// Commands taking a profile name as their first argument
+	for _, cmd := range []*cobra.Command{
+		switchCmd, switchAllCmd, removeCmd, renameCmd, copyCmd, pinCmd, verifyCmd,
+		showKeyCmd, sshGenerateCmd, profileShowCmd, profileValidateCmd, tokenSetExpiryCmd,
+		sshTestCmd, tokenVerifyCmd, infoCmd,
+	} {
+		cmd.ValidArgsFunction = completeProfileArg
+	}
+
+	// Commands taking a platform ID as their first argument, and --platform flags
+	for _, cmd := range []*cobra.Command{platformShowCmd, platformUpdateCmd, platformRemoveCmd} {
+		cmd.ValidArgsFunction = completePlatformArg
+	}
+	addCmd.RegisterFlagCompletionFunc("platform", completePlatformFlag)
+	addTemplateCmd.RegisterFlagCompletionFunc("platform", completePlatformFlag)
+
+	// 'gat alias add <alias> <profile>' completes the profile after the alias
+	addAliasCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completionProfileNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// 'gat diff' completes a profile name for both arguments
+	diffCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completionProfileNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// 'gat restore <name>' completes the names of backed-up profiles
+	restoreCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		backups, _ := config.ListProfileBackups()
+		var names []string
+		for _, backup := range backups {
+			if !slices.Contains(names, backup.Profile) {
+				names = append(names, backup.Profile)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "⚙️ View and change gat settings",
+	Long: `⚙️ View and change config-level gat settings stored in creds.json.
+
+Available settings: auto-switch, store-encrypted, no-store-tokens, max-backups.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Default behavior is to list settings
+		return listConfigCmd.RunE(cmd, args)
+	},
+}
+
+// getConfigCmd represents the get subcommand of config
+var getConfigCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the current value of a setting",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		setting, err := config.LookupSetting(args[0])
+		if err != nil {
+			return err
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		fmt.Println(setting.Value(&validConfig))
+		return nil
+	},
+}
+
+// listConfigCmd represents the list subcommand of config
+var listConfigCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings with their current values",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		fmt.Println("⚙️ gat settings:")
+		fmt.Println()
+		for _, setting := range config.ListSettings() {
+			fmt.Printf("  %-16s %-6s %-6s %s\n",
+				color.GreenString(setting.Key),
+				color.CyanString(setting.Value(&validConfig)),
+				setting.Type,
+				setting.Description)
+		}
+		fmt.Println()
+		fmt.Println("💡 Change a setting with 'gat config set <key> <value>'")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(getConfigCmd)
+	configCmd.AddCommand(listConfigCmd)
+}
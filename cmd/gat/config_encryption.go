@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+var (
+	disableEncryptionConfirm bool
+	encryptionPasswordMode   bool
+)
+
+// initEncryptionCmd represents the init-encryption subcommand of config
+var initEncryptionCmd = &cobra.Command{
+	Use:   "init-encryption",
+	Short: "🔒 Encrypt all stored tokens with a new salt",
+	Long: `🔒 Enables AES-GCM token encryption on an existing config: generates a new
+salt, encrypts every stored token and saves the config. The previous config
+file is backed up to ~/.gat/backups first.
+
+By default the key is derived from the salt stored in creds.json, which hides
+tokens from casual reads but not from anyone who can read the file. With
+--password-mode the key is derived from a password you choose (Argon2id), and
+gat asks for it whenever tokens are loaded. Set GAT_PASSWORD to supply it
+non-interactively. Leave the password empty to generate a four-word passphrase.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, err := loadConfigForEncryptionChange()
+		if err != nil {
+			return err
+		}
+		if validConfig.StoreEncrypted && (validConfig.UsePasswordEncryption || !encryptionPasswordMode) {
+			fmt.Println(color.GreenString("✅ Token encryption is already enabled"))
+			return nil
+		}
+
+		var affected []string
+		if encryptionPasswordMode {
+			password, err := readNewEncryptionPassword("password-mode")
+			if err != nil {
+				return err
+			}
+			affected, err = config.EnablePasswordEncryption(&validConfig, password)
+			if err != nil {
+				return err
+			}
+		} else {
+			affected, err = config.EnableEncryption(&validConfig)
+			if err != nil {
+				return err
+			}
+		}
+		if err := saveConfigWithBackup(&validConfig); err != nil {
+			return err
+		}
+
+		if encryptionPasswordMode {
+			fmt.Println(color.GreenString("✅ Password-protected token encryption enabled"))
+		} else {
+			fmt.Println(color.GreenString("✅ Token encryption enabled"))
+		}
+		printAffectedProfiles("Encrypted", affected)
+		return nil
+	},
+}
+
+// disableEncryptionCmd represents the disable-encryption subcommand of config
+var disableEncryptionCmd = &cobra.Command{
+	Use:   "disable-encryption",
+	Short: "🔓 Store tokens in plaintext",
+	Long: `🔓 Decrypts every stored token and disables token encryption. Tokens will be
+readable by anyone with access to creds.json, so --confirm is required.
+The previous config file is backed up to ~/.gat/backups first.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !disableEncryptionConfirm {
+			return fmt.Errorf("❌ this stores tokens in plaintext; re-run with --confirm to proceed")
+		}
+
+		validConfig, err := loadConfigForEncryptionChange()
+		if err != nil {
+			return err
+		}
+		if !validConfig.StoreEncrypted {
+			fmt.Println(color.YellowString("ℹ️ Token encryption is already disabled"))
+			return nil
+		}
+
+		affected, err := config.DisableEncryption(&validConfig)
+		if err != nil {
+			return err
+		}
+		if err := saveConfigWithBackup(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Println(color.YellowString("⚠️ Token encryption disabled; tokens are now stored in plaintext"))
+		printAffectedProfiles("Decrypted", affected)
+		return nil
+	},
+}
+
+// readNewEncryptionPassword asks for a new encryption password twice (for the
+// flag named flagName). An empty password is replaced by a generated
+// passphrase, which is printed.
+func readNewEncryptionPassword(flagName string) (string, error) {
+	if !stdinIsTerminal() {
+		return "", fmt.Errorf("❌ --%s needs an interactive terminal to set the password", flagName)
+	}
+
+	prompt := promptui.Prompt{Label: "New encryption password (empty to generate one)", Mask: '*'}
+	password, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("❌ password entry canceled")
+	}
+
+	if password == "" {
+		password = config.GenerateReadablePassword()
+		fmt.Printf("🔑 Generated passphrase: %s\n", color.CyanString(password))
+		fmt.Println(color.YellowString("   ⚠️ Store it safely; tokens cannot be recovered without it."))
+		return password, nil
+	}
+
+	confirm := promptui.Prompt{Label: "Confirm encryption password", Mask: '*'}
+	repeated, err := confirm.Run()
+	if err != nil {
+		return "", fmt.Errorf("❌ password entry canceled")
+	}
+	if repeated != password {
+		return "", fmt.Errorf("❌ passwords do not match")
+	}
+	return password, nil
+}
+
+// promptEncryptionPassword asks for the password of a password-protected
+// config (see config.PasswordPrompt)
+func promptEncryptionPassword() (string, error) {
+	prompt := promptui.Prompt{Label: "gat encryption password", Mask: '*'}
+	return prompt.Run()
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal. Unlike a
+// ModeCharDevice check, this is false for /dev/null.
+func stdinIsTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// loadConfigForEncryptionChange loads the config, refusing to continue if
+// any profile is invalid since saving would drop it
+func loadConfigForEncryptionChange() (config.Config, error) {
+	validConfig, validationErrors, ioErr := config.LoadConfig()
+	if ioErr != nil {
+		return validConfig, ioErr
+	}
+	if len(validationErrors) > 0 {
+		var names []string
+		for name := range validationErrors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return validConfig, fmt.Errorf("❌ fix or remove invalid profiles first: %s", strings.Join(names, ", "))
+	}
+	return validConfig, nil
+}
+
+// saveConfigWithBackup backs up the config file before saving cfg over it
+func saveConfigWithBackup(cfg *config.Config) error {
+	backupPath, err := config.BackupConfigFile()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("💾 Config backed up to: %s\n", backupPath)
+	return config.SaveConfig(cfg)
+}
+
+// printAffectedProfiles lists the profiles whose tokens were changed
+func printAffectedProfiles(action string, names []string) {
+	if len(names) == 0 {
+		fmt.Println("  No profiles have stored tokens")
+		return
+	}
+	fmt.Printf("  %s tokens for %d profile(s):\n", action, len(names))
+	for _, name := range names {
+		fmt.Printf("    - %s\n", color.GreenString(name))
+	}
+}
+
+func init() {
+	configCmd.AddCommand(initEncryptionCmd)
+	configCmd.AddCommand(disableEncryptionCmd)
+
+	initEncryptionCmd.Flags().BoolVar(&encryptionPasswordMode, "password-mode", false, "Derive the encryption key from a password (Argon2id) instead of the stored salt")
+	disableEncryptionCmd.Flags().BoolVar(&disableEncryptionConfirm, "confirm", false, "Confirm storing tokens in plaintext")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// setConfigCmd represents the set subcommand of config
+var setConfigCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting",
+	Long: `Change a config-level setting. Values are validated before saving:
+bool settings accept true/false, max-backups must be a positive integer and
+default-auth-method must be ssh, https or "" (infer from --ssh-identity) and
+secret-backend must be file or keychain. Changing secret-backend moves the
+stored tokens to the OS keychain or back to the credentials file.
+
+Example:
+  gat config set auto-switch true
+  gat config set max-backups 5
+  gat config set default-auth-method ssh
+  gat config set secret-backend keychain`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value := args[0], args[1]
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if len(validationErrors) > 0 {
+			fmt.Println(color.YellowString("\n⚠️ Found configuration issues with some profiles (will be ignored):"))
+			for name, err := range validationErrors {
+				fmt.Printf(color.YellowString("   - Profile [%s]: %v\n"), name, err)
+			}
+			fmt.Println()
+		}
+
+		if err := config.SetSetting(&validConfig, key, value); err != nil {
+			return err
+		}
+
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		setting, _ := config.LookupSetting(key)
+		fmt.Printf("✅ %s = %s\n", setting.Key, color.CyanString(setting.Value(&validConfig)))
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(setConfigCmd)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	copyUsername    string
+	copyEmail       string
+	copyToken       string
+	copySSHIdentity string
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy <src> <dst>",
+	Short: "📄 Duplicate a profile under a new name",
+	Long: `📄 Creates the profile <dst> with every setting of <src>, then asks for the
+username, email, token and SSH identity of the copy, offering the values of
+<src>. Flags set them without asking; without a terminal, fields not given
+by a flag keep the values of <src>.
+
+The SSH identities are shared with <src> unless --ssh-identity is given, which
+replaces all of them; the keys themselves are not copied. The copy is not activated, even if <src> is active.
+
+Examples:
+  gat copy work work-oss
+  gat copy work work-oss --username alice-oss --email alice@oss.example.com`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dstName := args[1]
+		if err := config.ValidateProfileName(dstName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		srcName := config.ResolveAlias(&validConfig, args[0])
+		src, exists := validConfig.Profiles[srcName]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", srcName)
+		}
+		if _, exists := validConfig.Profiles[dstName]; exists {
+			return fmt.Errorf("❌ profile '%s' already exists; choose another name", dstName)
+		}
+
+		interactive := stdinIsTerminal()
+		profile := src
+		var err error
+
+		if profile.Username, err = copyField(cmd, "username", "Username", copyUsername, src.Username, interactive); err != nil {
+			return err
+		}
+		if !config.ValidGitHubUsernameRegex.MatchString(profile.Username) {
+			return fmt.Errorf("❌ invalid username format: '%s'", profile.Username)
+		}
+		if profile.Email, err = copyField(cmd, "email", "Email", copyEmail, src.Email, interactive); err != nil {
+			return err
+		}
+		identity, err := copyField(cmd, "ssh-identity", "SSH identity", copySSHIdentity, src.SSHIdentity(), interactive)
+		if err != nil {
+			return err
+		}
+		if identity != src.SSHIdentity() {
+			profile.SetSSHIdentity(ssh.CollapseIdentityPath(identity))
+			profile.SSHCertPath = "" // Signed for the key of <src>
+		}
+
+		// The token is asked for masked; empty keeps the token of <src>
+		newToken := copyToken
+		tokenChanged := cmd.Flags().Changed("token")
+		if !tokenChanged && interactive {
+			prompt := promptui.Prompt{Label: "Token (empty to keep the token of " + srcName + ")", Mask: '*'}
+			if newToken, err = prompt.Run(); err != nil {
+				return fmt.Errorf("❌ copy canceled")
+			}
+			tokenChanged = newToken != ""
+		}
+		if tokenChanged {
+			if newToken != "" {
+				if plat, err := platform.NewRegistry().GetPlatform(profile.GetPlatform()); err == nil {
+					if err := plat.ValidateToken(newToken); err != nil {
+						return err
+					}
+					if err := plat.CheckTokenPrefix(newToken); err != nil {
+						fmt.Println(color.YellowString("⚠️ Warning: %v", err))
+					}
+				}
+			}
+			profile.SetToken(newToken, validConfig.StoreEncrypted, validConfig.EncryptionSecret())
+		}
+
+		if profile.Username == src.Username && profile.Email == src.Email {
+			fmt.Println(color.YellowString("⚠️ '%s' has the same username and email as '%s'", dstName, srcName))
+		}
+
+		if err := config.AddProfile(&validConfig, dstName, profile, false); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		// The copy gets its own host alias in ~/.ssh/gat_config
+		if len(profile.SSHIdentities) > 0 && profile.AuthMethod == "ssh" {
+			if err := ssh.UpdateSSHConfig(profile.GetPlatform(), dstName, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+				fmt.Printf(color.YellowString("⚠️ Warning: Failed to update SSH config: %v\n"), err)
+			}
+		}
+
+		fmt.Printf("✅ Copied profile %s to %s (%s on %s, auth: %s)\n",
+			srcName,
+			color.GreenString(dstName),
+			color.CyanString(profile.Username),
+			color.MagentaString(profile.GetPlatform()),
+			color.BlueString(profile.AuthMethod))
+		fmt.Printf("\nℹ️ To use this profile, run: %s\n", color.YellowString("gat switch "+dstName))
+		return nil
+	},
+}
+
+// copyField returns the value of a field of the copied profile: the flag's
+// value if it was given, else what the user enters (offering current) on a
+// terminal, else current
+func copyField(cmd *cobra.Command, flagName, label, flagValue, current string, interactive bool) (string, error) {
+	if cmd.Flags().Changed(flagName) {
+		return flagValue, nil
+	}
+	if !interactive {
+		return current, nil
+	}
+
+	prompt := promptui.Prompt{
+		Label:     label,
+		Default:   current,
+		AllowEdit: true,
+	}
+	value, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("❌ copy canceled")
+	}
+	return value, nil
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+
+	copyCmd.Flags().StringVar(&copyUsername, "username", "", "Git username of the copy")
+	copyCmd.Flags().StringVar(&copyEmail, "email", "", "Git email of the copy")
+	copyCmd.Flags().StringVar(&copyToken, "token", "", "Personal access token of the copy (empty for none)")
+	copyCmd.Flags().StringVar(&copySSHIdentity, "ssh-identity", "", "Path to the SSH identity of the copy; '~' is supported")
+}
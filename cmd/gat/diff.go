@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/utils"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var diffChangedOnly bool
+
+// diffField is a compared field of a profile and its displayed value
+type diffField struct {
+	label string
+	value string
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff <profile1> <profile2>",
+	Short: "🔀 Compare two profiles field by field",
+	Long: `🔀 Shows how two profiles differ. Fields that differ are printed as a pair of
+lines, '-' for the first profile and '+' for the second; identical fields are
+printed in grey, or left out with --changed-only. Use '-' as a name for the
+active profile. Tokens are never printed, only whether one is set, and SSH
+keys are shown by file name.
+
+Examples:
+  gat diff work personal
+  gat diff - personal --changed-only`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		var names [2]string
+		var profiles [2]config.Profile
+		for i, arg := range args {
+			name, profile, err := diffProfile(&validConfig, validationErrors, arg)
+			if err != nil {
+				return err
+			}
+			names[i], profiles[i] = name, profile
+		}
+
+		left := diffFieldValues(names[0], profiles[0], validConfig.Current == names[0])
+		right := diffFieldValues(names[1], profiles[1], validConfig.Current == names[1])
+
+		fmt.Println(color.RedString("--- %s", names[0]))
+		fmt.Println(color.GreenString("+++ %s", names[1]))
+		changed := 0
+		for i, field := range left {
+			if field.value == right[i].value {
+				if !diffChangedOnly {
+					fmt.Println(color.HiBlackString("  %s: %s", field.label, diffPlainValue(field.value)))
+				}
+				continue
+			}
+			changed++
+			fmt.Println(color.RedString("- %s:", field.label), formatValue(field.value))
+			fmt.Println(color.GreenString("+ %s:", field.label), formatValue(right[i].value))
+		}
+
+		if changed == 0 {
+			fmt.Printf("✅ Profiles %s and %s are identical\n", color.GreenString(names[0]), color.GreenString(names[1]))
+		}
+		return nil
+	},
+}
+
+// diffProfile looks up the profile named by arg, resolving aliases and '-'
+// for the active profile
+func diffProfile(cfg *config.Config, validationErrors map[string]error, arg string) (string, config.Profile, error) {
+	if arg == "-" {
+		profile, name, err := config.GetCurrentProfile(cfg)
+		if err != nil {
+			return "", config.Profile{}, err
+		}
+		return name, *profile, nil
+	}
+
+	if err := config.ValidateProfileName(arg); err != nil {
+		return "", config.Profile{}, fmt.Errorf("❌ %v", err)
+	}
+	name := config.ResolveAlias(cfg, arg)
+	if validationErr, isInvalid := validationErrors[name]; isInvalid {
+		return "", config.Profile{}, fmt.Errorf("❌ profile '%s' failed validation: %v", name, validationErr)
+	}
+	profile, exists := cfg.Profiles[name]
+	if !exists {
+		return "", config.Profile{}, utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", name)
+	}
+	return name, profile, nil
+}
+
+// diffFieldValues returns the compared fields of a profile, in display order
+func diffFieldValues(name string, profile config.Profile, active bool) []diffField {
+	details := collectProfileDetails(name, profile, active)
+
+	token := ""
+	if details.Token == valuePresent || profile.IsPasswordProtected() {
+		token = "<set>"
+	}
+	identities := make([]string, len(details.SSHIdentities))
+	for i, identity := range details.SSHIdentities {
+		identities[i] = filepath.Base(identity)
+	}
+	certificate := ""
+	if details.SSHCertificate != "" {
+		certificate = filepath.Base(details.SSHCertificate)
+	}
+
+	return []diffField{
+		{label: "Username", value: details.Username},
+		{label: "Email", value: details.Email},
+		{label: "Platform", value: details.Platform},
+		{label: "Host", value: details.Host},
+		{label: "Auth Method", value: details.AuthMethod},
+		{label: "Token", value: token},
+		{label: "SSH Identity", value: strings.Join(identities, ", ")},
+		{label: "SSH Certificate", value: certificate},
+		{label: "Working Directory", value: details.WorkingDirectory},
+		{label: "GNUPGHOME", value: details.GnupgHome},
+		{label: "GPG Key", value: details.GPGKey},
+		{label: "Description", value: details.Description},
+		{label: "Tags", value: strings.Join(details.Tags, ", ")},
+	}
+}
+
+// diffPlainValue is formatValue without color, for lines printed in grey
+func diffPlainValue(value string) string {
+	if value == "" {
+		return "<not set>"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().BoolVar(&diffChangedOnly, "changed-only", false, "Only show the fields that differ")
+}
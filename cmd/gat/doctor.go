@@ -2,24 +2,55 @@ package main
 
 import (
 	"fmt"
+	"gat/pkg/api/server"
 	"gat/pkg/config"
 	"gat/pkg/git"
 	"gat/pkg/platform"
 	"gat/pkg/ssh"
+	"gat/pkg/utils"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	doctorVerbose bool
+	doctorProfile string
+	doctorFix     bool
+)
+
+// doctorFixes counts the outcomes of 'gat doctor --fix' for its summary
+var doctorFixes struct {
+	fixed, failed, manual int
+}
+
 var doctorCmd = &cobra.Command{
 	Use:   "doctor",
 	Short: "🩺 Diagnose Git configuration issues",
-	Long:  `🩺 Diagnose Git configuration issues and provides solutions.`,
+	Long: `🩺 Diagnose Git configuration issues and provides solutions.
+
+Use --profile <name> to check a single profile instead of all of them.
+
+With --fix, issues gat can remediate are fixed right after they are reported:
+config and SSH file permissions, a missing SSH config or Include line, host
+aliases missing from ~/.ssh/gat_config, an unset or invalid active profile and
+a stale API server PID file. Each fix is safe to run again. Issues that need a
+decision or a missing file (such as an SSH key that does not exist or a token
+that cannot be decrypted) are marked as needing manual intervention.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorProfile != "" {
+			if err := config.ValidateProfileName(doctorProfile); err != nil {
+				return fmt.Errorf("❌ %v", err)
+			}
+		}
+
 		// Main title
 		fmt.Println(color.CyanString("🩺 Git Account Doctor"))
 		fmt.Println(color.CyanString("==================="))
@@ -51,10 +82,16 @@ var doctorCmd = &cobra.Command{
 			fmt.Printf("  Current Repository: %s\n", color.GreenString("✓"))
 			fmt.Printf("  Remote URL: %s\n", formatValue(identity["remote_url"]))
 			fmt.Printf("  Protocol: %s\n", formatValue(identity["protocol"]))
+			reportRemotes()
 		} else {
 			fmt.Printf("  Current Repository: %s (not in a Git repository)\n", color.YellowString("⚠️"))
 		}
 
+		// Map the Git identity back to the gat profiles that own it
+		if identity["username"] != "" {
+			reportIdentityProfiles(identity["username"], identity["remote_url"])
+		}
+
 		// Load config file
 		fmt.Println("\n" + color.YellowString("🔍 Configuration:"))
 		// Load configuration and explicitly handle validation errors
@@ -65,12 +102,28 @@ var doctorCmd = &cobra.Command{
 			return ioErr // Exit early
 		}
 
+		// The profile checks below can be limited to a single profile
+		if doctorProfile != "" {
+			doctorProfile = config.ResolveAlias(&validConfig, doctorProfile)
+			_, isValid := validConfig.Profiles[doctorProfile]
+			_, isInvalid := validationErrors[doctorProfile]
+			if !isValid && !isInvalid {
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", doctorProfile)
+			}
+		}
+
 		// Check config path
 		configPath, err := config.ConfigFilePath()
 		if err != nil {
 			return err
 		}
 		fmt.Printf("  Config File: %s\n", configPath)
+		if backupPath := config.LastLoadBackup(); backupPath != "" {
+			fmt.Printf("  %s Config file is corrupt; loaded from backup %s\n", color.RedString("⚠️"), backupPath)
+			fmt.Printf("  %s Run 'cp %s %s' to restore it, or set %s=1 to disable automatic recovery\n",
+				color.YellowString("💡"), backupPath, configPath, config.NoAutoRecoverEnvVar)
+			manualFix("  ")
+		}
 
 		// Check file permissions
 		if info, err := os.Stat(configPath); err == nil {
@@ -78,6 +131,9 @@ var doctorCmd = &cobra.Command{
 			if mode&0077 != 0 {
 				fmt.Printf("  %s Config file permissions are too open: %s\n", color.RedString("⚠️"), mode)
 				fmt.Printf("  %s Run 'chmod 600 %s' to secure your config\n", color.YellowString("💡"), configPath)
+				applyFix("  ", "restrict permissions of "+configPath, func() error {
+					return config.EnsureSecurePermissions(configPath)
+				})
 			} else {
 				fmt.Printf("  File Permissions: %s\n", color.GreenString("✓"))
 			}
@@ -85,9 +141,14 @@ var doctorCmd = &cobra.Command{
 
 		// Check config security settings from the loaded (potentially partial) config
 		fmt.Printf("  Tokens Stored: %s\n", formatBool(!validConfig.NoStoreTokens))
+		fmt.Printf("  Secret Backend: %s\n", formatValue(utils.Ternary(validConfig.SecretBackend == "", config.SecretBackendFile, validConfig.SecretBackend)))
 		fmt.Printf("  Token Encryption: %s\n", formatBool(validConfig.StoreEncrypted))
+		if validConfig.StoreEncrypted {
+			fmt.Printf("  Password Protected: %s\n", formatBool(validConfig.UsePasswordEncryption))
+		}
+		fmt.Printf("  Identity Scope: %s\n", utils.Ternary(validConfig.PreferLocalScope, "local", "global"))
 
-		if !validConfig.StoreEncrypted && !validConfig.NoStoreTokens {
+		if !validConfig.StoreEncrypted && !validConfig.NoStoreTokens && validConfig.SecretBackend != config.SecretBackendKeychain {
 			fmt.Printf("  %s Tokens are stored in plaintext\n", color.RedString("⚠️"))
 			fmt.Printf("  %s Consider enabling encryption or not storing tokens\n", color.YellowString("💡"))
 		}
@@ -107,11 +168,43 @@ var doctorCmd = &cobra.Command{
 				currentProfileStatus = fmt.Sprintf("%s (invalid or not found)", color.RedString(validConfig.Current))
 			}
 			fmt.Printf("  Current: %s\n", currentProfileStatus)
+			if _, exists := validConfig.Profiles[validConfig.Current]; !exists && len(validConfig.Profiles) > 0 {
+				first := config.ListProfileNames(&validConfig)[0]
+				applyFix("  ", "set the active profile to '"+first+"'", func() error {
+					if err := config.SwitchProfile(&validConfig, first); err != nil {
+						return err
+					}
+					return config.SaveConfig(&validConfig)
+				})
+				if doctorFix && validConfig.Current == first {
+					fmt.Printf("  %s Run 'gat switch %s' to apply its Git identity\n", color.YellowString("💡"), first)
+				}
+			}
+			if active, exists := validConfig.Profiles[validConfig.Current]; exists {
+				reportIdentityConflict(validConfig.Current, active, identity["username"], identity["email"])
+			}
+
+			// Report which profile this repository resolves to and why
+			if resolved, source, err := resolveRepoProfile(); err != nil {
+				fmt.Printf("  %s Could not resolve the repository profile: %v\n", color.RedString("⚠️"), err)
+			} else if source != git.ProfileSourceGlobal {
+				fmt.Printf("  Repository Profile: %s (%s)\n", formatValue(resolved), git.DescribeProfileSource(source))
+				if _, exists := validConfig.Profiles[resolved]; !exists {
+					fmt.Printf("  %s Profile '%s' does not exist or is invalid\n", color.RedString("⚠️"), resolved)
+				} else if resolved != validConfig.Current {
+					fmt.Printf("  %s Run 'gat switch' to apply it\n", color.YellowString("💡"))
+				}
+			}
+			if doctorProfile != "" {
+				fmt.Printf("  Checking Profile: %s\n", formatValue(doctorProfile))
+			}
 
 			// Get a sorted list of valid profile names
 			var profileNames []string
 			for name := range validConfig.Profiles {
-				profileNames = append(profileNames, name)
+				if doctorProfile == "" || name == doctorProfile {
+					profileNames = append(profileNames, name)
+				}
 			}
 			sort.Strings(profileNames)
 
@@ -129,6 +222,12 @@ var doctorCmd = &cobra.Command{
 				fmt.Printf("\n  Profile: %s\n", color.GreenString(name))
 				fmt.Printf("    Username: %s\n", formatValue(profile.Username))
 				fmt.Printf("    Email: %s\n", formatValue(profile.Email))
+				if strict, err := config.ValidateEmail(profile.Email); err != nil {
+					fmt.Printf("    %s %v\n", color.YellowString("⚠️"), err)
+				} else if !strict {
+					fmt.Printf("    %s %v\n", color.YellowString("⚠️"), config.StrictEmailError(profile.Email))
+					fmt.Printf("    %s Some servers may reject commits with this email; update it with 'gat add %s --email <email> --overwrite'\n", color.YellowString("💡"), name)
+				}
 
 				// Platform info
 				platformID := profile.Platform // Already normalized
@@ -138,6 +237,7 @@ var doctorCmd = &cobra.Command{
 				if !platformIDs[platformID] {
 					fmt.Printf("    %s Unknown platform '%s'\n", color.RedString("⚠️"), platformID)
 					fmt.Printf("    %s Add this platform using 'gat platforms register ...'\n", color.YellowString("💡"))
+					manualFix("    ")
 				}
 
 				// Check host info
@@ -161,76 +261,166 @@ var doctorCmd = &cobra.Command{
 				// Auth Method
 				fmt.Printf("    Auth Method: %s\n", formatValue(profile.AuthMethod))
 
-				// Token info (securely)
+				if len(profile.Tags) > 0 {
+					fmt.Printf("    Tags: %s\n", formatValue(strings.Join(profile.Tags, ", ")))
+				}
+
+				// Token info (securely); platforms with a credential helper
+				// need the helper's program instead of a token
 				hasToken := profile.GetToken() != ""
-				if profile.AuthMethod == "https" {
+				plat, _ := reg.GetPlatform(platformID)
+				if plat != nil && plat.CredentialHelper != "" {
+					fmt.Printf("    Credential Helper: %s\n", formatValue(plat.CredentialHelper))
+					if profile.AuthMethod != "https" {
+						fmt.Printf("    %s %s profiles must use HTTPS\n", color.RedString("⚠️"), plat.Name)
+						fmt.Printf("    %s Switch it with 'gat add %s --auth-method https --overwrite'\n", color.YellowString("💡"), name)
+						manualFix("    ")
+					}
+					if program := plat.CredentialHelperProgram(); program != "" {
+						if _, err := exec.LookPath(program); err != nil {
+							fmt.Printf("    %s Credential helper program '%s' not found on PATH\n", color.RedString("⚠️"), program)
+							fmt.Printf("    %s Install it (the AWS CLI for CodeCommit) and make sure it is on PATH\n", color.YellowString("💡"))
+							manualFix("    ")
+						}
+					}
+				} else if profile.AuthMethod == "https" {
 					fmt.Printf("    Token: %s\n", formatBool(hasToken))
 					if !hasToken {
 						fmt.Printf("    %s HTTPS profile has no token configured\n", color.YellowString("⚠️"))
 						fmt.Printf("    %s Add token using 'gat add %s --token <token> --overwrite'\n", color.YellowString("💡"), name)
+						manualFix("    ")
 					}
 				} else {
 					fmt.Printf("    Token: %s\n", color.CyanString("-")) // Not applicable
 				}
+				if profile.TokenExpiry != nil {
+					fmt.Printf("    Token Expiry: %s\n", formatTokenExpiry(profile))
+					if profile.TokenExpired() {
+						fmt.Printf("    %s Token has expired\n", color.RedString("❌"))
+					} else if profile.TokenExpirySoon() {
+						fmt.Printf("    %s Token expires within %d days\n", color.YellowString("⚠️"), int(config.TokenExpiryWarning.Hours()/24))
+					}
+					if profile.TokenExpired() || profile.TokenExpirySoon() {
+						fmt.Printf("    %s Rotate it with 'gat add %s --token <token> --token-expiry <date> --overwrite'\n", color.YellowString("💡"), name)
+						manualFix("    ")
+					}
+				}
 
 				// SSH identity info
-				hasSSH := profile.SSHIdentity != ""
+				hasSSH := len(profile.SSHIdentities) > 0
 				if profile.AuthMethod == "ssh" {
-					fmt.Printf("    SSH Identity: %s\n", formatSSHIdentity(profile.SSHIdentity, hasSSH))
+					fmt.Printf("    SSH Identity: %s\n", formatSSHIdentity(strings.Join(profile.SSHIdentities, ", "), hasSSH))
 
 					// Check if SSH identity exists
 					if hasSSH {
-						exists, err := ssh.CheckSSHIdentity(profile.SSHIdentity)
+						missing, err := ssh.CheckSSHIdentities(profile.SSHIdentities, profile.SSHCertPath)
 						if err != nil {
 							fmt.Printf("    %s Could not check SSH identity: %v\n", color.RedString("⚠️"), err)
-						} else if !exists {
-							fmt.Printf("    %s SSH identity file not found: %s\n", color.RedString("⚠️"), profile.SSHIdentity)
+						} else if missing == profile.SSHIdentity() && profile.SSHCertPath != "" {
+							fmt.Printf("    %s SSH identity, public key or certificate not found: %s\n", color.RedString("⚠️"), missing)
+							fmt.Printf("    %s Make sure the SSH key and certificate exist or update the profile\n", color.YellowString("💡"))
+							manualFix("    ")
+						} else if missing != "" {
+							fmt.Printf("    %s SSH identity file not found: %s\n", color.RedString("⚠️"), missing)
 							fmt.Printf("    %s Make sure the SSH key exists or update the profile\n", color.YellowString("💡"))
+							manualFix("    ")
+						} else {
+							for _, identity := range profile.SSHIdentities {
+								if err := ssh.ValidateIdentityPermissions(identity); err != nil {
+									fmt.Printf("    %s\n", color.RedString(err.Error()))
+									applyFix("    ", "restrict permissions of "+identity, func() error {
+										return ssh.SecureIdentityPermissions(identity)
+									})
+								}
+								if doctorVerbose {
+									reportPublicKey(identity)
+								}
+							}
+						}
+						if profile.SSHCertPath != "" {
+							reportCertificate(profile.SSHCertPath)
+						}
+						if shared, err := config.ProfilesSharingSSHIdentity(&validConfig, name, profile); err != nil {
+							fmt.Printf("    %s Could not compare SSH identities: %v\n", color.YellowString("⚠️"), err)
+						} else if len(shared) > 0 {
+							fmt.Printf("    %s SSH identity is shared with profile(s) on the same platform: %s\n", color.RedString("⚠️"), strings.Join(shared, ", "))
+							fmt.Printf("    %s SSH auth may use the wrong account; use a separate key per profile\n", color.YellowString("💡"))
+							manualFix("    ")
 						}
 					} else {
 						fmt.Printf("    %s SSH profile has no identity path configured\n", color.YellowString("⚠️"))
 						fmt.Printf("    %s Add identity using 'gat add %s --ssh-identity <path> --overwrite'\n", color.YellowString("💡"), name)
+						manualFix("    ")
 					}
 				} else {
 					fmt.Printf("    SSH Identity: %s\n", color.CyanString("-")) // Not applicable
 				}
+
+				// GPG keyring override
+				gnupgHome, gnupgHomeErr := profile.GnupgHomePath()
+				if gnupgHomeErr != nil {
+					fmt.Printf("    %s Could not resolve GNUPGHOME: %v\n", color.RedString("⚠️"), gnupgHomeErr)
+				} else if profile.GnupghomeOverride != "" {
+					fmt.Printf("    GNUPGHOME: %s\n", formatValue(profile.GnupghomeOverride))
+					if info, err := os.Stat(gnupgHome); err != nil || !info.IsDir() {
+						fmt.Printf("    %s GNUPGHOME directory not found: %s\n", color.RedString("⚠️"), profile.GnupghomeOverride)
+						fmt.Printf("    %s Create the keyring directory or update it with 'gat add %s --gnupghome <path> --overwrite'\n", color.YellowString("💡"), name)
+					}
+				}
+
+				// GPG signing key
+				if profile.GPGKey != "" && gnupgHomeErr == nil {
+					fmt.Printf("    GPG Key: %s\n", formatValue(profile.GPGKey))
+					found, err := git.GPGKeyExists(profile.GPGKey, gnupgHome)
+					if err != nil {
+						fmt.Printf("    %s Could not check the GPG key: %v\n", color.YellowString("⚠️"), err)
+					} else if !found {
+						fmt.Printf("    %s No secret key '%s' in the GPG keyring; commits cannot be signed\n", color.RedString("⚠️"), profile.GPGKey)
+						fmt.Printf("    %s Import the key or update it with 'gat add %s --gpg-key <id> --overwrite'\n", color.YellowString("💡"), name)
+						manualFix("    ")
+					}
+				}
 			}
 		}
 
 		// Report validation errors
-		if len(validationErrors) > 0 {
-			fmt.Println("\n" + color.RedString("🔍 Invalid Profiles Found:"))
-			// Sort error names for consistent output
-			var invalidNames []string
-			for name := range validationErrors {
+		// Sort error names for consistent output
+		var invalidNames []string
+		for name := range validationErrors {
+			if doctorProfile == "" || name == doctorProfile {
 				invalidNames = append(invalidNames, name)
 			}
-			sort.Strings(invalidNames)
+		}
+		sort.Strings(invalidNames)
 
+		if len(invalidNames) > 0 {
+			fmt.Println("\n" + color.RedString("🔍 Invalid Profiles Found:"))
 			for _, name := range invalidNames {
 				err := validationErrors[name]
 				fmt.Printf("  Profile: %s\n", color.RedString(name))
 				fmt.Printf("    Error: %v\n", err)
 				fmt.Printf("    %s This profile cannot be used until fixed. Try removing and re-adding.\n", color.YellowString("💡"))
+				manualFix("    ")
 			}
 		}
 
 		// SSH configuration
 		fmt.Println("\n" + color.YellowString("🔍 SSH Configuration:"))
-		homeDir, err := os.UserHomeDir()
+		sshDir, err := utils.ExpandHome("~/.ssh")
 		if err != nil {
 			return err
 		}
 
 		// Check SSH config files
-		sshConfigPath := filepath.Join(homeDir, ".ssh", "config")
-		gatConfigPath := filepath.Join(homeDir, ".ssh", "gat_config")
+		sshConfigPath := filepath.Join(sshDir, "config")
+		gatConfigPath := filepath.Join(sshDir, "gat_config")
 
 		// Main SSH config
 		_, err = os.Stat(sshConfigPath)
 		if os.IsNotExist(err) {
 			fmt.Printf("  %s SSH config file not found\n", color.RedString("⚠️"))
 			fmt.Printf("  %s Run 'gat switch <profile> --ssh' to create it\n", color.YellowString("💡"))
+			applyFix("  ", "create "+sshConfigPath+" including gat_config", ssh.EnsureGatInclude)
 		} else if err != nil {
 			fmt.Printf("  %s Could not check SSH config: %v\n", color.RedString("⚠️"), err)
 		} else {
@@ -246,6 +436,7 @@ var doctorCmd = &cobra.Command{
 				} else {
 					fmt.Printf("  %s SSH config does not include gat_config\n", color.RedString("⚠️"))
 					fmt.Printf("  %s Add 'Include ~/.ssh/gat_config' to your SSH config\n", color.YellowString("💡"))
+					applyFix("  ", "add 'Include ~/.ssh/gat_config' to "+sshConfigPath, ssh.EnsureGatInclude)
 				}
 			}
 		}
@@ -255,6 +446,9 @@ var doctorCmd = &cobra.Command{
 		if os.IsNotExist(err) {
 			fmt.Printf("  %s gat SSH config file not found\n", color.RedString("⚠️"))
 			fmt.Printf("  %s Run 'gat switch <profile> --ssh' to create it\n", color.YellowString("💡"))
+			applyFix("  ", "write the host aliases of SSH profiles to "+gatConfigPath, func() error {
+				return writeHostAliases(&validConfig)
+			})
 		} else if err != nil {
 			fmt.Printf("  %s Could not check gat SSH config: %v\n", color.RedString("⚠️"), err)
 		} else {
@@ -266,12 +460,31 @@ var doctorCmd = &cobra.Command{
 				if mode&0077 != 0 {
 					fmt.Printf("  %s gat SSH config permissions are too open: %s\n", color.RedString("⚠️"), mode)
 					fmt.Printf("  %s Run 'chmod 600 %s' to secure your config\n", color.YellowString("💡"), gatConfigPath)
+					applyFix("  ", "restrict permissions of "+gatConfigPath, func() error {
+						return config.EnsureSecurePermissions(gatConfigPath)
+					})
 				} else {
 					fmt.Printf("  File Permissions: %s\n", color.GreenString("✓"))
 				}
 			}
 		}
 
+		// Profiles whose Host blocks would collide
+		for _, duplicate := range config.DetectDuplicateHosts(&validConfig) {
+			fmt.Printf("  %s SSH host alias '%s' is shared by profiles: %s\n", color.RedString("⚠️"), duplicate.Alias, strings.Join(duplicate.Profiles, ", "))
+			fmt.Printf("  %s Only the first Host block applies; rename all but one of them with 'gat rename'\n", color.YellowString("💡"))
+			manualFix("  ")
+		}
+
+		// Key files in ~/.ssh and the profiles that use them
+		if doctorVerbose {
+			reportSSHKeyFiles(&validConfig, sshDir)
+		}
+
+		// Background API server started with 'gat serve --daemon'
+		fmt.Println("\n" + color.YellowString("🔍 API Server:"))
+		reportDaemon()
+
 		// Final summary
 		fmt.Println("\n" + color.YellowString("🔍 Summary:"))
 		reg := platform.NewRegistry() // Initialize registry for use in summary
@@ -294,11 +507,269 @@ var doctorCmd = &cobra.Command{
 				fmt.Printf("  %s Active profile '%s' is invalid. Switch to a valid profile using 'gat switch <name>'.\n", color.RedString("⚠️"), validConfig.Current)
 			}
 		}
+		if doctorFix {
+			fmt.Printf("  🔧 Fixed: %d, failed: %d, needs manual intervention: %d\n", doctorFixes.fixed, doctorFixes.failed, doctorFixes.manual)
+		}
 
 		return nil
 	},
 }
 
+// applyFix runs the remediation of an issue just reported when --fix is set
+// and reports the outcome. fix must do nothing harmful when run again.
+func applyFix(indent, description string, fix func() error) {
+	if !doctorFix {
+		return
+	}
+	if err := fix(); err != nil {
+		doctorFixes.failed++
+		fmt.Printf("%s%s Could not %s: %v\n", indent, color.RedString("🔧"), description, err)
+		return
+	}
+	doctorFixes.fixed++
+	fmt.Printf("%s%s Fixed: %s\n", indent, color.GreenString("🔧"), description)
+}
+
+// manualFix marks an issue just reported as one --fix cannot remediate
+func manualFix(indent string) {
+	if !doctorFix {
+		return
+	}
+	doctorFixes.manual++
+	fmt.Printf("%s%s Needs manual intervention\n", indent, color.YellowString("✋"))
+}
+
+// writeHostAliases writes the Host block of every SSH profile to
+// ~/.ssh/gat_config, like 'gat ssh --strategy host-alias' without the reset
+func writeHostAliases(cfg *config.Config) error {
+	for _, name := range config.ListProfileNames(cfg) {
+		profile := cfg.Profiles[name]
+		if profile.AuthMethod != "ssh" || len(profile.SSHIdentities) == 0 {
+			continue
+		}
+		if err := ssh.UpdateSSHConfig(profile.GetPlatform(), name, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportRemotes lists every remote of the current repository and flags
+// those that don't match the active profile's auth method
+func reportRemotes() {
+	remotes, err := git.GetRemoteURLs()
+	if err != nil {
+		fmt.Printf("  %s Could not list remotes: %v\n", color.RedString("⚠️"), err)
+		return
+	}
+	if len(remotes) == 0 {
+		fmt.Printf("  %s No remotes configured\n", color.YellowString("⚠️"))
+		return
+	}
+
+	var profile config.Profile
+	var profileName string
+	if configDir, err := config.ConfigPath(); err == nil {
+		configManager := config.NewManager(configDir)
+		if profiles, _, err := configManager.GetProfiles(); err == nil {
+			profileName = configManager.GetCurrent()
+			profile = profiles[profileName]
+		}
+	}
+
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("  Remotes:")
+	mismatched := false
+	for _, name := range names {
+		url := remotes[name]
+		protocol := utils.Ternary(git.IsSSHRemote(url), "SSH", "HTTPS")
+		if slices.Contains(profile.MirrorRemotes, name) {
+			protocol += ", mirror"
+		}
+		fmt.Printf("    %s: %s (%s)\n", name, url, protocol)
+
+		if profile.AuthMethod == "" {
+			continue // No active profile to compare against
+		}
+		if isProfileSSH, _, aliasProfile := git.IsProfileSSHRemote(url); isProfileSSH && aliasProfile != profileName {
+			fmt.Printf("      %s Uses the SSH host alias of profile '%s', not the active profile '%s'\n", color.YellowString("⚠️"), aliasProfile, profileName)
+			mismatched = true
+		} else if (profile.AuthMethod == "ssh") != git.IsSSHRemote(url) {
+			fmt.Printf("      %s Active profile '%s' uses %s auth\n", color.YellowString("⚠️"), profileName, strings.ToUpper(profile.AuthMethod))
+			mismatched = true
+		}
+	}
+	for _, name := range profile.MirrorRemotes {
+		if _, exists := remotes[name]; !exists {
+			fmt.Printf("  %s Mirror remote '%s' of profile '%s' does not exist in this repository\n", color.YellowString("⚠️"), name, profileName)
+		}
+	}
+	if mismatched {
+		fmt.Printf("  %s Run 'gat switch %s' to rewrite origin and mirror remotes, or 'git remote set-url <name> <url>' for other remotes\n", color.YellowString("💡"), profileName)
+	}
+}
+
+// reportDaemon prints whether a background API server is running
+func reportDaemon() {
+	configDir, err := config.ConfigPath()
+	if err != nil {
+		fmt.Printf("  %s Could not find config directory: %v\n", color.RedString("⚠️"), err)
+		return
+	}
+	info, running, err := server.DaemonStatus(configDir)
+	switch {
+	case err != nil:
+		fmt.Printf("  %s Could not read PID file: %v\n", color.RedString("⚠️"), err)
+	case running:
+		fmt.Printf("  Daemon: %s (PID %d, %s)\n", color.GreenString("running"), info.PID, info.URL())
+	case info.PID != 0:
+		fmt.Printf("  %s Daemon not running but %s still exists\n", color.YellowString("⚠️"), server.PIDFilePath(configDir))
+		fmt.Printf("  %s Run 'gat serve --stop' to clean it up or 'gat serve --daemon' to restart the server\n", color.YellowString("💡"))
+		applyFix("  ", "remove "+server.PIDFilePath(configDir), func() error {
+			return server.RemovePIDFile(configDir)
+		})
+	default:
+		fmt.Println("  Daemon: not running")
+	}
+}
+
+// reportIdentityProfiles prints which profiles match the global Git username,
+// inferring the platform from the repository remote (GitHub if unknown)
+func reportIdentityProfiles(username, remoteURL string) {
+	identityPlatform := "github"
+	if remoteURL != "" {
+		if remote, err := platform.ParseRemoteURL(remoteURL); err == nil {
+			if plat, err := platform.NewRegistry().GetPlatformByHost(remote.Host); err == nil {
+				identityPlatform = plat.ID
+			}
+		}
+	}
+
+	configDir, err := config.ConfigPath()
+	if err != nil {
+		return
+	}
+	_, names, err := config.NewManager(configDir).GetProfilesByUsername(username, identityPlatform)
+	if err != nil {
+		fmt.Printf("  %s Could not match identity to profiles: %v\n", color.RedString("⚠️"), err)
+		return
+	}
+
+	switch len(names) {
+	case 0:
+		fmt.Printf("  %s Git username '%s' does not match any %s profile\n", color.YellowString("⚠️"), username, identityPlatform)
+	case 1:
+		fmt.Printf("  Matching Profile: %s\n", color.GreenString(names[0]))
+	default:
+		fmt.Printf("  Matching Profiles: %s\n", color.YellowString(strings.Join(names, ", ")))
+		fmt.Printf("  %s Several profiles share this username on %s\n", color.YellowString("💡"), identityPlatform)
+	}
+}
+
+// reportIdentityConflict warns when the Git identity differs from the active
+// profile and, inside a repository, suggests the profile to switch to
+func reportIdentityConflict(activeName string, active config.Profile, username, email string) {
+	if username == active.Username && strings.EqualFold(email, active.Email) {
+		return
+	}
+	fmt.Printf("  %s Git identity '%s <%s>' does not match the active profile '%s' (%s <%s>)\n",
+		color.YellowString("⚠️"), username, email, activeName, active.Username, active.Email)
+
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		fmt.Printf("  %s Run 'gat switch %s' to apply the active profile\n", color.YellowString("💡"), activeName)
+		return
+	}
+	suggested, reason, err := git.ResolveConflictingIdentity(repoRoot)
+	if err != nil {
+		fmt.Printf("  %s Could not suggest a profile: %v\n", color.RedString("⚠️"), err)
+		return
+	}
+	if suggested == "" {
+		suggested = activeName
+		reason = "active profile"
+	}
+	fmt.Printf("  %s Suggested profile: %s (%s). Run: %s\n",
+		color.YellowString("💡"), color.GreenString(suggested), reason, color.CyanString("gat switch "+suggested))
+}
+
+// reportSSHKeyFiles lists the private keys found in sshDir alongside the
+// profiles whose SSH identity is each key
+func reportSSHKeyFiles(cfg *config.Config, sshDir string) {
+	keys, err := ssh.ListSSHKeyFiles(sshDir)
+	if err != nil {
+		fmt.Printf("  %s Could not list SSH keys: %v\n", color.RedString("⚠️"), err)
+		return
+	}
+	if len(keys) == 0 {
+		fmt.Printf("  SSH Keys: %s\n", color.CyanString("none found"))
+		return
+	}
+
+	var names []string
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("  SSH Keys:")
+	for _, key := range keys {
+		var users []string
+		for _, name := range names {
+			for _, identity := range cfg.Profiles[name].SSHIdentities {
+				if expanded, err := ssh.ExpandIdentityPath(identity); err == nil && expanded == key {
+					users = append(users, name)
+					break
+				}
+			}
+		}
+		usedBy := color.CyanString("unused")
+		if len(users) > 0 {
+			usedBy = color.GreenString(strings.Join(users, ", "))
+		}
+		fmt.Printf("    %s: %s\n", ssh.CollapseIdentityPath(key), usedBy)
+	}
+}
+
+// reportPublicKey prints a preview of an identity's public key and offers to
+// copy it to the clipboard when running interactively
+func reportPublicKey(sshIdentity string) {
+	publicKey, err := ssh.GetPublicKeyContent(sshIdentity)
+	if err != nil {
+		fmt.Printf("    %s Could not read public key: %v\n", color.RedString("⚠️"), err)
+		return
+	}
+
+	preview := publicKey
+	if len(preview) > 40 {
+		preview = preview[:40] + "..."
+	}
+	fmt.Printf("    Public Key: %s\n", color.CyanString(preview))
+
+	// Only prompt when a user can answer
+	fileInfo, _ := os.Stdin.Stat()
+	if fileInfo.Mode()&os.ModeCharDevice == 0 {
+		return
+	}
+
+	fmt.Print("    Copy to clipboard? [y/N]: ")
+	var input string
+	fmt.Scanln(&input)
+	if !strings.EqualFold(input, "y") && !strings.EqualFold(input, "yes") {
+		return
+	}
+	if err := utils.CopyToClipboard(publicKey); err != nil {
+		fmt.Printf("    %s %v\n", color.RedString("⚠️"), err)
+		return
+	}
+	fmt.Printf("    %s Public key copied to clipboard\n", color.GreenString("📋"))
+}
+
 // getPlatformID is a helper to get the platform ID from a profile
 func getPlatformID(profile config.Profile) string {
 	if profile.Platform == "" {
@@ -307,6 +778,28 @@ func getPlatformID(profile config.Profile) string {
 	return profile.Platform
 }
 
+// reportCertificate prints an SSH certificate's validity period and warns
+// when it has expired or expires within a week
+func reportCertificate(certPath string) {
+	fmt.Printf("    SSH Certificate: %s\n", formatValue(certPath))
+	validity, expires, err := ssh.GetCertificateValidity(certPath)
+	if err != nil {
+		fmt.Printf("    %s %v\n", color.RedString("⚠️"), err)
+		return
+	}
+	fmt.Printf("    Certificate Valid: %s\n", formatValue(validity))
+
+	if expires.IsZero() {
+		return
+	}
+	if remaining := time.Until(expires); remaining <= 0 {
+		fmt.Printf("    %s SSH certificate expired on %s\n", color.RedString("⚠️"), expires.Format("2006-01-02 15:04"))
+		fmt.Printf("    %s Ask your CA to sign the key again\n", color.YellowString("💡"))
+	} else if remaining < 7*24*time.Hour {
+		fmt.Printf("    %s SSH certificate expires on %s\n", color.YellowString("⚠️"), expires.Format("2006-01-02 15:04"))
+	}
+}
+
 // formatValue formats a value for display, handling empty strings
 func formatValue(value string) string {
 	if value == "" {
@@ -333,4 +826,9 @@ func formatSSHIdentity(path string, hasSSH bool) string {
 
 func init() {
 	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show extra details such as SSH public key previews")
+	doctorCmd.Flags().StringVar(&doctorProfile, "profile", "", "Only check the named profile")
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Fix the issues that can be fixed automatically after reporting them")
+	doctorCmd.RegisterFlagCompletionFunc("profile", completeProfileFlag)
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/utils"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	envProfile      string
+	envShell        string
+	envIncludeToken bool
+)
+
+// envShells are the shells 'gat env --shell' writes statements for, mapped
+// to the shell names of config.ExportStatement
+var envShells = map[string]string{
+	"bash":       "sh",
+	"zsh":        "sh",
+	"fish":       "fish",
+	"powershell": "powershell",
+}
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env [--profile <name>]",
+	Short: "🌱 Print shell statements that apply a profile's environment",
+	Long: `🌱 Prints the statements that set a profile's Git identity in a shell:
+GIT_AUTHOR_NAME, GIT_AUTHOR_EMAIL, GIT_COMMITTER_NAME, GIT_COMMITTER_EMAIL,
+GNUPGHOME if the profile overrides it, and GAT_PROFILE, which 'gat switch'
+without a name and 'gat status' then pick up. Without --profile the active
+profile is used.
+
+The token is only printed with --include-token, as GAT_TOKEN, GH_TOKEN or
+GITLAB_TOKEN and a Git credential helper reading it (see 'gat exec').
+
+Examples:
+  eval $(gat env --profile work)
+  gat env --profile work --shell fish | source
+  gat env --profile work --shell powershell | Out-String | Invoke-Expression`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shell, supported := envShells[strings.ToLower(envShell)]
+		if !supported {
+			return fmt.Errorf("❌ unsupported shell '%s'; use bash, zsh, fish or powershell", envShell)
+		}
+		config.WarningOutput = os.Stderr // Keep warnings out of eval
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		var profile config.Profile
+		var profileName string
+		if envProfile != "" {
+			profileName = config.ResolveAlias(&validConfig, envProfile)
+			var exists bool
+			if profile, exists = validConfig.Profiles[profileName]; !exists {
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+			}
+		} else {
+			current, name, err := config.GetCurrentProfile(&validConfig)
+			if err != nil {
+				return err
+			}
+			profile, profileName = *current, name
+		}
+
+		if envIncludeToken {
+			if err := config.UnlockProfileToken(profileName, &profile); err != nil {
+				return err
+			}
+		} else {
+			profile.SetToken("", false, "")
+		}
+
+		// The credential helper continues the user's GIT_CONFIG_COUNT entries
+		// rather than replacing them
+		var base []string
+		if count, set := os.LookupEnv("GIT_CONFIG_COUNT"); set && envIncludeToken {
+			base = []string{"GIT_CONFIG_COUNT=" + count}
+		}
+		vars, err := git.ProfileEnv(&profile, base)
+		if err != nil {
+			return err
+		}
+		vars = append(vars, git.ProfileEnvVar+"="+profileName)
+
+		// Each statement ends with ';' since an unquoted $(gat env) joins the lines
+		for _, variable := range vars {
+			name, value, _ := strings.Cut(variable, "=")
+			fmt.Println(config.ExportStatement(shell, name, value) + ";")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	envCmd.Flags().StringVar(&envProfile, "profile", "", "Profile to print the environment of (default: the active profile)")
+	envCmd.Flags().StringVar(&envShell, "shell", "bash", "Shell to print statements for: bash, zsh, fish or powershell")
+	envCmd.Flags().BoolVar(&envIncludeToken, "include-token", false, "Also print the profile's token and a Git credential helper that uses it")
+	envCmd.RegisterFlagCompletionFunc("profile", completeProfileFlag)
+	envCmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"bash", "zsh", "fish", "powershell"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execProfile string
+)
+
+// execCmd represents the exec command
+var execCmd = &cobra.Command{
+	Use:   "exec [--profile <name>] -- <command> [args...]",
+	Short: "▶️ Run a command as a profile without switching",
+	Long: `▶️ Runs a command with the Git identity and credentials of a profile (the
+active one unless --profile is given), leaving the active profile, ~/.gitconfig
+and ~/.git-credentials alone.
+
+The command gets GIT_AUTHOR_NAME, GIT_AUTHOR_EMAIL, GIT_COMMITTER_NAME,
+GIT_COMMITTER_EMAIL and the profile's GNUPGHOME. If the profile has a token,
+Git gets a credential helper that offers it for the profile's host, and gh or
+glab get it in GH_TOKEN (GH_ENTERPRISE_TOKEN) or GITLAB_TOKEN. If the profile
+has SSH identities, they are loaded into a temporary ssh-agent that is stopped
+when the command exits. gat exits with the command's exit code.
+
+Examples:
+  gat exec --profile work -- git push
+  gat exec --profile oss -- gh pr create --fill
+  gat exec -- git commit -m "Fix typo"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		var profile config.Profile
+		var profileName string
+		if execProfile != "" {
+			profileName = config.ResolveAlias(&validConfig, execProfile)
+			var exists bool
+			if profile, exists = validConfig.Profiles[profileName]; !exists {
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+			}
+		} else {
+			current, name, err := config.GetCurrentProfile(&validConfig)
+			if err != nil {
+				return err
+			}
+			profile, profileName = *current, name
+		}
+
+		if err := config.UnlockProfileToken(profileName, &profile); err != nil {
+			return err
+		}
+		env, err := git.ProfileEnv(&profile, os.Environ())
+		if err != nil {
+			return err
+		}
+
+		var agent *ssh.TemporaryAgent
+		if len(profile.SSHIdentities) > 0 {
+			missing, err := ssh.CheckSSHIdentities(profile.SSHIdentities, profile.SSHCertPath)
+			if err != nil {
+				return err
+			}
+			if missing != "" {
+				return utils.Errorf(ssh.ErrIdentityNotFound, "❌ SSH identity file not found: %s", missing)
+			}
+			if agent, err = ssh.StartTemporaryAgent(); err != nil {
+				return err
+			}
+			if err := agent.AddIdentity(profile.SSHIdentities...); err != nil {
+				stopExecAgent(agent)
+				return err
+			}
+			env = append(env, agent.Env()...)
+		}
+
+		code, err := runWithEnv(args, env)
+		if agent != nil {
+			stopExecAgent(agent)
+		}
+		if err != nil {
+			return err
+		}
+		if code != 0 {
+			os.Exit(code)
+		}
+		return nil
+	},
+}
+
+// runWithEnv runs a command with the given environment and gat's standard
+// streams, and returns its exit code. Interrupts reach the command (it is in
+// the terminal's process group) while gat waits for it to exit.
+func runWithEnv(args []string, env []string) (int, error) {
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = env
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		return 0, fmt.Errorf("❌ could not run %s: %w", args[0], err)
+	}
+	go func() {
+		for sig := range signals {
+			if sig == syscall.SIGTERM {
+				child.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := child.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code > 0 {
+			return code, nil
+		}
+		return 1, nil // Killed by a signal
+	}
+	if err != nil {
+		return 0, fmt.Errorf("❌ could not run %s: %w", args[0], err)
+	}
+	return 0, nil
+}
+
+// stopExecAgent stops the temporary agent of 'gat exec', warning if it cannot
+func stopExecAgent(agent *ssh.TemporaryAgent) {
+	if err := agent.Stop(); err != nil {
+		fmt.Fprintln(os.Stderr, color.YellowString("⚠️ %v", err))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	// Flags after the command belong to it: 'gat exec git push -f'
+	execCmd.Flags().SetInterspersed(false)
+	execCmd.Flags().StringVar(&execProfile, "profile", "", "Profile to run the command as (default: the active profile)")
+	execCmd.RegisterFlagCompletionFunc("profile", completeProfileFlag)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportIncludeTokens bool
+	exportEncrypt       bool
+	exportProfile       string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "📤 Export profiles to a portable file",
+	Long: `📤 Writes your valid profiles to a JSON file for backups or another machine.
+Read it back with 'gat import <file>'.
+
+Tokens are left out unless --include-tokens is given; each profile records
+whether it has one in 'has_token'. Included tokens are written in plaintext
+unless --encrypt is also given, which asks for a passphrase that 'gat import'
+will ask for again. Password-protected tokens stay encrypted with their own
+password either way.
+
+Examples:
+  gat export profiles.json
+  gat export work.json --profile work
+  gat export backup.json --include-tokens --encrypt`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		if exportEncrypt && !exportIncludeTokens {
+			return fmt.Errorf("❌ --encrypt only applies with --include-tokens")
+		}
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		for name, err := range validationErrors {
+			fmt.Printf(color.YellowString("⚠️ Not exporting invalid profile [%s]: %v\n"), name, err)
+		}
+		if len(validConfig.Profiles) == 0 {
+			return fmt.Errorf("❌ no valid profiles to export")
+		}
+
+		export, err := config.ExportProfiles(&validConfig, exportProfile, exportIncludeTokens)
+		if err != nil {
+			return err
+		}
+		if exportEncrypt {
+			passphrase, err := readNewEncryptionPassword("encrypt")
+			if err != nil {
+				return err
+			}
+			if err := config.EncryptExportTokens(&export, passphrase); err != nil {
+				return err
+			}
+		}
+		if err := config.WriteExportFile(path, export); err != nil {
+			return err
+		}
+
+		fmt.Println(color.GreenString("✅ Exported %d profile(s) to %s", len(export.Profiles), path))
+		if exportEncrypt {
+			fmt.Println("🔐 Tokens are encrypted; 'gat import' will ask for the passphrase")
+		} else if exportIncludeTokens {
+			fmt.Println(color.YellowString("⚠️ The file contains tokens in plaintext. Keep it private and delete it once imported."))
+		} else {
+			fmt.Printf("%s Tokens were not exported; add them again with 'gat add <name> --token <token> --overwrite'\n", color.YellowString("💡"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().BoolVar(&exportIncludeTokens, "include-tokens", false, "Include tokens in plaintext")
+	exportCmd.Flags().BoolVar(&exportEncrypt, "encrypt", false, "Encrypt the included tokens with a passphrase")
+	exportCmd.Flags().StringVar(&exportProfile, "profile", "", "Export only this profile")
+	exportCmd.RegisterFlagCompletionFunc("profile", completeProfileFlag)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hookGlobal bool
+)
+
+// hookName is the Git hook 'gat hook install' writes
+const hookName = "post-checkout"
+
+// postCheckoutHook switches to the profile pinned by the repository's .gat
+// file after a branch checkout or clone ($3 is 0 for file checkouts). It
+// exits 0 if gat is not installed or the switch fails.
+const postCheckoutHook = `# Switch to the profile pinned by .gat (see 'gat hook')
+if [ "$3" != 0 ] && [ -f .gat ] && command -v gat >/dev/null 2>&1; then
+	gat switch || true
+fi`
+
+// repoHookChain runs the repository's own post-checkout hook from the global
+// hook, since a global core.hooksPath keeps Git from running it
+const repoHookChain = `# core.hooksPath hides the repository's own hooks; run its post-checkout too
+repo_hook="$(git rev-parse --git-common-dir)/hooks/post-checkout"
+if [ -x "$repo_hook" ]; then
+	"$repo_hook" "$@" || exit $?
+fi
+`
+
+// hookCmd represents the hook command
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "🪝 Manage the Git hook that switches to pinned profiles",
+	Long: `🪝 'gat hook install' adds a post-checkout hook that runs 'gat switch' after a
+checkout or clone when the repository has a .gat pin file (see 'gat pin'), so
+the pinned profile is used without switching by hand.
+
+Examples:
+  gat hook install             # This repository's .git/hooks
+  gat hook install --global    # Every repository, through core.hooksPath
+  gat hook uninstall --global`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// hookInstallCmd represents the install subcommand of hook
+var hookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the post-checkout hook",
+	Long: `Installs the post-checkout hook in the current repository, or with --global
+in the directory set as core.hooksPath in ~/.gitconfig (~/.gat/hooks if it is
+not set). Commands already in the hook are kept and installing again replaces
+only gat's part.
+
+A global core.hooksPath makes Git ignore the hooks in each repository's
+.git/hooks, so the global hook runs the repository's own post-checkout hook
+as well. Other hooks in .git/hooks stop running.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scope, script := git.HookScopeLocal, postCheckoutHook
+		if hookGlobal {
+			scope, script = git.HookScopeGlobal, repoHookChain+postCheckoutHook
+		}
+		hadHooksPath, err := git.HookDir(scope)
+		if err != nil {
+			return err
+		}
+
+		if err := git.InstallHook(scope, hookName, script); err != nil {
+			return err
+		}
+		dir, err := git.HookDir(scope)
+		if err != nil {
+			return err
+		}
+		fmt.Println(color.GreenString("✅ Installed %s", filepath.Join(dir, hookName)))
+
+		if hookGlobal && hadHooksPath == "" {
+			fmt.Printf("%s Set core.hooksPath to %s in ~/.gitconfig; hooks in each repository's .git/hooks other than post-checkout no longer run\n", color.YellowString("⚠️"), dir)
+		}
+		if validConfig, _, ioErr := config.LoadConfig(); ioErr == nil && !validConfig.AutoSwitch {
+			fmt.Printf("%s The hook only switches profiles with auto-switch on; enable it with 'gat config set auto-switch true'\n", color.YellowString("💡"))
+		}
+		if !hookGlobal {
+			if active, err := git.ActiveHookDir(); err == nil && active != dir {
+				fmt.Printf("%s core.hooksPath is set to %s, so Git does not run this hook; use 'gat hook install --global' instead\n", color.YellowString("⚠️"), active)
+			}
+		}
+		return nil
+	},
+}
+
+// hookUninstallCmd represents the uninstall subcommand of hook
+var hookUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the post-checkout hook",
+	Long: `Removes gat's part of the post-checkout hook in the current repository, or
+with --global in core.hooksPath. A core.hooksPath set by 'gat hook install
+--global' is unset once its directory is empty.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scope := git.HookScopeLocal
+		if hookGlobal {
+			scope = git.HookScopeGlobal
+		}
+		removed, err := git.UninstallHook(scope, hookName)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			fmt.Println("😶 No gat hook installed")
+			return nil
+		}
+		fmt.Println(color.GreenString("✅ Removed the gat %s hook", hookName))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hookCmd)
+	hookCmd.AddCommand(hookInstallCmd)
+	hookCmd.AddCommand(hookUninstallCmd)
+
+	hookCmd.PersistentFlags().BoolVar(&hookGlobal, "global", false, "Use the global hooks directory (core.hooksPath) instead of the current repository's")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importMergeStrategy string
+	importOverwrite     bool
+	importDryRun        bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "📥 Import profiles from an export or another gat config file",
+	Long: `📥 Copies the profiles of a file written by 'gat export', or of another gat
+credentials file (e.g. a creds.json from another machine), into your
+configuration. Profiles are validated as by 'gat add'.
+
+--merge-strategy decides what happens to profiles that already exist:
+  skip        keep your profile (default)
+  overwrite   replace it with the imported one (same as --overwrite); the
+              imported file's active profile also becomes yours
+  keep-newer  keep whichever was added or changed more recently
+
+Profiles that exist in both files with different usernames are reported.
+Use --dry-run to see what would be imported without saving. Exports made
+with --include-tokens --encrypt ask for their passphrase.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if importOverwrite {
+			if cmd.Flags().Changed("merge-strategy") && importMergeStrategy != config.OverwriteExisting.String() {
+				return fmt.Errorf("❌ --overwrite conflicts with --merge-strategy %s", importMergeStrategy)
+			}
+			importMergeStrategy = config.OverwriteExisting.String()
+		}
+		strategy, err := config.ParseMergeStrategy(importMergeStrategy)
+		if err != nil {
+			return err
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		src, err := config.ReadImportFile(args[0], &validConfig)
+		if err != nil {
+			return err
+		}
+		if config.HasExportEncryptedTokens(&src) {
+			if !stdinIsTerminal() {
+				return fmt.Errorf("❌ the tokens in %s are encrypted; run gat import interactively to enter the passphrase", args[0])
+			}
+			prompt := promptui.Prompt{Label: "Export passphrase", Mask: '*'}
+			passphrase, err := prompt.Run()
+			if err != nil {
+				return fmt.Errorf("❌ passphrase entry canceled")
+			}
+			if err := config.DecryptExportTokens(&src, passphrase); err != nil {
+				return err
+			}
+		}
+
+		target := &validConfig
+		if importDryRun {
+			// Import into a copy so nothing in validConfig changes
+			preview := validConfig
+			preview.Profiles = make(map[string]config.Profile, len(validConfig.Profiles))
+			for name, profile := range validConfig.Profiles {
+				preview.Profiles[name] = profile
+			}
+			target = &preview
+			fmt.Printf("🧪 Dry run: importing profiles from %s (strategy: %s)\n", args[0], strategy)
+		} else {
+			fmt.Printf("📥 Importing profiles from %s (strategy: %s)\n", args[0], strategy)
+		}
+
+		result, err := config.ImportProfiles(target, src, strategy)
+		if err != nil {
+			return err
+		}
+
+		if importDryRun {
+			for _, name := range result.Imported {
+				action := "add"
+				if _, exists := validConfig.Profiles[name]; exists {
+					action = "overwrite"
+				}
+				fmt.Printf("   Would %s: %s\n", action, color.GreenString(name))
+			}
+			if len(result.Skipped) > 0 {
+				fmt.Printf("   Would skip (already exist): %s\n", strings.Join(result.Skipped, ", "))
+			}
+		} else if len(result.Imported) > 0 {
+			if err := config.SaveConfig(&validConfig); err != nil {
+				return err
+			}
+		}
+
+		verb := "Imported"
+		if importDryRun {
+			verb = "Would import"
+		}
+		fmt.Println(color.GreenString("✅ %s %d profile(s)", verb, len(result.Imported)) +
+			fmt.Sprintf(", skipped %d, %d invalid", len(result.Skipped), len(result.Invalid)))
+		if len(result.Invalid) > 0 {
+			return fmt.Errorf("❌ %d profile(s) could not be imported: %s", len(result.Invalid), strings.Join(result.Invalid, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(&importMergeStrategy, "merge-strategy", config.SkipExisting.String(), "How to handle existing profiles: skip, overwrite or keep-newer")
+	importCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "Replace existing profiles (same as --merge-strategy overwrite)")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without saving")
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// infoCmd represents the info command
+var infoCmd = &cobra.Command{
+	Use:   "info <profile>",
+	Short: "🔍 Show every field of a profile, description included",
+	Long: `🔍 Prints the detailed view of one profile, the same as 'gat profile show':
+every field including the description, SSH key permissions and fingerprint.
+Tokens are never printed; only their presence and a short fingerprint are
+shown.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return profileShowCmd.RunE(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+
+	infoCmd.Flags().StringVarP(&profileOutput, "output", "o", "text", "Output format ('text', 'json', or 'yaml')")
+}
@@ -3,19 +3,84 @@ package main
 import (
 	"fmt"
 	"gat/pkg/config"
+	"gat/pkg/output"
 	"gat/pkg/platform"
+	"gat/pkg/utils"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	listVerbose    bool
+	listOutput     string
+	listTags       []string
+	listPlatform   string
+	listAuthMethod string
+)
+
+// listedProfiles is the structured output of 'gat list'
+type listedProfiles struct {
+	Profiles []listedProfile `json:"profiles"`
+	Warnings []listWarning   `json:"warnings,omitempty"`
+}
+
+// listedProfile is a profile in the structured output of 'gat list'. The
+// token is never included; HasToken tells whether one is stored.
+type listedProfile struct {
+	Name               string     `json:"name"`
+	Active             bool       `json:"active"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	HasToken           bool       `json:"has_token"`
+	SSHIdentity        string     `json:"ssh_identity,omitempty"` // Primary identity
+	SSHIdentities      []string   `json:"ssh_identities,omitempty"`
+	SSHCertPath        string     `json:"ssh_cert_path,omitempty"`
+	Platform           string     `json:"platform"`
+	Host               string     `json:"host,omitempty"`
+	AuthMethod         string     `json:"auth_method"`
+	WorkingDirectory   string     `json:"working_directory,omitempty"`
+	GnupghomeOverride  string     `json:"gnupghome_override,omitempty"`
+	GPGKey             string     `json:"gpg_key,omitempty"`
+	MaxSessionDuration string     `json:"max_session_duration,omitempty"`
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+	AvatarURL          string     `json:"avatar_url,omitempty"`
+	MirrorRemotes      []string   `json:"mirror_remotes,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Tags               []string   `json:"tags,omitempty"`
+}
+
+// listWarning is a profile 'gat list' skipped because it failed validation
+type listWarning struct {
+	Profile string `json:"profile"`
+	Error   string `json:"error"`
+}
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "📋 List all stored profiles",
-	Long:  `📋 Lists all stored Git profiles across all platforms, highlighting the current active one.`,
+	Long: `📋 Lists all stored Git profiles across all platforms, highlighting the current active one.
+
+--tag, --platform and --auth-method narrow the list; profiles must match all
+of them.
+
+Examples:
+  gat list --platform github
+  gat list --platform gitlab --auth-method ssh`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(listOutput)
+		if err != nil {
+			return err
+		}
+		// Keep stdout parseable; warnings go to stderr
+		if format.IsStructured() {
+			config.WarningOutput = os.Stderr
+		}
+
 		// Ensure config directory and file exist
 		configPath, err := config.ConfigPath()
 		if err != nil {
@@ -25,7 +90,14 @@ var listCmd = &cobra.Command{
 			if err := os.MkdirAll(configPath, 0755); err != nil {
 				return fmt.Errorf("❌ could not create config directory: %w", err)
 			}
-			fmt.Printf("✅ Created configuration directory at %s\n\n", configPath)
+			fmt.Fprintf(config.WarningOutput, "✅ Created configuration directory at %s\n\n", configPath)
+		}
+
+		if err := config.ValidateTags(listTags); err != nil {
+			return err
+		}
+		if listAuthMethod != "" && listAuthMethod != "ssh" && listAuthMethod != "https" {
+			return fmt.Errorf("❌ invalid --auth-method '%s': must be ssh or https", listAuthMethod)
 		}
 
 		// Load configuration
@@ -34,6 +106,14 @@ var listCmd = &cobra.Command{
 			return ioErr // Handle file I/O or parsing errors first
 		}
 
+		// Profiles in the user's order (see 'gat profile reorder'), then
+		// alphabetically, limited to those with every --tag
+		profileNames := filterListedProfiles(&validConfig, config.ProfilesWithTags(&validConfig, listTags))
+
+		if format.IsStructured() {
+			return output.Write(os.Stdout, format, collectListedProfiles(&validConfig, profileNames, validationErrors))
+		}
+
 		// Print warnings for invalid profiles found during load
 		if len(validationErrors) > 0 {
 			fmt.Println(color.YellowString("\n⚠️ Found configuration issues:"))
@@ -50,16 +130,14 @@ var listCmd = &cobra.Command{
 			return nil
 		}
 
+		if len(profileNames) == 0 {
+			fmt.Printf("😶 No profiles match the filter (%s)\n", describeListFilter())
+			return nil
+		}
+
 		// Initialize platform registry
 		reg := platform.NewRegistry()
 
-		// Get a sorted list of profile names
-		var profileNames []string
-		for name := range validConfig.Profiles {
-			profileNames = append(profileNames, name)
-		}
-		sort.Strings(profileNames)
-
 		// Display profiles
 		fmt.Println("📋 Git Profiles:")
 		fmt.Println("--------------")
@@ -91,22 +169,62 @@ var listCmd = &cobra.Command{
 			if name == validConfig.Current {
 				// Current profile
 				fmt.Printf("%s %s\n", color.GreenString("✅"), color.GreenString(name))
+				printListDescription(profile)
 				fmt.Printf("   🌐 Platform: %s (%s)\n", platformName, hostName)
 				fmt.Printf("   👤 Username: %s\n", profile.Username)
 				fmt.Printf("   📧 Email: %s\n", profile.Email)
 				fmt.Printf("   🔒 Auth Method: %s\n", profile.AuthMethod)
-				if profile.SSHIdentity != "" {
-					fmt.Printf("   🔑 SSH Key: %s\n", profile.SSHIdentity)
+				if len(profile.SSHIdentities) > 0 {
+					fmt.Printf("   🔑 SSH Key: %s\n", formatSSHIdentities(profile.SSHIdentities))
+				}
+				if profile.WorkingDirectory != "" {
+					fmt.Printf("   📂 Working Directory: %s\n", profile.WorkingDirectory)
+				}
+				if profile.GnupghomeOverride != "" {
+					fmt.Printf("   🔏 GNUPGHOME: %s\n", profile.GnupghomeOverride)
+				}
+				if profile.GPGKey != "" {
+					fmt.Printf("   ✍️ GPG Key: %s\n", profile.GPGKey)
+				}
+				if len(profile.Tags) > 0 {
+					fmt.Printf("   🏷️ Tags: %s\n", strings.Join(profile.Tags, ", "))
+				}
+				if listVerbose && plat != nil {
+					fmt.Printf("   📦 Clone Prefix: %s\n", cloneURLPrefix(plat, name, profile))
+				}
+				if listVerbose {
+					printProfileAvatar(profile)
+					printProfileSize(&validConfig, name)
 				}
 			} else {
 				// Other profiles
 				fmt.Printf("⬜ %s\n", name)
+				printListDescription(profile)
 				fmt.Printf("   🌐 Platform: %s (%s)\n", platformName, hostName)
 				fmt.Printf("   👤 Username: %s\n", profile.Username)
 				fmt.Printf("   📧 Email: %s\n", profile.Email)
 				fmt.Printf("   🔒 Auth Method: %s\n", profile.AuthMethod)
-				if profile.SSHIdentity != "" {
-					fmt.Printf("   🔑 SSH Key: %s\n", profile.SSHIdentity)
+				if len(profile.SSHIdentities) > 0 {
+					fmt.Printf("   🔑 SSH Key: %s\n", formatSSHIdentities(profile.SSHIdentities))
+				}
+				if profile.WorkingDirectory != "" {
+					fmt.Printf("   📂 Working Directory: %s\n", profile.WorkingDirectory)
+				}
+				if profile.GnupghomeOverride != "" {
+					fmt.Printf("   🔏 GNUPGHOME: %s\n", profile.GnupghomeOverride)
+				}
+				if profile.GPGKey != "" {
+					fmt.Printf("   ✍️ GPG Key: %s\n", profile.GPGKey)
+				}
+				if len(profile.Tags) > 0 {
+					fmt.Printf("   🏷️ Tags: %s\n", strings.Join(profile.Tags, ", "))
+				}
+				if listVerbose && plat != nil {
+					fmt.Printf("   📦 Clone Prefix: %s\n", cloneURLPrefix(plat, name, profile))
+				}
+				if listVerbose {
+					printProfileAvatar(profile)
+					printProfileSize(&validConfig, name)
 				}
 			}
 			fmt.Println()
@@ -116,6 +234,98 @@ var listCmd = &cobra.Command{
 	},
 }
 
+// filterListedProfiles keeps the named profiles that match --platform and
+// --auth-method, in the same order
+func filterListedProfiles(cfg *config.Config, names []string) []string {
+	var filtered []string
+	for _, name := range names {
+		profile := cfg.Profiles[name]
+		if listPlatform != "" && profile.GetPlatform() != listPlatform {
+			continue
+		}
+		if listAuthMethod != "" && profile.AuthMethod != listAuthMethod {
+			continue
+		}
+		filtered = append(filtered, name)
+	}
+	return filtered
+}
+
+// describeListFilter describes the filter flags of 'gat list' that are set
+func describeListFilter() string {
+	var parts []string
+	if len(listTags) > 0 {
+		parts = append(parts, "tagged "+strings.Join(listTags, ", "))
+	}
+	if listPlatform != "" {
+		parts = append(parts, "platform "+listPlatform)
+	}
+	if listAuthMethod != "" {
+		parts = append(parts, "auth method "+listAuthMethod)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// collectListedProfiles builds the structured output of 'gat list' for the
+// named profiles, in the same order as the text output
+func collectListedProfiles(cfg *config.Config, names []string, validationErrors map[string]error) listedProfiles {
+	listed := listedProfiles{Profiles: []listedProfile{}}
+	for _, name := range names {
+		profile := cfg.Profiles[name]
+		entry := listedProfile{
+			Name:              name,
+			Active:            name == cfg.Current,
+			Username:          profile.Username,
+			Email:             profile.Email,
+			HasToken:          profile.Token != "",
+			SSHIdentity:       profile.SSHIdentity(),
+			SSHIdentities:     profile.SSHIdentities,
+			SSHCertPath:       profile.SSHCertPath,
+			Platform:          profile.GetPlatform(),
+			Host:              profile.Host,
+			AuthMethod:        profile.AuthMethod,
+			WorkingDirectory:  profile.WorkingDirectory,
+			GnupghomeOverride: profile.GnupghomeOverride,
+			GPGKey:            profile.GPGKey,
+			UpdatedAt:         profile.UpdatedAt,
+			AvatarURL:         profile.AvatarURL,
+			MirrorRemotes:     profile.MirrorRemotes,
+			Description:       profile.Description,
+			Tags:              profile.Tags,
+		}
+		if profile.MaxSessionDuration > 0 {
+			entry.MaxSessionDuration = profile.MaxSessionDuration.String()
+		}
+		listed.Profiles = append(listed.Profiles, entry)
+	}
+
+	invalid := make([]string, 0, len(validationErrors))
+	for name := range validationErrors {
+		invalid = append(invalid, name)
+	}
+	sort.Strings(invalid)
+	for _, name := range invalid {
+		listed.Warnings = append(listed.Warnings, listWarning{Profile: name, Error: validationErrors[name].Error()})
+	}
+	return listed
+}
+
+// cloneURLPrefix returns the URL prefix used to clone repositories with a profile,
+// honoring the profile's auth method and custom host
+func cloneURLPrefix(plat *platform.Platform, profileName string, profile config.Profile) string {
+	if profile.AuthMethod == "ssh" {
+		return platform.GenerateSSHURL(plat, profileName, "")
+	}
+
+	// Self-hosted instances override the platform's default host
+	if profile.Host != "" {
+		hosted := *plat
+		hosted.DefaultHost = profile.Host
+		plat = &hosted
+	}
+	return platform.GenerateHTTPSURL(plat, "")
+}
+
 // REMOVED redundant getPlatformID helper function
 // func getPlatformID(profile config.Profile) string {
 // 	if profile.Platform == "" {
@@ -124,6 +334,62 @@ var listCmd = &cobra.Command{
 // 	return profile.Platform
 // }
 
+// listDescriptionLength is how many characters of a description 'gat list' shows
+const listDescriptionLength = 80
+
+// formatSSHIdentities shows the primary of a profile's SSH identities and
+// how many others it has
+func formatSSHIdentities(identities []string) string {
+	if len(identities) > 1 {
+		return fmt.Sprintf("%s (+%d more)", identities[0], len(identities)-1)
+	}
+	return identities[0]
+}
+
+// printListDescription prints the start of a profile's description in grey,
+// under its name
+func printListDescription(profile config.Profile) {
+	if profile.Description != "" {
+		fmt.Println(color.HiBlackString("   %s", summarizeDescription(profile.Description)))
+	}
+}
+
+// summarizeDescription returns the first listDescriptionLength characters of
+// a description on one line, followed by "..." if it is longer
+func summarizeDescription(description string) string {
+	runes := []rune(strings.Join(strings.Fields(description), " "))
+	if len(runes) <= listDescriptionLength {
+		return string(runes)
+	}
+	return string(runes[:listDescriptionLength]) + "..."
+}
+
+// printProfileAvatar draws the avatar saved by 'gat verify', or the
+// username's initial where the terminal cannot show images
+func printProfileAvatar(profile config.Profile) {
+	fmt.Print("   🖼️ Avatar: ")
+	if profile.AvatarURL == "" || output.RenderAvatar(profile.AvatarURL, os.Stdout) != nil {
+		fmt.Print(output.AvatarFallback(profile.Username))
+	}
+	fmt.Println()
+}
+
+// printProfileSize prints the disk space used by a profile and its backups
+func printProfileSize(cfg *config.Config, name string) {
+	if size, err := config.ProfileSize(cfg, name); err == nil {
+		fmt.Printf("   💾 Size: %s\n", utils.FormatBytes(size))
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().BoolVarP(&listVerbose, "verbose", "v", false, "Show additional details such as clone URL prefixes and disk usage")
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "text", "Output format ('text', 'json', or 'yaml')")
+	listCmd.Flags().StringArrayVar(&listTags, "tag", nil, "Only list profiles with this tag (repeatable: profiles with all the tags)")
+	listCmd.Flags().StringVar(&listPlatform, "platform", "", "Only list profiles on this platform ID")
+	listCmd.Flags().StringVar(&listAuthMethod, "auth-method", "", "Only list profiles with this authentication method ('ssh' or 'https')")
+	listCmd.RegisterFlagCompletionFunc("tag", completeTagFlag)
+	listCmd.RegisterFlagCompletionFunc("platform", completePlatformFlag)
+	listCmd.RegisterFlagCompletionFunc("auth-method", completeAuthMethodFlag)
 }
@@ -1,15 +1,48 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/ssh"
 	"os"
 
 	"github.com/fatih/color"
 )
 
 func main() {
+	// gat is its own SSH_ASKPASS program when generating keys
+	if ssh.ServeAskpass() {
+		return
+	}
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(color.RedString("❌ Error:"), err)
+		printErrorHint(err)
 		os.Exit(1)
 	}
 }
+
+// printErrorHint prints a follow-up suggestion for well-known error kinds
+func printErrorHint(err error) {
+	var hint string
+	switch {
+	case errors.Is(err, config.ErrProfileNotFound):
+		hint = "Run 'gat list' to see available profiles"
+	case errors.Is(err, config.ErrProfileExists):
+		hint = "Use --overwrite to replace the existing profile"
+	case errors.Is(err, config.ErrInvalidAuthMethod):
+		hint = "Set --auth-method to 'ssh' or 'https'"
+	case errors.Is(err, config.ErrConfigCorrupt):
+		hint = "Check your credentials file for JSON errors or restore it from ~/.gat/backups"
+	case errors.Is(err, git.ErrNotInRepo):
+		hint = "Run this command from inside a Git repository"
+	case errors.Is(err, git.ErrNoRemote):
+		hint = "Add a remote with 'git remote add origin <url>'"
+	case errors.Is(err, ssh.ErrIdentityNotFound):
+		hint = "Check the path with 'gat list' or update it with 'gat add <name> --ssh-identity <path> --overwrite'"
+	default:
+		return
+	}
+	fmt.Printf("%s %s\n", color.YellowString("💡"), hint)
+}
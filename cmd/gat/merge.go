@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeDryRun bool
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <base-config-file>",
+	Short: "🧬 Merge a shared base config into your profiles",
+	Long: `🧬 Applies a shared base configuration (for example one distributed by IT)
+underneath your own gat configuration.
+
+Your profiles win over base profiles with the same name, and your active profile
+and encryption settings are always kept. Profiles that exist in both files with
+different usernames are reported as conflicts.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		basePath := args[0]
+
+		base, err := config.ReadConfigFile(basePath)
+		if err != nil {
+			return err
+		}
+
+		// Load configuration, print warnings for invalid profiles but proceed
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		if len(validationErrors) > 0 {
+			fmt.Println(color.YellowString("\n⚠️ Found configuration issues with some profiles (will be dropped by the merge):"))
+			for name, err := range validationErrors {
+				fmt.Printf(color.YellowString("   - Profile [%s]: %v\n"), name, err)
+			}
+			fmt.Println()
+		}
+
+		merged, conflicts, err := config.MergeConfigs(&base, &validConfig)
+		if err != nil {
+			return err
+		}
+
+		// Report which base profiles are new
+		var added []string
+		for name := range base.Profiles {
+			if _, exists := validConfig.Profiles[name]; !exists {
+				added = append(added, name)
+			}
+		}
+		sort.Strings(added)
+
+		fmt.Printf("🧬 Merging base config: %s\n", basePath)
+		for _, name := range added {
+			fmt.Printf("  %s %s\n", color.GreenString("+"), name)
+		}
+		if len(added) == 0 {
+			fmt.Println("  ℹ️ No new profiles in base config")
+		}
+		for _, name := range conflicts {
+			fmt.Printf(color.YellowString("  ⚠️ Conflict: profile [%s] is '%s' in base but '%s' in your config (keeping yours)\n"),
+				name, base.Profiles[name].Username, validConfig.Profiles[name].Username)
+		}
+
+		if mergeDryRun {
+			fmt.Println(color.YellowString("🧪 Dry run mode enabled. No changes were made."))
+			return nil
+		}
+
+		if err := config.SaveConfig(merged); err != nil {
+			return err
+		}
+
+		fmt.Println(color.GreenString("✅ Merged %d profile(s) from base config", len(added)))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().BoolVar(&mergeDryRun, "dry-run", false, "Show what would be merged without writing changes")
+}
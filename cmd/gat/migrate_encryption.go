@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// migrateEncryptionCmd represents the migrate-encryption command
+var migrateEncryptionCmd = &cobra.Command{
+	Use:   "migrate-encryption",
+	Short: "🔒 Re-encrypt stored tokens with the Argon2id key",
+	Long: `🔒 Tokens encrypted by older versions of gat use a key that is a single
+SHA-256 hash of the secret. They can still be read, but this command
+re-encrypts every one of them with a key derived with Argon2id (enc:v2:) and
+saves the config. The previous config file is backed up to ~/.gat/backups
+first. Running it again changes nothing.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, err := loadConfigForEncryptionChange()
+		if err != nil {
+			return err
+		}
+		passwordCheckCurrent := !validConfig.UsePasswordEncryption || !config.IsLegacyEncryptedToken(validConfig.PasswordCheck)
+
+		affected, err := config.MigrateEncryption(&validConfig)
+		if err != nil {
+			return err
+		}
+		if len(affected) == 0 && passwordCheckCurrent {
+			fmt.Println(color.GreenString("✅ All stored tokens already use the Argon2id key"))
+			return nil
+		}
+		if err := saveConfigWithBackup(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Println(color.GreenString("✅ Token encryption migrated to the Argon2id key"))
+		printAffectedProfiles("Re-encrypted", affected)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateEncryptionCmd)
+}
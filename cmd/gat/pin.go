@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/utils"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// pinCmd represents the pin command
+var pinCmd = &cobra.Command{
+	Use:   "pin <profile>",
+	Short: "📌 Pin the current repository to a profile",
+	Long: `📌 Writes a .gat file naming the profile in the repository root. 'gat switch'
+without a name and 'gat status' use it, and other commands warn when a
+different profile is active. A .gat file in a directory above several
+repositories pins all of them.
+
+Commit the file to share the pin, or add it to .gitignore to keep it local.
+'gat hook install' switches to the pinned profile after each checkout.
+
+Examples:
+  gat pin work
+  gat unpin`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		name := args[0]
+		if _, exists := validConfig.Profiles[config.ResolveAlias(&validConfig, name)]; !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", name)
+		}
+
+		dir, err := git.GetRepoRoot()
+		if err != nil {
+			return err
+		}
+		path, err := config.WritePinFile(dir, name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(color.GreenString("📌 Pinned %s to profile '%s' (%s)", dir, name, path))
+		if !validConfig.AutoSwitch {
+			fmt.Printf("%s 'gat switch' only uses pins with auto-switch on; enable it with 'gat config set auto-switch true'\n", color.YellowString("💡"))
+		} else if validConfig.Current != config.ResolveAlias(&validConfig, name) {
+			fmt.Printf("%s Run 'gat switch' to use it now\n", color.YellowString("💡"))
+		}
+		return nil
+	},
+}
+
+// unpinCmd represents the unpin command
+var unpinCmd = &cobra.Command{
+	Use:   "unpin",
+	Short: "Remove the profile pin of the current repository",
+	Long: `Removes the nearest .gat pin file: in the current directory or a parent,
+up to the home directory.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, path, err := config.FindRepoProfile()
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			fmt.Println("😶 No pinned profile here")
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("❌ could not remove %s: %w", path, err)
+		}
+		fmt.Println(color.GreenString("✅ Removed %s (profile '%s')", path, name))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(unpinCmd)
+}
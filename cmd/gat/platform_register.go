@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"gat/pkg/platform"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -21,6 +20,9 @@ var (
 	platHTTPSPrefix string
 	platSSHUser     string
 	platTokenScope  string
+	platTokenPrefix string
+	platMaxTokenLen int
+	platOAuthScopes []string
 	platYAMLPath    string
 	platForce       bool
 )
@@ -103,73 +105,42 @@ Example YAML file format:
 				HTTPSPrefix:    platHTTPSPrefix,
 				SSHUser:        platSSHUser,
 				TokenAuthScope: platTokenScope,
+				TokenPrefix:    platTokenPrefix,
+				MaxTokenLength: platMaxTokenLen,
+				OAuthScopes:    platOAuthScopes,
 				Custom:         true,
 			}
 		}
 
-		// Get user's home directory
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("❌ could not find home directory: %w", err)
+		// Surface a broken platforms.yaml instead of overwriting it
+		if _, err := platform.LoadCustomPlatforms(); err != nil {
+			return fmt.Errorf("❌ %w", err)
 		}
-
-		// Path to custom platforms file
-		configDir := filepath.Join(homeDir, ".gat")
-		platformsPath := filepath.Join(configDir, "platforms.yaml")
-
-		// Create config directory if it doesn't exist
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return fmt.Errorf("❌ could not create config directory: %w", err)
-		}
-
-		// Load existing platforms or create new map
-		existingPlatforms := make(map[string]*platform.Platform)
-		if _, err := os.Stat(platformsPath); err == nil {
-			// File exists, read it
-			data, err := os.ReadFile(platformsPath)
-			if err != nil {
-				return fmt.Errorf("❌ could not read platforms file: %w", err)
-			}
-
-			// Parse YAML
-			if err := yaml.Unmarshal(data, &existingPlatforms); err != nil {
-				return fmt.Errorf("❌ could not parse platforms file: %w", err)
-			}
-
-			// Check if platform already exists
-			if _, exists := existingPlatforms[newPlatform.ID]; exists && !platForce {
-				// Check if we're in a non-interactive environment (such as CI or tests)
-				// by checking if stdin is connected to a terminal
-				fileInfo, _ := os.Stdin.Stat()
-				isTerminal := (fileInfo.Mode() & os.ModeCharDevice) != 0
-
-				if isTerminal {
-					// Prompt for confirmation only in interactive mode
-					fmt.Printf("⚠️ Platform '%s' already exists. Overwrite? (y/N): ", newPlatform.ID)
-					var input string
-					fmt.Scanln(&input)
-					if !strings.EqualFold(input, "y") && !strings.EqualFold(input, "yes") {
-						fmt.Println("Operation cancelled.")
-						return nil
-					}
-				} else {
-					// In non-interactive mode, just return an error
-					return fmt.Errorf("❌ platform '%s' already exists (use --force to overwrite)", newPlatform.ID)
+		reg := platform.NewRegistry()
+
+		// Check if platform already exists
+		if existing, exists := reg.Platforms[newPlatform.ID]; exists && existing.Custom && !platForce {
+			if stdinIsTerminal() {
+				// Prompt for confirmation only in interactive mode
+				fmt.Printf("⚠️ Platform '%s' already exists. Overwrite? (y/N): ", newPlatform.ID)
+				var input string
+				fmt.Scanln(&input)
+				if !strings.EqualFold(input, "y") && !strings.EqualFold(input, "yes") {
+					fmt.Println("Operation cancelled.")
+					return nil
 				}
+			} else {
+				// In non-interactive mode, just return an error
+				return fmt.Errorf("❌ platform '%s' already exists (use --force to overwrite)", newPlatform.ID)
 			}
 		}
 
-		// Add the new platform
-		existingPlatforms[newPlatform.ID] = newPlatform
-
-		// Write the platforms file
-		data, err := yaml.Marshal(existingPlatforms)
-		if err != nil {
-			return fmt.Errorf("❌ could not marshal platforms data: %w", err)
+		// Add the new platform and write the platforms file
+		if err := reg.RegisterPlatform(newPlatform); err != nil {
+			return err
 		}
-
-		if err := os.WriteFile(platformsPath, data, 0644); err != nil {
-			return fmt.Errorf("❌ could not write platforms file: %w", err)
+		if err := reg.PersistCustomPlatforms(); err != nil {
+			return fmt.Errorf("❌ %w", err)
 		}
 
 		fmt.Printf("✅ Successfully registered platform %s (%s)\n",
@@ -191,6 +162,9 @@ func init() {
 	platformRegisterCmd.Flags().StringVar(&platHTTPSPrefix, "https-prefix", "", "HTTPS URL prefix (e.g., https://git.example.com/)")
 	platformRegisterCmd.Flags().StringVar(&platSSHUser, "ssh-user", "git", "SSH username (defaults to 'git')")
 	platformRegisterCmd.Flags().StringVar(&platTokenScope, "token-scope", "", "Token authentication scope (defaults to host)")
+	platformRegisterCmd.Flags().StringVar(&platTokenPrefix, "token-prefix", "", "Accepted token prefixes, comma-separated (e.g., glpat-)")
+	platformRegisterCmd.Flags().IntVar(&platMaxTokenLen, "max-token-length", 0, "Maximum token length (0 = unlimited)")
+	platformRegisterCmd.Flags().StringSliceVar(&platOAuthScopes, "oauth-scopes", nil, "Token scopes gat needs, comma-separated (e.g., read_repository,write_repository)")
 	platformRegisterCmd.Flags().StringVar(&platYAMLPath, "yaml", "", "Path to YAML file containing platform definition")
 	platformRegisterCmd.Flags().BoolVar(&platForce, "force", false, "Overwrite existing platform without confirmation")
 
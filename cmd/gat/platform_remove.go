@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	platRemoveForce bool
+)
+
+// platformRemoveCmd represents the remove subcommand of platforms
+var platformRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a custom Git hosting platform",
+	Long: `Removes a custom platform from ~/.gat/platforms.yaml. Built-in platforms
+cannot be removed. gat asks for confirmation first; --force skips it and is
+also needed to remove a platform that profiles still use.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+
+		if err := platform.ValidatePlatformID(id); err != nil {
+			return err
+		}
+		if platform.IsBuiltIn(id) {
+			return fmt.Errorf("❌ '%s' is a built-in platform and cannot be removed", id)
+		}
+		// Surface a broken platforms.yaml instead of overwriting it
+		customPlatforms, err := platform.LoadCustomPlatforms()
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+		if _, exists := customPlatforms[id]; !exists {
+			return fmt.Errorf("❌ custom platform '%s' not found", id)
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		var users []string
+		for name, profile := range validConfig.Profiles {
+			if profile.GetPlatform() == id {
+				users = append(users, name)
+			}
+		}
+		sort.Strings(users)
+		if len(users) > 0 {
+			if !platRemoveForce {
+				return fmt.Errorf("❌ platform '%s' is used by profile(s) [%s] (use --force to remove it anyway)", id, strings.Join(users, ", "))
+			}
+			fmt.Println(color.YellowString("⚠️ Platform '%s' is used by profile(s) [%s]; they need it registered again or another platform", id, strings.Join(users, ", ")))
+		}
+
+		// Confirm removal unless --force is set
+		if !platRemoveForce {
+			if !stdinIsTerminal() {
+				return fmt.Errorf("❌ removing a platform needs confirmation; use --force without a terminal")
+			}
+			prompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Remove custom platform '%s'", id),
+				IsConfirm: true,
+			}
+			if _, err := prompt.Run(); err != nil {
+				return fmt.Errorf("❌ removal canceled")
+			}
+		}
+
+		reg := platform.NewRegistry()
+		if err := reg.UnregisterPlatform(id); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed platform %s\n", color.GreenString(id))
+		return nil
+	},
+}
+
+func init() {
+	platformsCmd.AddCommand(platformRemoveCmd)
+
+	platformRemoveCmd.Flags().BoolVar(&platRemoveForce, "force", false, "Skip the confirmation and remove the platform even if profiles use it")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/output"
+	"gat/pkg/platform"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// platformShowCmd represents the show subcommand of platforms
+var platformShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show details and example clone commands for a platform",
+	Long:  `Display all fields of a Git hosting platform along with ready-to-copy clone commands.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(platformsOutput)
+		if err != nil {
+			return err
+		}
+
+		reg := platform.NewRegistry()
+		plat, err := reg.GetPlatform(args[0])
+		if err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		if format.IsStructured() {
+			return output.Write(os.Stdout, format, plat)
+		}
+
+		kind := "built-in"
+		if plat.Custom {
+			kind = "custom"
+		}
+
+		fmt.Printf("🌐 Platform: %s (%s)\n", color.GreenString(plat.ID), kind)
+		fmt.Printf("   Name: %s\n", plat.Name)
+		fmt.Printf("   Default Host: %s\n", color.YellowString(plat.DefaultHost))
+		fmt.Printf("   SSH Prefix: %s\n", plat.SSHPrefix)
+		fmt.Printf("   HTTPS Prefix: %s\n", plat.HTTPSPrefix)
+		fmt.Printf("   SSH User: %s\n", plat.SSHUser)
+		fmt.Printf("   Token Scope: %s\n", plat.TokenAuthScope)
+		if plat.TokenPrefix != "" {
+			fmt.Printf("   Token Prefix: %s\n", strings.Join(plat.TokenPrefixes(), ", "))
+		}
+		if plat.MaxTokenLength > 0 {
+			fmt.Printf("   Max Token Length: %d\n", plat.MaxTokenLength)
+		}
+		if plat.APICompatibility != "" {
+			fmt.Printf("   API Compatibility: %s\n", plat.APICompatibility)
+		}
+		if plat.APIVerifyURL != "" {
+			fmt.Printf("   Token Verify URL: %s\n", plat.APIVerifyURL)
+		}
+		if plat.CredentialHelper != "" {
+			fmt.Printf("   Credential Helper: %s\n", plat.CredentialHelper)
+		}
+		if len(plat.OAuthScopes) > 0 {
+			fmt.Println("   OAuth Scopes:")
+			for _, scope := range plat.OAuthScopes {
+				if description := plat.ScopeDescription(scope); description != "" {
+					fmt.Printf("     - %s: %s\n", color.CyanString(scope), description)
+				} else {
+					fmt.Printf("     - %s\n", color.CyanString(scope))
+				}
+			}
+		}
+
+		// Example clone commands for a hypothetical repository
+		examplePath := "user/repo.git"
+		fmt.Println()
+		fmt.Println(color.CyanString("Example clone commands:"))
+		fmt.Printf("  SSH:     %s\n", platform.GenerateCloneCommand(plat, "<profile>", examplePath, true))
+		fmt.Printf("  HTTPS:   %s\n", platform.GenerateCloneCommand(plat, "<profile>", examplePath, false))
+		fmt.Printf("  Shallow: %s\n", platform.GenerateCloneCommand(plat, "<profile>", examplePath, true, platform.CloneOptions{Depth: 1}))
+
+		return nil
+	},
+}
+
+func init() {
+	platformsCmd.AddCommand(platformShowCmd)
+}
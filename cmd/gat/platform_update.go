@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// platformUpdateCmd represents the update subcommand of platforms
+var platformUpdateCmd = &cobra.Command{
+	Use:   "update <id>",
+	Short: "Update fields of a custom Git hosting platform",
+	Long: `Change one or more fields of a registered custom platform in place.
+Only the flags you pass are changed. Built-in platforms cannot be updated.
+When the host, SSH user or name changes, the host aliases of the SSH profiles
+using the platform are rewritten in ~/.ssh/gat_config.
+
+A partial YAML file with just the fields to change can be given with --yaml:
+  defaultHost: "git.new-example.com"
+  httpsPrefix: "https://git.new-example.com/"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+
+		if err := platform.ValidatePlatformID(id); err != nil {
+			return err
+		}
+		if platform.IsBuiltIn(id) {
+			return fmt.Errorf("❌ '%s' is a built-in platform and cannot be updated", id)
+		}
+
+		customPlatforms, err := platform.LoadCustomPlatforms()
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+		existing, exists := customPlatforms[id]
+		if !exists {
+			return fmt.Errorf("❌ custom platform '%s' not found. Register it with 'gat platforms register'", id)
+		}
+
+		before := *existing
+		before.ID = id
+		updated := before
+
+		// Apply a partial YAML file first so explicit flags win
+		yamlPath, _ := cmd.Flags().GetString("yaml")
+		if yamlPath != "" {
+			data, err := os.ReadFile(yamlPath)
+			if err != nil {
+				return fmt.Errorf("❌ could not read YAML file: %w", err)
+			}
+			if err := yaml.Unmarshal(data, &updated); err != nil {
+				return fmt.Errorf("❌ could not parse YAML file: %w", err)
+			}
+			updated.ID = id // The ID is fixed by the argument
+		}
+
+		stringFlags := map[string]*string{
+			"name":         &updated.Name,
+			"host":         &updated.DefaultHost,
+			"ssh-prefix":   &updated.SSHPrefix,
+			"https-prefix": &updated.HTTPSPrefix,
+			"ssh-user":     &updated.SSHUser,
+			"token-scope":  &updated.TokenAuthScope,
+			"token-prefix": &updated.TokenPrefix,
+		}
+		for flagName, field := range stringFlags {
+			if cmd.Flags().Changed(flagName) {
+				*field, _ = cmd.Flags().GetString(flagName)
+			}
+		}
+		if cmd.Flags().Changed("max-token-length") {
+			updated.MaxTokenLength, _ = cmd.Flags().GetInt("max-token-length")
+		}
+		if cmd.Flags().Changed("oauth-scopes") {
+			updated.OAuthScopes, _ = cmd.Flags().GetStringSlice("oauth-scopes")
+		}
+
+		// The same fields register requires must still be set
+		if updated.Name == "" || updated.DefaultHost == "" || updated.SSHPrefix == "" || updated.HTTPSPrefix == "" {
+			return fmt.Errorf("❌ name, host, ssh-prefix and https-prefix cannot be empty")
+		}
+		if updated.MaxTokenLength < 0 {
+			return fmt.Errorf("❌ --max-token-length cannot be negative")
+		}
+
+		changes := platformDiff(&before, &updated)
+		if len(changes) == 0 {
+			fmt.Printf("ℹ️ No changes for platform %s\n", color.GreenString(id))
+			return nil
+		}
+
+		updated.Custom = true
+		customPlatforms[id] = &updated
+		if err := platform.SaveCustomPlatforms(customPlatforms); err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+
+		fmt.Printf("✅ Updated platform %s\n", color.GreenString(id))
+		hostAliasesChanged := false
+		for _, change := range changes {
+			fmt.Println(color.RedString("  - %s: %s", change.field, change.before))
+			fmt.Println(color.GreenString("  + %s: %s", change.field, change.after))
+			switch change.field {
+			case "name", "defaultHost", "sshUser":
+				hostAliasesChanged = true
+			}
+		}
+
+		// Host aliases in ~/.ssh/gat_config name the platform's host and user
+		if hostAliasesChanged {
+			return updatePlatformHostAliases(id)
+		}
+		return nil
+	},
+}
+
+// updatePlatformHostAliases rewrites the host aliases in ~/.ssh/gat_config
+// of the SSH profiles that use the platform, after the platform changed
+func updatePlatformHostAliases(id string) error {
+	validConfig, _, ioErr := config.LoadConfig()
+	if ioErr != nil {
+		return ioErr
+	}
+	for _, name := range config.ListProfileNames(&validConfig) {
+		profile := validConfig.Profiles[name]
+		if profile.GetPlatform() != id || profile.AuthMethod != "ssh" || len(profile.SSHIdentities) == 0 {
+			continue
+		}
+		if err := ssh.UpdateSSHConfig(id, name, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+			fmt.Printf(color.YellowString("⚠️ Warning: Failed to update SSH config for %s: %v\n"), name, err)
+		}
+	}
+	return nil
+}
+
+// platformFieldChange is one changed field in 'gat platforms update'
+type platformFieldChange struct {
+	field  string
+	before string
+	after  string
+}
+
+// platformDiff lists the fields that differ between two platforms, using
+// the YAML field names
+func platformDiff(before, after *platform.Platform) []platformFieldChange {
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"name", before.Name, after.Name},
+		{"defaultHost", before.DefaultHost, after.DefaultHost},
+		{"sshPrefix", before.SSHPrefix, after.SSHPrefix},
+		{"httpsPrefix", before.HTTPSPrefix, after.HTTPSPrefix},
+		{"sshUser", before.SSHUser, after.SSHUser},
+		{"tokenAuthScope", before.TokenAuthScope, after.TokenAuthScope},
+		{"tokenPrefix", before.TokenPrefix, after.TokenPrefix},
+		{"maxTokenLength", strconv.Itoa(before.MaxTokenLength), strconv.Itoa(after.MaxTokenLength)},
+		{"oauthScopes", strings.Join(before.OAuthScopes, ","), strings.Join(after.OAuthScopes, ",")},
+		{"apiCompatibility", before.APICompatibility, after.APICompatibility},
+		{"credentialHelper", before.CredentialHelper, after.CredentialHelper},
+	}
+
+	var changes []platformFieldChange
+	for _, field := range fields {
+		if field.before != field.after {
+			changes = append(changes, platformFieldChange{field.name, field.before, field.after})
+		}
+	}
+	return changes
+}
+
+func init() {
+	platformsCmd.AddCommand(platformUpdateCmd)
+
+	// Same flags as register, all optional
+	platformUpdateCmd.Flags().String("name", "", "Display name")
+	platformUpdateCmd.Flags().String("host", "", "Default hostname")
+	platformUpdateCmd.Flags().String("ssh-prefix", "", "SSH URL prefix")
+	platformUpdateCmd.Flags().String("https-prefix", "", "HTTPS URL prefix")
+	platformUpdateCmd.Flags().String("ssh-user", "", "SSH username")
+	platformUpdateCmd.Flags().String("token-scope", "", "Token authentication scope")
+	platformUpdateCmd.Flags().String("token-prefix", "", "Accepted token prefixes, comma-separated")
+	platformUpdateCmd.Flags().Int("max-token-length", 0, "Maximum token length (0 = unlimited)")
+	platformUpdateCmd.Flags().StringSlice("oauth-scopes", nil, "Token scopes gat needs, comma-separated")
+	platformUpdateCmd.Flags().String("yaml", "", "Path to a partial YAML file with the fields to update")
+}
@@ -2,20 +2,27 @@ package main
 
 import (
 	"fmt"
+	"gat/pkg/output"
 	"gat/pkg/platform"
+	"os"
+	"sort"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	platformsOutput string // Output format shared by all platforms subcommands
+)
+
 // platformsCmd represents the platforms command
 var platformsCmd = &cobra.Command{
 	Use:   "platforms",
 	Short: "🌐 Manage supported Git hosting platforms",
 	Long:  `🌐 List, register, and manage Git hosting platforms supported by gat.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Default behavior is to list platforms
-		listPlatformsCmd.Run(cmd, args)
+		return listPlatformsCmd.RunE(cmd, args)
 	},
 }
 
@@ -24,13 +31,26 @@ var listPlatformsCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List built-in and custom Git hosting platforms",
 	Long:  `Display all supported Git hosting platforms, including built-in and custom user-defined platforms.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(platformsOutput)
+		if err != nil {
+			return err
+		}
+
 		// Create a new platform registry
 		reg := platform.NewRegistry()
 
 		// Get all platforms
 		platforms := reg.ListPlatforms()
 
+		// Structured output is a flat array sorted by ID for stable diffs
+		if format.IsStructured() {
+			sort.Slice(platforms, func(i, j int) bool {
+				return platforms[i].ID < platforms[j].ID
+			})
+			return output.Write(os.Stdout, format, platforms)
+		}
+
 		// Print header
 		fmt.Println("🌐 Supported Git hosting platforms:")
 		fmt.Println()
@@ -70,10 +90,15 @@ var listPlatformsCmd = &cobra.Command{
 			fmt.Println("To register a custom platform, use the command:")
 			fmt.Printf("  %s\n", color.YellowString("gat platforms register --help"))
 		}
+
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(platformsCmd)
 	platformsCmd.AddCommand(listPlatformsCmd)
+
+	// Persistent so every platforms subcommand inherits it
+	platformsCmd.PersistentFlags().StringVarP(&platformsOutput, "output", "o", "text", "Output format ('text', 'json', or 'yaml')")
 }
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileOutput string // Output format shared by all profile subcommands
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "👤 Inspect and validate individual profiles",
+	Long:  `👤 Commands that operate on a single Git profile in detail.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+
+	// Persistent so every profile subcommand inherits it
+	profileCmd.PersistentFlags().StringVarP(&profileOutput, "output", "o", "text", "Output format ('text', 'json', or 'yaml')")
+}
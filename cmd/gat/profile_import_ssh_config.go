@@ -0,0 +1,259 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importSSHConfigPath string
+)
+
+// sshImportCandidate is a Host block that could become a gat profile
+type sshImportCandidate struct {
+	block    ssh.HostBlock
+	platform *platform.Platform
+}
+
+// profileNameUnsafeChars matches characters not allowed in profile names
+var profileNameUnsafeChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// profileImportSSHConfigCmd represents the import-ssh-config subcommand of profile
+var profileImportSSHConfigCmd = &cobra.Command{
+	Use:   "import-ssh-config",
+	Short: "Suggest profiles for the Git hosts in ~/.ssh/config",
+	Long: `Reads the Host blocks in ~/.ssh/config and, for each one whose HostName is
+a known platform host (e.g. github.com), offers to create an SSH profile with
+its IdentityFile pre-filled. Hosts managed by gat (~/.ssh/gat_config) and
+wildcard patterns are skipped.
+
+Without a terminal the matching hosts are listed with a 'gat add' command
+for each instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		candidates, err := findSSHImportCandidates(importSSHConfigPath)
+		if err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			fmt.Printf("😶 No Host blocks for known platforms found in %s\n", importSSHConfigPath)
+			return nil
+		}
+
+		if !stdinIsTerminal() {
+			fmt.Printf("🔍 Found %d Git host(s) in %s:\n", len(candidates), importSSHConfigPath)
+			for _, candidate := range candidates {
+				fmt.Printf("\n  Host %s (%s)\n", color.GreenString(strings.Join(candidate.block.Patterns, " ")), candidate.platform.Name)
+				suggestion := fmt.Sprintf("gat add %s --platform %s --ssh-identity %s",
+					suggestProfileName(candidate.block, &validConfig), candidate.platform.ID, identityOrPlaceholder(candidate.block))
+				if candidate.block.CertificateFile != "" {
+					suggestion += " --ssh-cert " + ssh.CollapseIdentityPath(candidate.block.CertificateFile)
+				}
+				fmt.Printf("    %s --username <username> --email <email>\n", suggestion)
+			}
+			return nil
+		}
+
+		created := 0
+		for _, candidate := range candidates {
+			ok, err := importSSHHost(&validConfig, candidate)
+			if err != nil {
+				return err
+			}
+			if ok {
+				created++
+			}
+		}
+		fmt.Printf("\n✅ Created %d profile(s)\n", created)
+		return nil
+	},
+}
+
+// findSSHImportCandidates returns the Host blocks in configPath that point at
+// a known platform host and are not already managed by gat
+func findSSHImportCandidates(configPath string) ([]sshImportCandidate, error) {
+	blocks, err := ssh.ParseSSHConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aliases gat already manages live in gat_config
+	gatBlocks, err := ssh.ParseSSHConfig(filepath.Join(filepath.Dir(configPath), "gat_config"))
+	if err != nil {
+		return nil, err
+	}
+	gatHosts := make(map[string]bool)
+	for _, block := range gatBlocks {
+		for _, pattern := range block.Patterns {
+			gatHosts[pattern] = true
+		}
+	}
+
+	reg := platform.NewRegistry()
+	var candidates []sshImportCandidate
+	for _, block := range blocks {
+		if block.ManagedByGat || block.IsWildcard() || gatHosts[block.Patterns[0]] {
+			continue
+		}
+		plat, err := reg.GetPlatformByHost(block.ResolvedHostName())
+		if err != nil {
+			continue // Not a Git host gat knows about
+		}
+		candidates = append(candidates, sshImportCandidate{block: block, platform: plat})
+	}
+	return candidates, nil
+}
+
+// importSSHHost walks the user through creating a profile for one Host
+// block. It returns false if the user skipped it.
+func importSSHHost(cfg *config.Config, candidate sshImportCandidate) (bool, error) {
+	block := candidate.block
+	fmt.Printf("\n🔑 Host %s -> %s (%s)\n", color.GreenString(strings.Join(block.Patterns, " ")), block.ResolvedHostName(), candidate.platform.Name)
+	if block.IdentityFile != "" {
+		fmt.Printf("   IdentityFile: %s\n", block.IdentityFile)
+	}
+
+	confirm := promptui.Prompt{Label: "Create a gat profile for this host", IsConfirm: true}
+	if _, err := confirm.Run(); err != nil {
+		if errors.Is(err, promptui.ErrInterrupt) {
+			return false, fmt.Errorf("❌ import canceled")
+		}
+		return false, nil
+	}
+
+	namePrompt := promptui.Prompt{
+		Label:   "Profile name",
+		Default: suggestProfileName(block, cfg),
+		Validate: func(input string) error {
+			if err := config.ValidateProfileName(input); err != nil {
+				return err
+			}
+			if _, exists := cfg.Profiles[input]; exists {
+				return fmt.Errorf("profile '%s' already exists", input)
+			}
+			return nil
+		},
+	}
+	profileName, err := namePrompt.Run()
+	if err != nil {
+		return false, fmt.Errorf("❌ import canceled")
+	}
+
+	usernamePrompt := promptui.Prompt{
+		Label: fmt.Sprintf("%s username", candidate.platform.Name),
+		Validate: func(input string) error {
+			if !config.ValidGitHubUsernameRegex.MatchString(input) {
+				return fmt.Errorf("invalid username format")
+			}
+			return nil
+		},
+	}
+	profileUsername, err := usernamePrompt.Run()
+	if err != nil {
+		return false, fmt.Errorf("❌ import canceled")
+	}
+
+	emailPrompt := promptui.Prompt{
+		Label: "Git email",
+		Validate: func(input string) error {
+			_, err := config.ValidateEmail(input)
+			return err
+		},
+	}
+	profileEmail, err := emailPrompt.Run()
+	if err != nil {
+		return false, fmt.Errorf("❌ import canceled")
+	}
+
+	identityPrompt := promptui.Prompt{
+		Label:   "SSH identity",
+		Default: block.IdentityFile,
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return fmt.Errorf("an SSH identity is required")
+			}
+			return nil
+		},
+	}
+	identity, err := identityPrompt.Run()
+	if err != nil {
+		return false, fmt.Errorf("❌ import canceled")
+	}
+
+	profile := config.Profile{
+		Username:   profileUsername,
+		Email:      profileEmail,
+		Platform:   candidate.platform.ID,
+		AuthMethod: "ssh",
+	}
+	profile.SetSSHIdentity(ssh.CollapseIdentityPath(identity))
+	if block.CertificateFile != "" {
+		profile.SSHCertPath = ssh.CollapseIdentityPath(block.CertificateFile)
+	}
+	if err := config.AddProfile(cfg, profileName, profile, false); err != nil {
+		return false, err
+	}
+	if len(cfg.Profiles) == 1 {
+		cfg.Current = profileName
+	}
+	if err := config.SaveConfig(cfg); err != nil {
+		return false, err
+	}
+	if err := ssh.UpdateSSHConfig(profile.Platform, profileName, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+		fmt.Printf(color.YellowString("⚠️ Profile saved, but the SSH host alias could not be written: %v\n"), err)
+	}
+
+	fmt.Printf("✅ Added profile: %s (%s on %s, auth: ssh)\n", color.GreenString(profileName), profileUsername, candidate.platform.ID)
+	return true, nil
+}
+
+// suggestProfileName derives a free profile name from a Host block's alias,
+// e.g. "github.com-work" -> "github-com-work"
+func suggestProfileName(block ssh.HostBlock, cfg *config.Config) string {
+	base := strings.Trim(profileNameUnsafeChars.ReplaceAllString(block.Patterns[0], "-"), "-")
+	if base == "" || config.ValidateProfileName(base) != nil {
+		base = "imported"
+	}
+
+	name := base
+	for i := 2; ; i++ {
+		if _, exists := cfg.Profiles[name]; !exists {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// identityOrPlaceholder returns the block's IdentityFile for a suggested command
+func identityOrPlaceholder(block ssh.HostBlock) string {
+	if block.IdentityFile == "" {
+		return "<path>"
+	}
+	return ssh.CollapseIdentityPath(block.IdentityFile)
+}
+
+func init() {
+	profileCmd.AddCommand(profileImportSSHConfigCmd)
+
+	defaultPath := "~/.ssh/config"
+	if expanded, err := utils.ExpandHome(defaultPath); err == nil {
+		defaultPath = expanded
+	}
+	profileImportSSHConfigCmd.Flags().StringVar(&importSSHConfigPath, "ssh-config", defaultPath, "SSH client config file to read")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reorderReset bool
+)
+
+// Moves offered for the profile picked in 'gat profile reorder'
+const (
+	moveUp     = "⬆️  Move up"
+	moveDown   = "⬇️  Move down"
+	moveTop    = "⏫ Move to top"
+	moveBottom = "⏬ Move to bottom"
+)
+
+// Menu entries after the profiles in 'gat profile reorder'
+const (
+	reorderSave   = "💾 Save order"
+	reorderCancel = "✖  Cancel"
+)
+
+// profileReorderCmd represents the profile reorder command
+var profileReorderCmd = &cobra.Command{
+	Use:   "reorder [name...]",
+	Short: "Change the order profiles are listed in",
+	Long: `Change the order 'gat list', shell completion and the REST API list
+profiles in. Without arguments an interactive list lets you pick profiles and
+move them; with arguments the named profiles come first, in that order.
+Profiles left out follow alphabetically.
+
+Examples:
+  gat profile reorder
+  gat profile reorder personal work
+  gat profile reorder --reset`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		if len(validConfig.Profiles) == 0 {
+			fmt.Println("😶 No valid profiles found. Add one with 'gat add <name>'")
+			return nil
+		}
+
+		switch {
+		case reorderReset:
+			if len(args) > 0 {
+				return fmt.Errorf("❌ --reset does not take profile names")
+			}
+			validConfig.ProfileOrdering = nil
+		case len(args) > 0:
+			if err := config.SetProfileOrdering(&validConfig, args); err != nil {
+				return err
+			}
+		default:
+			if !stdinIsTerminal() {
+				return fmt.Errorf("❌ no terminal to reorder interactively; pass the profile names in order")
+			}
+			ordering, saved, err := reorderInteractively(config.ListProfileNames(&validConfig))
+			if err != nil {
+				return err
+			}
+			if !saved {
+				fmt.Println("✖ Order unchanged")
+				return nil
+			}
+			validConfig.ProfileOrdering = ordering
+		}
+
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Profile order: %s\n", color.GreenString(strings.Join(config.ListProfileNames(&validConfig), ", ")))
+		return nil
+	},
+}
+
+// reorderInteractively lets the user pick a profile and move it until they
+// save or cancel. It returns the new order and whether it should be saved.
+func reorderInteractively(names []string) ([]string, bool, error) {
+	cursor := 0
+	for {
+		items := make([]string, 0, len(names)+2)
+		for i, name := range names {
+			items = append(items, fmt.Sprintf("%d. %s", i+1, name))
+		}
+		items = append(items, reorderSave, reorderCancel)
+
+		pick := promptui.Select{
+			Label:     "Pick a profile to move",
+			Items:     items,
+			Size:      len(items),
+			CursorPos: cursor,
+		}
+		index, _, err := pick.Run()
+		if err != nil {
+			if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("❌ reorder failed: %w", err)
+		}
+		switch items[index] {
+		case reorderSave:
+			return names, true, nil
+		case reorderCancel:
+			return nil, false, nil
+		}
+
+		move := promptui.Select{
+			Label: fmt.Sprintf("Move '%s'", names[index]),
+			Items: []string{moveUp, moveDown, moveTop, moveBottom},
+		}
+		_, action, err := move.Run()
+		if err != nil {
+			cursor = index
+			continue
+		}
+
+		target := index
+		switch action {
+		case moveUp:
+			target = max(index-1, 0)
+		case moveDown:
+			target = min(index+1, len(names)-1)
+		case moveTop:
+			target = 0
+		case moveBottom:
+			target = len(names) - 1
+		}
+		names = moveName(names, index, target)
+		cursor = target
+	}
+}
+
+// moveName returns names with the entry at from moved to position to
+func moveName(names []string, from, to int) []string {
+	name := names[from]
+	rest := append(append([]string{}, names[:from]...), names[from+1:]...)
+	moved := append([]string{}, rest[:to]...)
+	moved = append(moved, name)
+	return append(moved, rest[to:]...)
+}
+
+func init() {
+	profileCmd.AddCommand(profileReorderCmd)
+
+	profileReorderCmd.Flags().BoolVar(&reorderReset, "reset", false, "Forget the custom order and list profiles alphabetically")
+	profileReorderCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completionProfileNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/output"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Placeholders shown instead of sensitive values
+const (
+	valuePresent = "[present]"
+	valueAbsent  = "[absent]"
+)
+
+// profileDetails is the structured output of 'gat profile show'
+type profileDetails struct {
+	Name              string   `json:"name"`
+	Active            bool     `json:"active"`
+	Username          string   `json:"username"`
+	Email             string   `json:"email"`
+	Platform          string   `json:"platform"`
+	Host              string   `json:"host"`
+	AuthMethod        string   `json:"auth_method"`
+	SSHIdentity       string   `json:"ssh_identity,omitempty"` // Primary identity
+	SSHIdentities     []string `json:"ssh_identities,omitempty"`
+	SSHKeyPermissions string   `json:"ssh_key_permissions,omitempty"`
+	SSHFingerprint    string   `json:"ssh_fingerprint,omitempty"`
+	SSHCertificate    string   `json:"ssh_certificate,omitempty"`
+	SSHCertValidity   string   `json:"ssh_certificate_validity,omitempty"`
+	Token             string   `json:"token"`
+	TokenFingerprint  string   `json:"token_fingerprint,omitempty"`
+	WorkingDirectory  string   `json:"working_directory,omitempty"`
+	GnupgHome         string   `json:"gnupghome,omitempty"`
+	GPGKey            string   `json:"gpg_key,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+}
+
+// descriptionWidth is the column 'gat profile show' wraps descriptions at
+const descriptionWidth = 72
+
+// profileShowCmd represents the profile show command
+var profileShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "🔍 Show every field of a single profile",
+	Long: `🔍 Prints a detailed view of one profile, including SSH key permissions and
+fingerprint. Tokens are never printed; only their presence and a short
+fingerprint are shown.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName := args[0]
+
+		format, err := output.ParseFormat(profileOutput)
+		if err != nil {
+			return err
+		}
+
+		// Validate profile name for security
+		if err := config.ValidateProfileName(profileName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
+		if validationErr, isInvalid := validationErrors[profileName]; isInvalid {
+			return fmt.Errorf("❌ profile '%s' failed validation: %v", profileName, validationErr)
+		}
+
+		profile, exists := validConfig.Profiles[profileName]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+		}
+
+		details := collectProfileDetails(profileName, profile, validConfig.Current == profileName)
+
+		if format.IsStructured() {
+			return output.Write(os.Stdout, format, details)
+		}
+
+		printProfileDetails(details)
+		return nil
+	},
+}
+
+// collectProfileDetails gathers the displayable fields of a profile
+func collectProfileDetails(name string, profile config.Profile, active bool) profileDetails {
+	details := profileDetails{
+		Name:             name,
+		Active:           active,
+		Username:         profile.Username,
+		Email:            profile.Email,
+		Platform:         profile.GetPlatform(),
+		Host:             profile.Host,
+		AuthMethod:       profile.AuthMethod,
+		SSHIdentity:      profile.SSHIdentity(),
+		SSHIdentities:    profile.SSHIdentities,
+		SSHCertificate:   profile.SSHCertPath,
+		Token:            valueAbsent,
+		WorkingDirectory: profile.WorkingDirectory,
+		GnupgHome:        profile.GnupghomeOverride,
+		GPGKey:           profile.GPGKey,
+		Description:      profile.Description,
+		Tags:             profile.Tags,
+	}
+
+	// Fall back to the platform's default host
+	if details.Host == "" {
+		if plat, err := platform.NewRegistry().GetPlatform(details.Platform); err == nil {
+			details.Host = plat.DefaultHost
+		}
+	}
+
+	if token := profile.GetToken(); token != "" {
+		details.Token = valuePresent
+		details.TokenFingerprint = config.TokenFingerprint(token)
+	}
+
+	// Permissions and fingerprint are those of the primary identity
+	if len(profile.SSHIdentities) > 0 {
+		if err := ssh.ValidateIdentityPermissions(profile.SSHIdentity()); err != nil {
+			details.SSHKeyPermissions = err.Error()
+		} else {
+			details.SSHKeyPermissions = "ok"
+		}
+		if fingerprint, err := ssh.GetKeyFingerprint(profile.SSHIdentity()); err == nil {
+			details.SSHFingerprint = fingerprint
+		}
+	}
+	if profile.SSHCertPath != "" {
+		if validity, _, err := ssh.GetCertificateValidity(profile.SSHCertPath); err == nil {
+			details.SSHCertValidity = validity
+		}
+	}
+
+	return details
+}
+
+// printProfileDetails prints the text view of 'gat profile show'
+func printProfileDetails(details profileDetails) {
+	title := color.GreenString(details.Name)
+	if details.Active {
+		title += color.GreenString(" (active)")
+	}
+	fmt.Printf("👤 Profile: %s\n", title)
+	fmt.Printf("   👤 Username: %s\n", details.Username)
+	fmt.Printf("   📧 Email: %s\n", details.Email)
+	fmt.Printf("   🌐 Platform: %s\n", details.Platform)
+	fmt.Printf("   🖥️ Host: %s\n", formatValue(details.Host))
+	fmt.Printf("   🔒 Auth Method: %s\n", details.AuthMethod)
+
+	if details.SSHIdentity != "" {
+		fmt.Printf("   🔑 SSH Key: %s\n", details.SSHIdentity)
+		if details.SSHKeyPermissions == "ok" {
+			fmt.Printf("   🔐 Key Permissions: %s\n", color.GreenString("✓"))
+		} else {
+			fmt.Printf("   🔐 Key Permissions: %s\n", color.RedString(details.SSHKeyPermissions))
+		}
+		fmt.Printf("   🧬 Key Fingerprint: %s\n", formatValue(details.SSHFingerprint))
+		if details.SSHCertificate != "" {
+			fmt.Printf("   📜 SSH Certificate: %s (valid: %s)\n", details.SSHCertificate, formatValue(details.SSHCertValidity))
+		}
+		if len(details.SSHIdentities) > 1 {
+			fmt.Printf("   🗝️ Other SSH Keys: %s\n", strings.Join(details.SSHIdentities[1:], ", "))
+		}
+	} else {
+		fmt.Printf("   🔑 SSH Key: %s\n", color.CyanString("-"))
+	}
+
+	if details.TokenFingerprint != "" {
+		fmt.Printf("   🎟️ Token: %s (fingerprint %s)\n", details.Token, details.TokenFingerprint)
+	} else {
+		fmt.Printf("   🎟️ Token: %s\n", details.Token)
+	}
+
+	if details.WorkingDirectory != "" {
+		fmt.Printf("   📂 Working Directory: %s\n", details.WorkingDirectory)
+	} else {
+		fmt.Printf("   📂 Working Directory: %s\n", color.CyanString("-"))
+	}
+
+	if details.GnupgHome != "" {
+		fmt.Printf("   🔏 GNUPGHOME: %s\n", details.GnupgHome)
+	}
+
+	if details.GPGKey != "" {
+		fmt.Printf("   ✍️ GPG Key: %s\n", details.GPGKey)
+	}
+
+	if len(details.Tags) > 0 {
+		fmt.Printf("   🏷️ Tags: %s\n", strings.Join(details.Tags, ", "))
+	}
+
+	if details.Description != "" {
+		fmt.Println("   📝 Description:")
+		for _, line := range strings.Split(output.RenderMarkdown(details.Description, descriptionWidth), "\n") {
+			if line == "" {
+				fmt.Println()
+			} else {
+				fmt.Printf("      %s\n", line)
+			}
+		}
+	}
+}
+
+func init() {
+	profileCmd.AddCommand(profileShowCmd)
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/output"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateStrict bool
+)
+
+// Check statuses reported by profile validation
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// validationCheck is the result of a single profile validation check
+type validationCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// validationReport is the structured output of 'gat profile validate'
+type validationReport struct {
+	Profile string            `json:"profile"`
+	Status  string            `json:"status"`
+	Checks  []validationCheck `json:"checks"`
+}
+
+// profileValidateCmd represents the profile validate command
+var profileValidateCmd = &cobra.Command{
+	Use:   "validate <name>",
+	Short: "✅ Run all validation checks on a single profile",
+	Long: `✅ Runs every validation check on a single profile and reports the results.
+
+A profile with a token also has it checked against the platform API, like
+'gat verify'; a rejected token fails, while a network problem is only a
+warning.
+
+Intended for CI pre-flight checks. Exit codes:
+  0  all checks passed
+  1  at least one check failed (or a warning with --strict)
+  2  warnings only`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName := args[0]
+
+		format, err := output.ParseFormat(profileOutput)
+		if err != nil {
+			return err
+		}
+
+		// Validate profile name for security
+		if err := config.ValidateProfileName(profileName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		// Load configuration; validation errors for the target are a check failure
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
+
+		checks := validateProfileChecks(cmd.Context(), &validConfig, validationErrors, profileName)
+
+		// Overall status is the worst individual status
+		status := checkPass
+		for _, check := range checks {
+			if check.Status == checkFail {
+				status = checkFail
+				break
+			}
+			if check.Status == checkWarn {
+				status = checkWarn
+			}
+		}
+		if validateStrict && status == checkWarn {
+			status = checkFail
+		}
+
+		if format.IsStructured() {
+			report := validationReport{Profile: profileName, Status: status, Checks: checks}
+			if err := output.Write(os.Stdout, format, report); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("✅ Validating profile: %s\n", color.GreenString(profileName))
+			for _, check := range checks {
+				fmt.Printf("  %s %s", formatCheckStatus(check.Status), check.Name)
+				if check.Message != "" {
+					fmt.Printf(": %s", check.Message)
+				}
+				fmt.Println()
+			}
+			fmt.Println()
+		}
+
+		switch status {
+		case checkFail:
+			if format.IsStructured() {
+				os.Exit(1)
+			}
+			return fmt.Errorf("❌ profile '%s' failed validation", profileName)
+		case checkWarn:
+			if !format.IsStructured() {
+				fmt.Println(color.YellowString("⚠️ Profile '%s' passed with warnings", profileName))
+			}
+			os.Exit(2)
+		}
+
+		if !format.IsStructured() {
+			fmt.Println(color.GreenString("✅ Profile '%s' passed all checks", profileName))
+		}
+		return nil
+	},
+}
+
+// validateProfileChecks runs the individual checks for one profile
+func validateProfileChecks(ctx context.Context, cfg *config.Config, validationErrors map[string]error, name string) []validationCheck {
+	// Stored field validation happens in LoadConfig
+	if validationErr, invalid := validationErrors[name]; invalid {
+		return []validationCheck{{Name: "Configuration", Status: checkFail, Message: validationErr.Error()}}
+	}
+	profile, exists := cfg.Profiles[name]
+	if !exists {
+		return []validationCheck{{Name: "Configuration", Status: checkFail, Message: fmt.Sprintf("profile '%s' does not exist", name)}}
+	}
+
+	checks := []validationCheck{{Name: "Configuration", Status: checkPass}}
+
+	// Email format is a warning only, matching LoadConfig, unless strict
+	// validation is enabled
+	strict, err := config.ValidateEmail(profile.Email)
+	switch {
+	case err != nil:
+		checks = append(checks, validationCheck{Name: "Email", Status: checkWarn, Message: err.Error()})
+	case !strict && cfg.StrictEmailValidation:
+		checks = append(checks, validationCheck{Name: "Email", Status: checkFail, Message: config.StrictEmailError(profile.Email).Error()})
+	case !strict:
+		checks = append(checks, validationCheck{Name: "Email", Status: checkWarn, Message: config.StrictEmailError(profile.Email).Error()})
+	default:
+		checks = append(checks, validationCheck{Name: "Email", Status: checkPass})
+	}
+
+	// Platform must be known to the registry
+	reg := platform.NewRegistry()
+	plat, err := reg.GetPlatform(profile.GetPlatform())
+	if err != nil {
+		checks = append(checks, validationCheck{Name: "Platform", Status: checkFail, Message: err.Error()})
+	} else {
+		checks = append(checks, validationCheck{Name: "Platform", Status: checkPass})
+	}
+
+	if profile.AuthMethod == "ssh" {
+		checks = append(checks, validateSSHChecks(&profile, name)...)
+	} else {
+		// HTTPS profiles can still prompt for credentials, so a missing token is a warning
+		if profile.GetToken() == "" {
+			checks = append(checks, validationCheck{Name: "Token", Status: checkWarn, Message: "HTTPS profile has no token configured"})
+		} else {
+			checks = append(checks, validationCheck{Name: "Token", Status: checkPass})
+		}
+	}
+
+	if plat != nil && (profile.GetToken() != "" || profile.IsPasswordProtected()) {
+		checks = append(checks, validateTokenAPICheck(ctx, plat, &profile, name))
+	}
+
+	return checks
+}
+
+// validateTokenAPICheck checks the profile's token against the platform API
+// like 'gat verify'. Only a rejected token fails; anything that keeps the
+// check from running, such as a network error, is a warning.
+func validateTokenAPICheck(ctx context.Context, plat *platform.Platform, profile *config.Profile, name string) validationCheck {
+	if err := config.UnlockProfileToken(name, profile); err != nil {
+		return validationCheck{Name: "Token API", Status: checkWarn, Message: err.Error()}
+	}
+
+	info, err := plat.VerifyToken(ctx, profile.Host, profile.GetToken())
+	switch {
+	case errors.Is(err, platform.ErrTokenRejected):
+		return validationCheck{Name: "Token API", Status: checkFail, Message: err.Error()}
+	case err != nil:
+		return validationCheck{Name: "Token API", Status: checkWarn, Message: fmt.Sprintf("could not verify the token: %v", err)}
+	case !strings.EqualFold(info.Username, profile.Username):
+		return validationCheck{Name: "Token API", Status: checkWarn, Message: fmt.Sprintf("token authenticates as %s, not the profile's username %s", info.Username, profile.Username)}
+	}
+	return validationCheck{Name: "Token API", Status: checkPass, Message: fmt.Sprintf("authenticates as %s", info.Username)}
+}
+
+// validateSSHChecks runs the SSH identity checks for an SSH profile
+func validateSSHChecks(profile *config.Profile, name string) []validationCheck {
+	if len(profile.SSHIdentities) == 0 {
+		return []validationCheck{{Name: "SSH Identity", Status: checkFail, Message: "SSH profile has no identity path configured"}}
+	}
+
+	missing, err := ssh.CheckSSHIdentities(profile.SSHIdentities, profile.SSHCertPath)
+	if err != nil {
+		return []validationCheck{{Name: "SSH Identity", Status: checkFail, Message: err.Error()}}
+	}
+	if missing != "" {
+		return []validationCheck{{Name: "SSH Identity", Status: checkFail, Message: fmt.Sprintf("SSH identity file or public key not found: %s", missing)}}
+	}
+	checks := []validationCheck{{Name: "SSH Identity", Status: checkPass}}
+
+	// OpenSSH refuses keys that are readable by others
+	permissions := validationCheck{Name: "SSH Key Permissions", Status: checkPass}
+	for _, identity := range profile.SSHIdentities {
+		if err := ssh.ValidateIdentityPermissions(identity); err != nil {
+			permissions = validationCheck{Name: "SSH Key Permissions", Status: checkFail, Message: err.Error()}
+			break
+		}
+	}
+	checks = append(checks, permissions)
+
+	// The host alias is needed for profile-specific remotes
+	hostAlias := platform.GetProfileSSHHost(profile.GetPlatform(), name)
+	hostExists, err := ssh.CheckSSHHostExists(hostAlias)
+	if err != nil {
+		checks = append(checks, validationCheck{Name: "SSH Host Alias", Status: checkWarn, Message: err.Error()})
+	} else if !hostExists {
+		checks = append(checks, validationCheck{Name: "SSH Host Alias", Status: checkWarn, Message: fmt.Sprintf("host alias '%s' not found in SSH configuration", hostAlias)})
+	} else {
+		checks = append(checks, validationCheck{Name: "SSH Host Alias", Status: checkPass})
+	}
+
+	return checks
+}
+
+// formatCheckStatus formats a check status as a colored glyph
+func formatCheckStatus(status string) string {
+	switch status {
+	case checkPass:
+		return color.GreenString("✓")
+	case checkWarn:
+		return color.YellowString("⚠️")
+	}
+	return color.RedString("✗")
+}
+
+func init() {
+	profileCmd.AddCommand(profileValidateCmd)
+
+	profileValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Treat warnings as failures")
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"gat/pkg/audit"
 	"gat/pkg/config"
+	"gat/pkg/utils"
 	"strings"
 
 	"github.com/fatih/color"
@@ -13,15 +15,33 @@ import (
 var (
 	forceRemove bool
 	noBackup    bool
+	removeTags  []string
 )
 
 var removeCmd = &cobra.Command{
 	Use:   "remove <name>",
 	Short: "🗑️ Remove a GitHub profile",
-	Long:  `🗑️ Removes a GitHub profile from your configuration.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `🗑️ Removes a GitHub profile from your configuration.
+
+With --tag (repeatable) instead of a name, gat asks which of the profiles
+with all of the given tags to remove.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		profileName := args[0]
+		var profileName string
+		switch {
+		case len(removeTags) > 0 && len(args) > 0:
+			return fmt.Errorf("❌ give a profile name or --tag, not both")
+		case len(removeTags) > 0:
+			name, err := pickTaggedProfile(removeTags, "Remove")
+			if err != nil {
+				return err
+			}
+			profileName = name
+		case len(args) == 0:
+			return fmt.Errorf("❌ give the name of the profile to remove, or --tag")
+		default:
+			profileName = args[0]
+		}
 
 		// Validate profile name for security
 		if err := config.ValidateProfileName(profileName); err != nil {
@@ -33,6 +53,9 @@ var removeCmd = &cobra.Command{
 		if ioErr != nil {
 			return ioErr // Handle file I/O or parsing errors first
 		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
 		if len(validationErrors) > 0 {
 			// Check if the target profile itself failed validation
 			if _, isInvalid := validationErrors[profileName]; isInvalid {
@@ -52,7 +75,7 @@ var removeCmd = &cobra.Command{
 		if _, exists := validConfig.Profiles[profileName]; !exists {
 			// If it didn't exist in validationErrors either, it's truly not found
 			if _, wasInvalid := validationErrors[profileName]; !wasInvalid {
-				return fmt.Errorf("❌ profile '%s' does not exist", profileName)
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
 			} // If it *was* invalid, the error was already returned above.
 			// This path shouldn't normally be reached due to the check above, but covers edge cases.
 			return fmt.Errorf("❌ profile '%s' not found (it may have failed validation)", profileName)
@@ -96,6 +119,8 @@ var removeCmd = &cobra.Command{
 		}
 
 		fmt.Println(color.RedString("🗑️ Profile '%s' has been destroyed. Poof. 💨", profileName))
+		notifyWebhooks(config.WebhookEventRemove, profileName)
+		logAudit(audit.EventRemove, profileName, utils.Ternary(noBackup, "no backup", "backed up"))
 		return nil
 	},
 }
@@ -106,4 +131,6 @@ func init() {
 	// Add flags
 	removeCmd.Flags().BoolVar(&forceRemove, "force", false, "Skip confirmation prompt (useful for scripts)")
 	removeCmd.Flags().BoolVar(&noBackup, "no-backup", false, "Don't create a backup of the profile before deletion")
+	removeCmd.Flags().StringArrayVar(&removeTags, "tag", nil, "Pick the profile from those with this tag (repeatable: profiles with all the tags)")
+	removeCmd.RegisterFlagCompletionFunc("tag", completeTagFlag)
 }
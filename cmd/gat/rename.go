@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var renameDescription string
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "✏️ Rename a profile",
+	Long: `✏️ Renames a profile, keeping it active if it was. Aliases and the profile
+order follow the new name, and the profile's SSH host alias in
+~/.ssh/gat_config is renamed with it.
+
+Remotes that use the old host alias (git@<platform>-<old-name>:...) stop
+connecting; run 'gat switch <new-name>' in those repositories to rewrite them.
+
+--description replaces the profile's description at the same time (an empty
+value removes it).
+
+Examples:
+  gat rename work acme
+  gat rename work acme --description "Acme GitHub account (alice@acme.com)"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		oldName := config.ResolveAlias(&validConfig, args[0])
+		newName := args[1]
+		if cmd.Flags().Changed("description") {
+			if err := config.ValidateDescription(renameDescription); err != nil {
+				return err
+			}
+		}
+		if err := config.RenameProfile(&validConfig, oldName, newName); err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("description") {
+			profile := validConfig.Profiles[newName]
+			profile.Description = renameDescription
+			validConfig.Profiles[newName] = profile
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+		fmt.Println(color.GreenString("✅ Renamed profile '%s' to '%s'", oldName, newName))
+
+		profile := validConfig.Profiles[newName]
+		if len(profile.SSHIdentities) == 0 {
+			return nil
+		}
+		platformID := profile.GetPlatform()
+		if err := ssh.RenameProfile(oldName, newName, platformID); err != nil {
+			fmt.Println(color.YellowString("⚠️ Could not rename the SSH host alias: %v", err))
+		}
+		warnOldHostAliasRemotes(platformID, oldName, newName)
+		return nil
+	},
+}
+
+// warnOldHostAliasRemotes warns that remotes using a renamed profile's old
+// SSH host alias no longer connect, listing those of the current repository
+func warnOldHostAliasRemotes(platformID, oldName, newName string) {
+	oldAlias := platform.GetProfileSSHHost(platformID, oldName)
+
+	var stale []string
+	if remotes, err := git.GetRemoteURLs(); err == nil {
+		for remote, url := range remotes {
+			// Compare whole aliases: platform IDs may contain dashes
+			if parsed, err := platform.ParseRemoteURL(url); err == nil && strings.EqualFold(parsed.ProfileAlias, oldAlias) {
+				stale = append(stale, remote)
+			}
+		}
+	}
+	sort.Strings(stale)
+
+	for _, remote := range stale {
+		fmt.Println(color.YellowString("⚠️ Remote '%s' of this repository still uses the host alias '%s'", remote, oldAlias))
+	}
+	fmt.Printf("%s Repositories whose remotes use '%s' need 'gat switch %s' to connect again\n", color.YellowString("💡"), oldAlias, newName)
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+
+	renameCmd.Flags().StringVar(&renameDescription, "description", "", fmt.Sprintf("New markdown description of the profile (up to %d characters)", config.MaxDescriptionLength))
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/audit"
+	"gat/pkg/config"
+	"gat/pkg/utils"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreBackup    string
+	restoreOverwrite bool
+	restoreList      bool
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "♻️ Restore a removed profile from its backup",
+	Long: `♻️ Restores a profile from the backup 'gat remove' wrote to
+~/.gat/backups/<name>.backup.json, or from the file given with --backup. A
+profile that still exists is only replaced with --overwrite. The backed-up
+token is kept if it can still be read; otherwise the profile is restored
+without one.
+
+Use --list to see the available backups.
+
+Examples:
+  gat restore work
+  gat restore work --backup ~/.gat/backups/work.backup.1.json --overwrite
+  gat restore --list`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if restoreList {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if restoreList {
+			return listProfileBackups()
+		}
+
+		name := args[0]
+		if err := config.ValidateProfileName(name); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		backupPath, err := utils.ExpandHome(restoreBackup)
+		if err != nil {
+			return err
+		}
+		if backupPath == "" {
+			backupDir, err := config.BackupDir()
+			if err != nil {
+				return err
+			}
+			backupPath = filepath.Join(backupDir, name+".backup.json")
+		}
+		backupName, profile, err := config.ReadProfileBackup(backupPath)
+		if err != nil {
+			return err
+		}
+		if backupName != name {
+			fmt.Printf("ℹ️ Backup holds profile '%s'; restoring it as '%s'\n", backupName, name)
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		if err := config.RestoreProfile(&validConfig, name, profile, restoreOverwrite); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Restored profile %s from %s\n", color.GreenString(name), backupPath)
+		notifyWebhooks(config.WebhookEventAdd, name)
+		logAudit(audit.EventAdd, name, "restored from "+backupPath)
+
+		if validConfig.Current == name {
+			return nil
+		}
+		if !stdinIsTerminal() {
+			fmt.Printf("💡 Run 'gat switch %s' to use it\n", name)
+			return nil
+		}
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Switch to '%s' now", name),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			fmt.Printf("💡 Run 'gat switch %s' to use it\n", name)
+			return nil
+		}
+		return switchCmd.RunE(switchCmd, []string{name})
+	},
+}
+
+// listProfileBackups prints the profile backups in ~/.gat/backups, newest first
+func listProfileBackups() error {
+	backups, err := config.ListProfileBackups()
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Println("📭 No profile backups found")
+		return nil
+	}
+
+	fmt.Println(color.CyanString("💾 Profile backups:"))
+	for _, backup := range backups {
+		fmt.Printf("  %s %s  %s\n", color.GreenString("%-20s", backup.Profile),
+			backup.ModTime.Format("2006-01-02 15:04:05"), filepath.Base(backup.Path))
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().StringVar(&restoreBackup, "backup", "", "Backup file to restore from (default: ~/.gat/backups/<name>.backup.json)")
+	restoreCmd.Flags().BoolVar(&restoreOverwrite, "overwrite", false, "Replace the profile if it still exists")
+	restoreCmd.Flags().BoolVar(&restoreList, "list", false, "List the available profile backups")
+	restoreCmd.MarkFlagsMutuallyExclusive("list", "backup")
+	restoreCmd.MarkFlagsMutuallyExclusive("list", "overwrite")
+}
@@ -3,11 +3,18 @@ package main
 import (
 	"fmt"
 	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/utils"
 	"os"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	configFileFlag string // --config-file override for GAT_CONFIG_FILE
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "gat",
 	Short: "😸 GitHub Account Tool - Manage Git identities across multiple platforms",
@@ -18,6 +25,9 @@ A smart CLI for managing Git identities across GitHub, GitLab, Bitbucket, Huggin
 Switch between profiles, update Git configs, and manage tokens with ease.
 
 Each profile can have its own username, email, token, SSH identity, and platform.
+
+Use --config-file (or the GAT_CONFIG_FILE environment variable) to work with
+an alternate credentials file. The flag takes precedence when both are set.
 `,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Skip this initialization for help commands
@@ -54,6 +64,7 @@ Each profile can have its own username, email, token, SSH identity, and platform
 			}
 		}
 
+		warnPinnedProfile(cmd)
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -64,9 +75,48 @@ Each profile can have its own username, email, token, SSH identity, and platform
 
 func init() {
 	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().StringVar(&configFileFlag, "config-file", "", "Path to an alternate gat credentials file (overrides GAT_CONFIG_FILE)")
+}
+
+// pinCheckSkipped lists commands that do not warn about a pinned profile:
+// those that handle pins themselves and those whose output is parsed
+var pinCheckSkipped = map[string]bool{
+	"switch": true, "pin": true, "unpin": true, "status": true, "whoami": true,
+	"check-dir": true, "check-host": true, "completion": true, "__complete-profiles": true,
+}
+
+// warnPinnedProfile warns on stderr when a .gat pin file (see 'gat pin')
+// names a profile other than the active one. Lookup errors are ignored here;
+// 'gat switch' reports them.
+func warnPinnedProfile(cmd *cobra.Command) {
+	if pinCheckSkipped[cmd.Name()] || os.Getenv(git.ProfileEnvVar) != "" {
+		return
+	}
+	pinned, path, err := config.FindRepoProfile()
+	if err != nil || pinned == "" {
+		return
+	}
+	isActive, active, err := config.IsActiveProfile(pinned)
+	if err != nil || isActive {
+		return
+	}
+	fmt.Fprintln(os.Stderr, color.YellowString("📌 %s pins profile '%s', but '%s' is active. Run 'gat switch %s' to use it.", path, pinned, active, pinned))
 }
 
 // initConfig sets up any configuration needed before running commands
 func initConfig() {
-	// Nothing needed here yet
+	// Route --config-file through GAT_CONFIG_FILE so config.ConfigFilePath picks it up
+	if configFileFlag != "" {
+		path := configFileFlag
+		if expanded, err := utils.ExpandHome(path); err == nil {
+			path = expanded
+		}
+		os.Setenv("GAT_CONFIG_FILE", path)
+	}
+
+	// Password-protected tokens can only be unlocked by asking interactively
+	if stdinIsTerminal() {
+		config.PasswordPrompt = promptEncryptionPassword
+	}
 }
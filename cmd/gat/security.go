@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// securityCmd represents the security command
+var securityCmd = &cobra.Command{
+	Use:   "security",
+	Short: "🛡️ Manage the protection of stored tokens",
+	Long:  `🛡️ Commands for the keys that protect the tokens stored in creds.json.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// securityRotateKeyCmd represents the rotate-key subcommand of security
+var securityRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Re-encrypt stored tokens with a new salt",
+	Long: `Generates a new salt and re-encrypts every encrypted token with the key
+derived from it, for when the salt in creds.json may have been exposed. In
+password mode the new key is derived from the same password, which is asked
+for again (or read from GAT_PASSWORD).
+
+If any token cannot be decrypted with the current key, nothing is changed and
+the profile is reported. The config file is replaced in one step (a temporary
+file renamed over it), so it is never left half rotated.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		// Saving would drop invalid profiles, tokens that failed to decrypt included
+		if len(validationErrors) > 0 {
+			var names []string
+			for name := range validationErrors {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf(color.RedString("  ❌ Profile [%s]: %v\n"), name, validationErrors[name])
+			}
+			return fmt.Errorf("❌ key not rotated; fix or remove these profiles first: %s", strings.Join(names, ", "))
+		}
+
+		var password string
+		if validConfig.UsePasswordEncryption {
+			password = os.Getenv(config.PasswordEnvVar)
+			if password == "" {
+				if !stdinIsTerminal() {
+					return fmt.Errorf("❌ rotating the key of password mode needs the password; set %s", config.PasswordEnvVar)
+				}
+				var err error
+				if password, err = promptEncryptionPassword(); err != nil {
+					return fmt.Errorf("❌ password entry canceled")
+				}
+			}
+		}
+
+		affected, err := config.RotateEncryptionKey(&validConfig, password)
+		if err != nil {
+			return err
+		}
+		if err := config.SaveConfigAtomic(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Println(color.GreenString("✅ Encryption key rotated"))
+		printAffectedProfiles("Re-encrypted", affected)
+		fmt.Println(color.YellowString("💡 Earlier copies of creds.json in ~/.gat/backups (creds.*.json) still use the old key; delete them"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(securityCmd)
+	securityCmd.AddCommand(securityRotateKeyCmd)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"gat/pkg/api/graphql"
 	"gat/pkg/api/rest"
@@ -10,15 +11,26 @@ import (
 	"gat/pkg/platform"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
 var (
-	apiPort int
-	apiHost string
+	apiPort       int
+	apiHost       string
+	accessLogFile string
+	serveDaemon   bool
+	serveStop     bool
+	serveStatus   bool
+	serveTLSCert  string
+	serveTLSKey   string
+	serveTLSAuto  bool
+	serveAPIKey   string
+	serveGenKey   bool
 )
 
 // serveCmd represents the serve command
@@ -28,7 +40,26 @@ var serveCmd = &cobra.Command{
 	Long: `🌐 Start a local API server that exposes GAT functionality via REST and GraphQL.
 This allows other tools and UIs to interact with GAT programmatically.
 
-By default, the server binds to localhost:9999 for security reasons.`,
+By default, the server binds to localhost:9999 for security reasons.
+Each request is logged (method, path, status, latency) to stderr, or to
+the file given with --access-log-file.
+
+Use --daemon to run the server in the background. Its PID is written to
+~/.gat/gat.pid and its output to ~/.gat/server.log; 'gat serve --status'
+reports whether it is running and 'gat serve --stop' stops it.
+
+Use --tls-cert and --tls-key to serve HTTPS (and WSS for subscriptions) with
+your own certificate, or --tls-auto to generate a temporary self-signed one
+for local use; clients then have to trust it explicitly (e.g. 'curl -k').
+
+To expose the server beyond localhost, require an API key: every request but
+/ping then needs an "Authorization: Bearer <key>" header and gets 401
+otherwise. 'gat serve --generate-api-key' saves a random key to
+~/.gat/api_key, which later servers use until it is deleted; --api-key or the
+GAT_API_TOKEN environment variable gives one for this run instead.
+
+GET and PUT /config/settings read and change the global settings. They are
+only enabled when the server has an API key.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get config directory
 		configPath, err := config.ConfigPath()
@@ -37,11 +68,66 @@ By default, the server binds to localhost:9999 for security reasons.`,
 			os.Exit(1)
 		}
 
+		if serveGenKey {
+			if err := generateAPIKey(configPath); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Background server management
+		var daemonErr error
+		switch {
+		case serveStop:
+			daemonErr = stopDaemon(configPath)
+		case serveStatus:
+			daemonErr = printDaemonStatus(configPath)
+		case serveDaemon:
+			daemonErr = startDaemon(configPath)
+		}
+		if serveStop || serveStatus || serveDaemon {
+			if daemonErr != nil {
+				fmt.Println(daemonErr)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// A daemon keeps running when its terminal closes and cleans up its PID file
+		isDaemon := os.Getenv(server.DaemonEnvVar) != ""
+		if isDaemon {
+			signal.Ignore(syscall.SIGHUP)
+		}
+
+		// One API key guards the whole server and /config/settings: from the
+		// flag, else GAT_API_TOKEN, else the saved one
+		apiKey := serveAPIKey
+		if apiKey == "" {
+			apiKey = os.Getenv(rest.APITokenEnvVar)
+		}
+		if apiKey == "" {
+			if apiKey, err = server.LoadAPIKey(configPath); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Create server configuration
 		serverConfig := server.Config{
 			Port:      apiPort,
 			Host:      apiHost,
 			ConfigDir: configPath,
+			TLSCert:   serveTLSCert,
+			TLSKey:    serveTLSKey,
+			APIKey:    apiKey,
+		}
+		if serveTLSAuto {
+			serverConfig.TLSCertPEM, serverConfig.TLSKeyPEM, err = server.GenerateSelfSignedCert(apiHost)
+			if err != nil {
+				fmt.Printf("❌ Failed to generate a TLS certificate: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		// Initialize the server
@@ -52,14 +138,27 @@ By default, the server binds to localhost:9999 for security reasons.`,
 		platformReg := platform.NewRegistry()
 		gitManager := git.NewManager(configManager, platformReg)
 
+		// Send access logs to a file if requested
+		if accessLogFile != "" {
+			logFile, err := os.OpenFile(accessLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				fmt.Printf("❌ Failed to open access log file: %v\n", err)
+				os.Exit(1)
+			}
+			defer logFile.Close()
+			rest.SetAccessLogOutput(logFile)
+		}
+
 		// Set up REST handlers
-		restHandler := rest.NewHandler(configManager, platformReg)
+		restHandler := rest.NewHandler(configManager, platformReg, gitManager, apiKey)
 		restHandler.RegisterRoutes(apiServer.GetServeMux())
 
 		// Set up GraphQL handlers
 		resolver := graphql.NewResolver(configManager, platformReg, gitManager)
-		apiServer.RegisterHandler("/graphql", graphql.Handler(resolver))
-		apiServer.RegisterHandler("/playground", graphql.PlaygroundHandler())
+		graphqlHandler := graphql.Handler(resolver)
+		apiServer.RegisterHandler("/graphql", rest.LoggingMiddleware(graphqlHandler))
+		apiServer.OnStop(graphqlHandler.CloseSubscriptions)
+		apiServer.RegisterHandler("/playground", rest.LoggingMiddleware(graphql.PlaygroundHandler()))
 
 		// Start the server
 		if err := apiServer.Start(); err != nil {
@@ -67,18 +166,53 @@ By default, the server binds to localhost:9999 for security reasons.`,
 			os.Exit(1)
 		}
 
+		httpScheme, wsScheme := "http", "ws"
+		if apiServer.TLSEnabled() {
+			httpScheme, wsScheme = "https", "wss"
+		}
 		fmt.Println(color.GreenString("✅ GAT API server started on %s:%d", apiHost, apiPort))
-		fmt.Println(color.CyanString("🔎 REST API available at http://%s:%d/profiles, /platforms, /doctor", apiHost, apiPort))
-		fmt.Println(color.CyanString("🔮 GraphQL API available at http://%s:%d/graphql", apiHost, apiPort))
-		fmt.Println(color.CyanString("🛝 GraphQL Playground at http://%s:%d/playground", apiHost, apiPort))
+		fmt.Println(color.CyanString("🔎 REST API available at %s://%s:%d/profiles, /platforms, /doctor, /switch (localhost only), /config/settings (API key)", httpScheme, apiHost, apiPort))
+		fmt.Println(color.CyanString("🔮 GraphQL API available at %s://%s:%d/graphql", httpScheme, apiHost, apiPort))
+		fmt.Println(color.CyanString("📡 GraphQL subscriptions at %s://%s:%d/graphql (graphql-transport-ws)", wsScheme, apiHost, apiPort))
+		fmt.Println(color.CyanString("🛝 GraphQL Playground at %s://%s:%d/playground", httpScheme, apiHost, apiPort))
+		if apiKey != "" {
+			fmt.Println(color.CyanString("🔑 Requests need \"Authorization: Bearer <API key>\" (except /ping)"))
+		}
+		if serveTLSAuto {
+			fmt.Println(color.YellowString("⚠️ Using a temporary self-signed certificate; clients must trust it explicitly (e.g. curl -k)"))
+		}
 		fmt.Println(color.YellowString("Press Ctrl+C to stop"))
 
+		// Pick up edits to the config file without a restart
+		watchCtx, stopWatching := context.WithCancel(context.Background())
+		defer stopWatching()
+		if configFile, err := config.ConfigFilePath(); err == nil {
+			go func() {
+				err := config.WatchConfig(watchCtx, configFile, func(cfg config.Config, validationErrors map[string]error) {
+					configManager.SetConfig(cfg)
+					fmt.Printf("🔄 Config reloaded: %d profile(s), active '%s'\n", len(cfg.Profiles), cfg.Current)
+					for name, err := range validationErrors {
+						fmt.Println(color.YellowString("⚠️ Invalid profile '%s': %v", name, err))
+					}
+				})
+				if err != nil {
+					fmt.Println(color.YellowString("⚠️ Config changes need a restart to take effect: %v", err))
+				}
+			}()
+		}
+
 		// Set up signal handling for graceful shutdown
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		<-c
 
 		fmt.Println(color.YellowString("\nShutting down server..."))
+		stopWatching()
+		if isDaemon {
+			if info, err := server.ReadPIDFile(configPath); err == nil && info.PID == os.Getpid() {
+				server.RemovePIDFile(configPath)
+			}
+		}
 		if err := apiServer.Stop(); err != nil {
 			fmt.Printf("❌ Error stopping server: %v\n", err)
 			os.Exit(1)
@@ -87,10 +221,152 @@ By default, the server binds to localhost:9999 for security reasons.`,
 	},
 }
 
+// generateAPIKey saves a new random API key to the config directory and
+// prints it once
+func generateAPIKey(configDir string) error {
+	key, err := server.GenerateAPIKey()
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	replaced, err := server.LoadAPIKey(configDir)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	if err := server.SaveAPIKey(configDir, key); err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	if replaced != "" {
+		fmt.Println(color.YellowString("⚠️ Replaced the previous API key; clients using it will get 401"))
+	}
+	fmt.Println(color.GreenString("✅ Saved a new API key to %s", server.APIKeyFilePath(configDir)))
+	fmt.Printf("🔑 %s\n", key)
+	fmt.Println(color.CyanString("💡 Clients send it as \"Authorization: Bearer <key>\""))
+	if info, running, err := server.DaemonStatus(configDir); err == nil && running {
+		fmt.Printf("💡 Restart the background server (PID %d) to use it\n", info.PID)
+	}
+	return nil
+}
+
+// startDaemon starts this command again without --daemon as a background
+// process with its output sent to the log file, and records its PID
+func startDaemon(configDir string) error {
+	if info, running, err := server.DaemonStatus(configDir); err == nil && running {
+		return fmt.Errorf("❌ the server is already running (PID %d, %s); stop it with 'gat serve --stop'", info.PID, info.Addr)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("❌ could not find the gat executable: %w", err)
+	}
+	args := []string{exe}
+	for _, arg := range os.Args[1:] {
+		if arg != "--daemon" && !strings.HasPrefix(arg, "--daemon=") {
+			args = append(args, arg)
+		}
+	}
+
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("❌ could not create config directory: %w", err)
+	}
+	logPath := server.LogFilePath(configDir)
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("❌ could not open server log: %w", err)
+	}
+	defer logFile.Close()
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		return fmt.Errorf("❌ could not open %s: %w", os.DevNull, err)
+	}
+	defer devNull.Close()
+
+	proc, err := os.StartProcess(exe, args, &os.ProcAttr{
+		Env:   append(os.Environ(), server.DaemonEnvVar+"=1"),
+		Files: []*os.File{devNull, logFile, logFile},
+	})
+	if err != nil {
+		return fmt.Errorf("❌ could not start the server: %w", err)
+	}
+
+	info := server.DaemonInfo{
+		PID:  proc.Pid,
+		Addr: fmt.Sprintf("%s:%d", apiHost, apiPort),
+		TLS:  serveTLSAuto || serveTLSCert != "",
+	}
+	if err := server.WritePIDFile(configDir, info); err != nil {
+		proc.Kill()
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	// Catch a server that fails right away (e.g. an invalid flag)
+	exited := make(chan struct{})
+	go func() {
+		proc.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		server.RemovePIDFile(configDir)
+		return fmt.Errorf("❌ the server exited during startup; see %s", logPath)
+	case <-time.After(time.Second):
+	}
+
+	fmt.Println(color.GreenString("✅ GAT API server started in the background on %s (PID %d)", info.URL(), info.PID))
+	fmt.Printf("📝 Logs: %s\n", logPath)
+	fmt.Println(color.CyanString("💡 Stop it with 'gat serve --stop'"))
+	return nil
+}
+
+// stopDaemon stops the background server
+func stopDaemon(configDir string) error {
+	info, wasRunning, err := server.StopDaemon(configDir)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	if !wasRunning {
+		fmt.Printf("🧹 Server was not running; removed stale PID file for PID %d\n", info.PID)
+		return nil
+	}
+	fmt.Println(color.GreenString("✅ Stopped the server (PID %d)", info.PID))
+	return nil
+}
+
+// printDaemonStatus reports whether the background server is running
+func printDaemonStatus(configDir string) error {
+	info, running, err := server.DaemonStatus(configDir)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	switch {
+	case running:
+		fmt.Println(color.GreenString("✅ Server running (PID %d) on %s", info.PID, info.URL()))
+	case info.PID != 0:
+		fmt.Println(color.YellowString("⚠️ Server not running (stale PID file for PID %d)", info.PID))
+		fmt.Println("💡 Run 'gat serve --stop' to clean it up or 'gat serve --daemon' to restart the server")
+	default:
+		fmt.Println("⚪ Server not running")
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
 
 	// Add flags
 	serveCmd.Flags().IntVar(&apiPort, "port", 9999, "Port to run the server on")
 	serveCmd.Flags().StringVar(&apiHost, "host", "localhost", "Host to bind the server to")
+	serveCmd.Flags().StringVar(&accessLogFile, "access-log-file", "", "Write request access logs to this file instead of stderr")
+	serveCmd.Flags().BoolVar(&serveDaemon, "daemon", false, "Run the server in the background")
+	serveCmd.Flags().BoolVar(&serveStop, "stop", false, "Stop the background server")
+	serveCmd.Flags().BoolVar(&serveStatus, "status", false, "Show whether the background server is running")
+	serveCmd.Flags().StringVar(&serveTLSCert, "tls-cert", "", "Serve HTTPS with this certificate file (PEM); needs --tls-key")
+	serveCmd.Flags().StringVar(&serveTLSKey, "tls-key", "", "Private key file (PEM) of the --tls-cert certificate")
+	serveCmd.Flags().BoolVar(&serveTLSAuto, "tls-auto", false, "Serve HTTPS with a temporary self-signed certificate")
+	serveCmd.Flags().StringVar(&serveAPIKey, "api-key", "", "Require this API key as a Bearer token (default: GAT_API_TOKEN, else the key in ~/.gat/api_key, if any)")
+	serveCmd.Flags().BoolVar(&serveGenKey, "generate-api-key", false, "Generate a random API key, save it to ~/.gat/api_key and print it")
+	serveCmd.MarkFlagsMutuallyExclusive("daemon", "stop", "status", "generate-api-key")
+	serveCmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
+	serveCmd.MarkFlagsMutuallyExclusive("tls-auto", "tls-cert")
+	serveCmd.MarkFlagsMutuallyExclusive("tls-auto", "tls-key")
 }
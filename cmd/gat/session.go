@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/ssh"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionClearCredentials bool
+	sessionQuiet            bool
+)
+
+// sessionCmd represents the session command
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "⏰ Manage profile session timeouts",
+	Long: `⏰ Profiles with a session timeout ('gat switch <name> --session-timeout 8h')
+have their credentials cleared once the session started by 'gat switch' expires.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// sessionCheckCmd represents the check subcommand of session
+var sessionCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Clear credentials if the active profile's session has expired",
+	Long: `Checks the session of the active profile. Once it is older than the
+profile's session timeout, the SSH agent's identities are removed, the active
+profile is unset and, with --clear-credentials, ~/.git-credentials is emptied.
+
+Meant to be run from a shell hook or cron, e.g.:
+  */15 * * * * gat session check --quiet --clear-credentials`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		profile, profileName, err := config.GetCurrentProfile(&validConfig)
+		if err != nil || profile.MaxSessionDuration <= 0 {
+			if !sessionQuiet {
+				fmt.Println("ℹ️ No active profile with a session timeout.")
+			}
+			return nil
+		}
+
+		start, err := config.SessionStart(profileName)
+		if os.IsNotExist(err) {
+			// No recorded session: start one now rather than expiring immediately
+			if err := config.StartSession(profileName); err != nil {
+				return err
+			}
+			start = time.Now()
+		} else if err != nil {
+			return err
+		}
+
+		remaining := profile.SessionRemaining(start)
+		if remaining > 0 {
+			if !sessionQuiet {
+				fmt.Printf("✅ Session for profile '%s' expires in %s\n", profileName, remaining.Round(time.Minute))
+			}
+			return nil
+		}
+
+		// The session has expired: clear credentials
+		if err := ssh.ClearIdentities(); err != nil {
+			fmt.Printf(color.YellowString("⚠️ Could not clear identities from ssh-agent: %v\n"), err)
+		}
+		if sessionClearCredentials {
+			if err := git.ClearGitCredentials(); err != nil {
+				fmt.Printf(color.YellowString("⚠️ %v\n"), err)
+			}
+		}
+		validConfig.Current = ""
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+		if err := config.EndSession(profileName); err != nil {
+			return err
+		}
+
+		fmt.Printf("⏰ Session for profile '%s' expired after %s; credentials cleared\n", profileName, profile.MaxSessionDuration)
+		fmt.Println("👉 Use 'gat switch <name>' to start a new session.")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sessionCmd)
+	sessionCmd.AddCommand(sessionCheckCmd)
+
+	sessionCheckCmd.Flags().BoolVar(&sessionClearCredentials, "clear-credentials", false, "Also empty ~/.git-credentials when the session has expired")
+	sessionCheckCmd.Flags().BoolVarP(&sessionQuiet, "quiet", "q", false, "Only print output when the session has expired")
+}
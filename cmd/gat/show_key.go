@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	showKeyCopy bool
+)
+
+var showKeyCmd = &cobra.Command{
+	Use:   "show-key <profile>",
+	Short: "🔑 Print a profile's SSH public key",
+	Long: `🔑 Prints the SSH public key for a profile so it can be pasted into your
+Git platform's SSH key settings page.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName := args[0]
+
+		// Validate profile name for security
+		if err := config.ValidateProfileName(profileName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
+		if validationErr, isInvalid := validationErrors[profileName]; isInvalid {
+			return fmt.Errorf("❌ profile '%s' failed validation: %v", profileName, validationErr)
+		}
+
+		profile, exists := validConfig.Profiles[profileName]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+		}
+		if len(profile.SSHIdentities) == 0 {
+			return fmt.Errorf("❌ profile '%s' has no SSH identity configured", profileName)
+		}
+
+		publicKey, err := ssh.GetPublicKeyContent(profile.SSHIdentity())
+		if err != nil {
+			return err
+		}
+
+		// Print the bare key so it can be piped elsewhere
+		fmt.Println(publicKey)
+
+		if showKeyCopy {
+			if err := utils.CopyToClipboard(publicKey); err != nil {
+				return err
+			}
+			fmt.Println(color.GreenString("📋 Public key copied to clipboard"))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(showKeyCmd)
+
+	showKeyCmd.Flags().BoolVar(&showKeyCopy, "copy", false, "Also copy the public key to the clipboard")
+}
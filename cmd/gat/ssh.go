@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sshStrategy string
+)
+
+// sshCmd represents the ssh command
+var sshCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "🔐 Manage SSH keys for profiles",
+	Long: `🔐 Commands that create and manage the SSH keys used by Git profiles.
+
+--strategy chooses how ~/.ssh/gat_config selects each profile's key:
+  host-alias  one Host block per profile (github-<profile>), the default
+  match-exec  one Match exec block that asks 'gat' for the key of the
+              profile on each connection, keeping the file small with many
+              profiles. Hosts without an alias use the active profile's key.
+
+Example:
+  gat ssh --strategy match-exec`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("strategy") {
+			if strategy, err := ssh.CurrentStrategy(); err == nil {
+				fmt.Printf("🔐 SSH strategy: %s\n\n", color.GreenString(strategy))
+			}
+			return cmd.Help()
+		}
+
+		switch sshStrategy {
+		case ssh.StrategyMatchExec:
+			return useMatchExecStrategy()
+		case ssh.StrategyHostAlias:
+			return useHostAliasStrategy()
+		}
+		return fmt.Errorf("❌ unknown strategy '%s'. Use '%s' or '%s'", sshStrategy, ssh.StrategyHostAlias, ssh.StrategyMatchExec)
+	},
+}
+
+// useMatchExecStrategy rewrites ~/.ssh/gat_config with ssh.MultiHostConfig
+func useMatchExecStrategy() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("❌ could not find the gat executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	multiHost := ssh.MultiHostConfig{
+		GatBinary:   executable,
+		IdentityDir: filepath.Join(configPath, "ssh"),
+		Platforms:   platform.NewRegistry().ListPlatforms(),
+	}
+	if err := multiHost.Write(); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ ~/.ssh/gat_config now uses the %s strategy (previous file kept as gat_config.bak)\n", color.GreenString(ssh.StrategyMatchExec))
+	fmt.Printf("ℹ️ ssh runs '%s check-host' for each connection; moving the binary needs 'gat ssh --strategy %s' again\n", executable, ssh.StrategyMatchExec)
+	return nil
+}
+
+// useHostAliasStrategy rewrites ~/.ssh/gat_config with a Host block for
+// every SSH profile
+func useHostAliasStrategy() error {
+	validConfig, _, ioErr := config.LoadConfig()
+	if ioErr != nil {
+		return ioErr
+	}
+	if err := ssh.ResetHostAliasConfig(); err != nil {
+		return err
+	}
+
+	for _, name := range config.ListProfileNames(&validConfig) {
+		profile := validConfig.Profiles[name]
+		if profile.AuthMethod != "ssh" || len(profile.SSHIdentities) == 0 {
+			continue
+		}
+		if err := ssh.UpdateSSHConfig(profile.GetPlatform(), name, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("✅ ~/.ssh/gat_config now uses the %s strategy (previous file kept as gat_config.bak)\n", color.GreenString(ssh.StrategyHostAlias))
+	return nil
+}
+
+// sshTestCmd represents the test subcommand of ssh
+var sshTestCmd = &cobra.Command{
+	Use:   "test [profile]",
+	Short: "Check that a profile can authenticate over SSH",
+	Long: `Connects to the host alias of a profile (the active one by default) with
+'ssh -T', which is how Git reaches the platform, and shows what the platform
+answers. When it greets a user (GitHub, GitLab, Bitbucket, Gitea), gat warns if
+that user is not the profile's username.
+
+gat exits with 0 once the platform greets a user, and with the exit code of
+ssh otherwise. ssh gets 10 seconds and does not prompt, so keys with a
+passphrase must be in the ssh-agent (see 'gat switch').
+
+Examples:
+  gat ssh test
+  gat ssh test work`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		var profile config.Profile
+		var profileName string
+		if len(args) > 0 {
+			profileName = config.ResolveAlias(&validConfig, args[0])
+			var exists bool
+			if profile, exists = validConfig.Profiles[profileName]; !exists {
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+			}
+		} else {
+			current, name, err := config.GetCurrentProfile(&validConfig)
+			if err != nil {
+				return err
+			}
+			profile, profileName = *current, name
+		}
+
+		if len(profile.SSHIdentities) == 0 {
+			return fmt.Errorf("❌ profile '%s' has no SSH identity; add one with 'gat add %s --ssh-identity <path> --overwrite'", profileName, profileName)
+		}
+		missing, err := ssh.CheckSSHIdentities(profile.SSHIdentities, profile.SSHCertPath)
+		if err != nil {
+			return err
+		}
+		if missing != "" {
+			return utils.Errorf(ssh.ErrIdentityNotFound, "❌ SSH identity file not found: %s", missing)
+		}
+
+		platformID := profile.GetPlatform()
+		hostAlias := platform.GetProfileSSHHost(platformID, profileName)
+		if exists, err := ssh.CheckSSHHostExists(hostAlias); err == nil && !exists {
+			fmt.Println(color.YellowString("⚠️ ~/.ssh/gat_config has no host alias %s; 'gat doctor --fix' rewrites missing aliases", hostAlias))
+		}
+		sshUser := "git"
+		if plat, err := platform.NewRegistry().GetPlatform(platformID); err == nil && plat.SSHUser != "" {
+			sshUser = plat.SSHUser
+		}
+
+		fmt.Printf("🔌 Testing SSH for %s (ssh -T %s@%s)...\n", color.GreenString(profileName), sshUser, hostAlias)
+		output, code, err := ssh.TestConnection(sshUser, hostAlias)
+		if output = strings.TrimSpace(output); output != "" {
+			for _, line := range strings.Split(output, "\n") {
+				fmt.Printf("   %s\n", line)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if username, ok := ssh.GreetingUsername(output); ok {
+			if !strings.EqualFold(username, profile.Username) {
+				fmt.Println(color.YellowString("⚠️ Authenticated as %s, but profile '%s' has username %s", username, profileName, profile.Username))
+				return nil
+			}
+			fmt.Printf("✅ Authenticated as %s\n", color.CyanString(username))
+			return nil
+		}
+
+		if code != 0 {
+			fmt.Println(color.RedString("❌ SSH failed (exit code %d)", code))
+			os.Exit(code)
+		}
+		fmt.Println(color.GreenString("✅ Connected (the platform did not name the user)"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sshCmd)
+	sshCmd.AddCommand(sshTestCmd)
+	sshCmd.Flags().StringVar(&sshStrategy, "strategy", "", "How ~/.ssh/gat_config selects keys ('host-alias' or 'match-exec')")
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sshGenerateType         string
+	sshGenerateBits         int
+	sshGeneratePassphrase   string
+	sshGenerateNoPassphrase bool
+	sshGenerateOutputPath   string
+	sshGenerateForce        bool
+)
+
+// sshGenerateCmd represents the ssh generate command
+var sshGenerateCmd = &cobra.Command{
+	Use:   "generate <profile>",
+	Short: "🔑 Generate an SSH key for a profile",
+	Long: `🔑 Generates a new SSH key for a profile with ssh-keygen and sets it as the
+profile's SSH identity.
+
+The key is written to ~/.ssh/gat_<profile> (or --output-path) with the comment
+"<email> (gat:<platform>)". --algorithm picks the key type (ed25519 by default;
+use --bits to size rsa keys). ssh-keygen prompts for a passphrase unless
+--passphrase or --no-passphrase is given; '--passphrase -' reads it from the
+first line of stdin. A given passphrase is handed to ssh-keygen through
+SSH_ASKPASS (OpenSSH 8.4 or later), never on its command line. An existing key
+is only replaced once ssh-keygen succeeds. The profile's SSH host alias is added to ~/.ssh/gat_config and the public key is printed so it
+can be added to the platform.
+
+Examples:
+  gat ssh generate work
+  gat ssh generate work --algorithm rsa --bits 4096
+  echo "$PASSPHRASE" | gat ssh generate work --passphrase -
+  gat ssh generate work --output-path ~/.ssh/id_work`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName := args[0]
+
+		// Validate profile name for security
+		if err := config.ValidateProfileName(profileName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
+		if validationErr, isInvalid := validationErrors[profileName]; isInvalid {
+			return fmt.Errorf("❌ profile '%s' failed validation: %v", profileName, validationErr)
+		}
+
+		profile, exists := validConfig.Profiles[profileName]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+		}
+
+		if sshGenerateNoPassphrase && sshGeneratePassphrase != "" {
+			return fmt.Errorf("❌ --passphrase and --no-passphrase cannot be used together")
+		}
+		if sshGenerateBits != 0 && sshGenerateType == "ed25519" {
+			return fmt.Errorf("❌ --bits does not apply to ed25519 keys; use it with --algorithm rsa or ecdsa")
+		}
+		passphrase := sshGeneratePassphrase
+		if passphrase == "-" {
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && line == "" {
+				return fmt.Errorf("❌ could not read the passphrase from stdin: %w", err)
+			}
+			passphrase = strings.TrimRight(line, "\r\n")
+			if passphrase == "" {
+				return fmt.Errorf("❌ the passphrase read from stdin is empty; use --no-passphrase for a key without one")
+			}
+		}
+
+		keyPath := ssh.ProfileKeyPath(profileName)
+		if sshGenerateOutputPath != "" {
+			keyPath = sshGenerateOutputPath
+		}
+		opts := ssh.KeyOptions{
+			Path:         keyPath,
+			Type:         sshGenerateType,
+			Bits:         sshGenerateBits,
+			Passphrase:   passphrase,
+			NoPassphrase: sshGenerateNoPassphrase,
+		}
+		if err := generateProfileKey(opts, profile, sshGenerateForce); err != nil {
+			return err
+		}
+
+		// A certificate signed for the previous key does not cover the new one
+		if profile.SSHIdentity() != keyPath && profile.SSHCertPath != "" {
+			fmt.Println(color.YellowString("⚠️ Removed SSH certificate %s from the profile; have the new key signed and add it with --ssh-cert"), profile.SSHCertPath)
+			profile.SSHCertPath = ""
+		}
+		profile.SetPrimarySSHIdentity(keyPath)
+		validConfig.Profiles[profileName] = profile
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+		fmt.Printf("✅ SSH identity of profile %s set to %s\n", color.GreenString(profileName), color.CyanString(keyPath))
+
+		if err := ssh.UpdateSSHConfig(profile.GetPlatform(), profileName, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+			fmt.Printf(color.YellowString("⚠️ Warning: Failed to update SSH config: %v\n"), err)
+		}
+
+		if profile.AuthMethod != "ssh" {
+			fmt.Printf("💡 Profile '%s' uses %s; switch it to SSH with 'gat add %s --auth-method ssh --overwrite'\n", profileName, profile.AuthMethod, profileName)
+		}
+
+		return printGeneratedKey(keyPath)
+	},
+}
+
+// generateProfileKey creates a key pair at opts.Path for the profile, with
+// the profile's key comment, asking before replacing an existing key unless
+// force is set. Shared by 'gat ssh generate' and 'gat add --generate-key'.
+func generateProfileKey(opts ssh.KeyOptions, profile config.Profile, force bool) error {
+	if ssh.KeyExists(opts.Path) && !force {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("An SSH key already exists at %s. Overwrite it", opts.Path),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			return fmt.Errorf("❌ key generation canceled; existing key kept")
+		}
+	}
+
+	if opts.Type == "" {
+		opts.Type = ssh.DefaultKeyType
+	}
+	opts.Comment = ssh.KeyComment(profile.Email, profile.GetPlatform())
+	fmt.Printf("🔑 Generating %s key at %s...\n", opts.Type, opts.Path)
+	return ssh.GenerateKey(opts)
+}
+
+// printGeneratedKey prints the public key of a newly generated identity
+func printGeneratedKey(keyPath string) error {
+	publicKey, err := ssh.GetPublicKeyContent(keyPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println(color.CyanString("\n📋 Public key (add it to your platform's SSH key settings):"))
+	fmt.Println(publicKey)
+	return nil
+}
+
+func init() {
+	sshCmd.AddCommand(sshGenerateCmd)
+
+	sshGenerateCmd.Flags().StringVar(&sshGenerateType, "algorithm", ssh.DefaultKeyType, "Key type passed to ssh-keygen -t (e.g. ed25519, rsa, ecdsa)")
+	sshGenerateCmd.Flags().StringVar(&sshGenerateType, "type", ssh.DefaultKeyType, "Same as --algorithm")
+	sshGenerateCmd.Flags().MarkHidden("type")
+	sshGenerateCmd.Flags().IntVar(&sshGenerateBits, "bits", 0, "Key size in bits for rsa and ecdsa keys (e.g. 4096)")
+	sshGenerateCmd.Flags().StringVar(&sshGeneratePassphrase, "passphrase", "", "Passphrase for the key, or '-' to read it from stdin")
+	sshGenerateCmd.Flags().BoolVar(&sshGenerateNoPassphrase, "no-passphrase", false, "Create the key without a passphrase instead of prompting for one")
+	sshGenerateCmd.Flags().StringVar(&sshGenerateOutputPath, "output-path", "", "Where to write the private key instead of ~/.ssh/gat_<profile>; '~' is supported")
+	sshGenerateCmd.Flags().BoolVarP(&sshGenerateForce, "force", "f", false, "Overwrite an existing key without asking")
+}
@@ -4,16 +4,27 @@ import (
 	"fmt"
 	"gat/pkg/config"
 	"gat/pkg/git"
+	"gat/pkg/platform"
+	"gat/pkg/utils"
+	"math"
 	"os"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
+var (
+	statusSize bool
+)
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "🔍 Show current GitHub profile status",
-	Long:  `🔍 Displays information about the current active GitHub profile and repository settings.`,
+	Long: `🔍 Displays information about the current active GitHub profile and repository settings.
+
+To inspect a different gat configuration, point at its credentials file:
+  gat status --config-file ~/work/.gat/creds.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Ensure config directory exists
 		configPath, err := config.ConfigPath()
@@ -40,11 +51,20 @@ var statusCmd = &cobra.Command{
 			fmt.Println() // Add a newline for separation
 		}
 
-		// Get current profile based on the valid configuration
-		// Pass address of validConfig as GetCurrentProfile expects a pointer
-		profile, profileName, err := config.GetCurrentProfile(&validConfig)
+		// Resolve the profile for this repository (GAT_PROFILE, .gatprofile
+		// or the active profile)
+		profileName, source, err := resolveRepoProfile()
 		if err != nil {
-			// This handles both "Current" being empty and "Current" pointing to an invalid profile
+			return err
+		}
+		profileName = config.ResolveAlias(&validConfig, profileName)
+		profile, exists := validConfig.Profiles[profileName]
+		if !exists {
+			// This handles both "Current" being empty and a name pointing to an invalid profile
+			if source != git.ProfileSourceGlobal {
+				fmt.Printf("⚠️ Profile '%s' (%s) does not exist or is invalid.\n", profileName, git.DescribeProfileSource(source))
+				return nil
+			}
 			fmt.Println("⚠️ No active profile set or the active profile is invalid.")
 			fmt.Println("👉 Use 'gat switch <name>' to activate a valid profile.")
 			return nil
@@ -53,17 +73,51 @@ var statusCmd = &cobra.Command{
 		// Print profile information
 		fmt.Println("�� Current Profile:")
 		fmt.Printf("   Name: %s\n", color.GreenString(profileName))
+		fmt.Printf("   📌 Using profile '%s' (%s)\n", profileName, git.DescribeProfileSource(source))
+		if profileName != validConfig.Current {
+			fmt.Printf(color.YellowString("   ⚠️ The active profile is '%s'. Run 'gat switch' to apply '%s'.\n"), validConfig.Current, profileName)
+		}
 		fmt.Printf("   👤 Username: %s\n", profile.Username)
 		fmt.Printf("   📧 Email: %s\n", profile.Email)
 
-		if profile.SSHIdentity != "" {
-			fmt.Printf("   🔑 SSH Identity: %s\n", profile.SSHIdentity)
+		if len(profile.SSHIdentities) > 0 {
+			fmt.Printf("   🔑 SSH Identity: %s\n", formatSSHIdentities(profile.SSHIdentities))
+		}
+		if profile.TokenExpiry != nil {
+			fmt.Printf("   ⌛ Token Expiry: %s\n", formatTokenExpiry(profile))
+		}
+
+		// Session timeout applies to the active profile only
+		if profile.MaxSessionDuration > 0 && profileName == validConfig.Current {
+			if start, err := config.SessionStart(profileName); err != nil {
+				fmt.Printf("   ⏰ Session: %s timeout, no session recorded\n", profile.MaxSessionDuration)
+			} else if remaining := profile.SessionRemaining(start); remaining > 0 {
+				fmt.Printf("   ⏰ Session: %s remaining\n", color.CyanString(remaining.Round(time.Minute).String()))
+			} else {
+				fmt.Printf(color.YellowString("   ⚠️ Session expired %s ago. Run 'gat session check' to clear credentials.\n"), (-remaining).Round(time.Minute))
+			}
+		}
+
+		if statusSize {
+			size, err := config.ProfileSize(&validConfig, profileName)
+			if err != nil {
+				fmt.Printf(color.YellowString("   ⚠️ Could not compute profile size: %v\n"), err)
+			} else {
+				fmt.Printf("   💾 Size: %s (profile and backups)\n", utils.FormatBytes(size))
+			}
+		}
+
+		// Show how to clone with this profile
+		reg := platform.NewRegistry()
+		if plat, err := reg.GetPlatform(profile.GetPlatform()); err == nil {
+			fmt.Printf("   📦 Clone Prefix: %s\n", cloneURLPrefix(plat, profileName, profile))
 		}
 
 		// Check Git repository information
-		if git.IsInGitRepo() {
+		if repoRoot, err := git.GetRepoRoot(); err == nil {
 			fmt.Println()
 			fmt.Println("📁 Git Repository:")
+			fmt.Printf("   📂 Root: %s\n", repoRoot)
 
 			// Get and display remote URL
 			remoteURL, err := git.GetCurrentRemoteURL()
@@ -78,6 +132,15 @@ var statusCmd = &cobra.Command{
 				} else {
 					fmt.Printf("   🌐 Protocol: %s\n", color.CyanString("HTTPS"))
 				}
+
+				if plat, err := reg.GetPlatformForURL(remoteURL); err == nil {
+					fmt.Printf("   🏢 Platform: %s\n", plat.Name)
+				}
+				// Warn when the remote belongs to another profile than the active one
+				if owner := git.GuessProfileFromRemote(remoteURL, validConfig.Profiles); owner != "" && owner != validConfig.Current {
+					fmt.Println(color.YellowString("   ⚠️ Remote belongs to profile '%s' but active profile is '%s'", owner, validConfig.Current))
+					fmt.Printf("   💡 Run 'gat switch %s' to use it here\n", owner)
+				}
 			}
 		} else {
 			fmt.Println()
@@ -88,6 +151,22 @@ var statusCmd = &cobra.Command{
 	},
 }
 
+// formatTokenExpiry returns the date a profile's token expires, marked when
+// it has expired or how many days are left when it expires soon
+func formatTokenExpiry(profile config.Profile) string {
+	expires := profile.TokenExpiry.Format(config.TokenExpiryLayout)
+	switch {
+	case profile.TokenExpired():
+		return color.RedString("%s (expired)", expires)
+	case profile.TokenExpirySoon():
+		days := int(math.Ceil(time.Until(*profile.TokenExpiry).Hours() / 24))
+		return color.YellowString("%s (in %d days)", expires, days)
+	}
+	return expires
+}
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().BoolVar(&statusSize, "size", false, "Show the disk space used by the current profile and its backups")
 }
@@ -2,22 +2,34 @@ package main
 
 import (
 	"fmt"
+	"gat/pkg/audit"
 	"gat/pkg/config"
 	"gat/pkg/git"
 	"gat/pkg/platform"
 	"gat/pkg/ssh"
+	"gat/pkg/utils"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	dryRun bool
+	dryRun           bool
+	switchEval       bool
+	sessionTimeout   string
+	switchNoGlobal   bool
+	switchTags       []string
+	switchRemote     string
+	switchAllRemotes bool
 )
 
 var switchCmd = &cobra.Command{
-	Use:   "switch <name>",
+	Use:   "switch [name]",
 	Short: "🔄 Switch to a different Git profile",
 	Long: `🔄 Switches to a different Git profile.
 
@@ -25,21 +37,81 @@ This command updates your global Git identity (user.name, user.email).
 If run inside a Git repository, it also:
 - Configures the SSH agent (starts if necessary, clears old keys, adds the profile's key if AuthMethod is 'ssh').
 - Updates the 'origin' remote URL to match the profile's AuthMethod ('ssh' or 'https').
-- Updates stored Git credentials for HTTPS if applicable.`,
-	Args: cobra.ExactArgs(1),
+  Use --remote to update another remote instead, or --all-remotes for every one.
+- Updates stored Git credentials for HTTPS if applicable.
+
+It also writes shell activation scripts (~/.gat/activate.sh, .fish, .csh)
+that export the profile's GNUPGHOME, if set. To apply them to the current
+shell in one step, use --eval, which prints only the shell commands to stdout:
+
+  eval "$(gat switch work --eval)"
+
+Without a name, the profile for the current repository is used: the
+GAT_PROFILE environment variable, or, with the auto-switch setting on
+('gat config set auto-switch true'), a .gatprofile or .gat file in the
+repository root or one of its parent directories.
+
+With --no-global the identity is written to the repository's .git/config
+instead and ~/.git-credentials is left alone, for machines shared with other
+users. The choice is remembered (see 'gat config get prefer-local-scope');
+use --no-global=false to go back to global switching.
+
+With --tag (repeatable), gat asks which of the profiles with all of the
+given tags to switch to.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		profileName := args[0]
+		// With --eval stdout is eval'd by the shell, so send progress output
+		// to stderr and keep the real stdout for the activation commands
+		evalOutput := os.Stdout
+		if switchEval {
+			os.Stdout = os.Stderr
+			defer func() { os.Stdout = evalOutput }()
+			config.WarningOutput = os.Stderr
+		}
+
+		var profileName string
+		if len(switchTags) > 0 {
+			if len(args) > 0 {
+				return fmt.Errorf("❌ give a profile name or --tag, not both")
+			}
+			name, err := pickTaggedProfile(switchTags, "Switch to")
+			if err != nil {
+				return err
+			}
+			profileName = name
+		} else if len(args) > 0 {
+			profileName = args[0]
+		} else {
+			name, source, err := resolveRepoProfile()
+			if err != nil {
+				return err
+			}
+			if source == git.ProfileSourceGlobal {
+				if validConfig, _, ioErr := config.LoadConfig(); ioErr == nil && !validConfig.AutoSwitch {
+					return fmt.Errorf("❌ no profile is configured for this repository. Use 'gat switch <name>', or run 'gat config set auto-switch true' to use %s and .gat files", git.ProfileFileName)
+				}
+				return fmt.Errorf("❌ no profile is configured for this repository. Use 'gat switch <name>' or add a %s file", git.ProfileFileName)
+			}
+			fmt.Printf("📌 Using profile '%s' (%s)\n", name, git.DescribeProfileSource(source))
+			profileName = name
+		}
 
 		// Validate profile name for security
 		if err := config.ValidateProfileName(profileName); err != nil {
 			return fmt.Errorf("❌ %v", err)
 		}
+		if err := git.ValidateRemoteName(switchRemote); err != nil {
+			return err
+		}
 
 		// Load configuration, print warnings for invalid profiles but proceed
 		validConfig, validationErrors, ioErr := config.LoadConfig()
 		if ioErr != nil {
 			return ioErr // Handle file I/O or parsing errors first
 		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
 		if len(validationErrors) > 0 {
 			// Check if the target profile itself failed validation
 			if validationErr, isInvalid := validationErrors[profileName]; isInvalid {
@@ -60,7 +132,7 @@ If run inside a Git repository, it also:
 		if !exists {
 			// If it didn't exist in validationErrors either, it's truly not found
 			if _, wasInvalid := validationErrors[profileName]; !wasInvalid {
-				return fmt.Errorf("❌ profile '%s' does not exist", profileName)
+				return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
 			} // If it *was* invalid, the error was already returned above.
 			// This path shouldn't normally be reached due to the check above, but covers edge cases.
 			return fmt.Errorf("❌ profile '%s' not found (it may have failed validation)", profileName)
@@ -80,23 +152,63 @@ If run inside a Git repository, it also:
 			color.MagentaString(platformName),
 			color.GreenString(profileName))
 
+		// --session-timeout is stored on the profile for later switches too
+		if cmd.Flags().Changed("session-timeout") {
+			timeout, err := parseAge(sessionTimeout)
+			if err != nil {
+				return err
+			}
+			profile.MaxSessionDuration = timeout
+			validConfig.Profiles[profileName] = profile
+		}
+
+		// --no-global is remembered as the prefer-local-scope setting
+		if cmd.Flags().Changed("no-global") {
+			validConfig.PreferLocalScope = switchNoGlobal
+		}
+		localScope := validConfig.PreferLocalScope
+
 		if dryRun {
 			fmt.Println(color.YellowString("🧪 Dry run mode enabled. No changes will be made."))
+			if localScope {
+				fmt.Printf("    Identity scope: local (.git/config only)\n")
+			}
 			fmt.Printf("    Would set Git User: %s\n", profile.Username)
 			fmt.Printf("    Would set Git Email: %s\n", profile.Email)
 			fmt.Printf("    Auth Method: %s\n", profile.AuthMethod)
 			if profile.AuthMethod == "ssh" {
-				fmt.Printf("    Would manage SSH Key: %s\n", profile.SSHIdentity)
+				fmt.Printf("    Would manage SSH Key: %s\n", strings.Join(profile.SSHIdentities, ", "))
+			} else if plat != nil && plat.CredentialHelper != "" {
+				fmt.Printf("    Would use credential helper: %s\n", plat.CredentialHelper)
 			} else {
 				fmt.Printf("    Would use Token for HTTPS\n")
 			}
-			fmt.Printf("    Would ensure remote uses: %s\n", strings.ToUpper(profile.AuthMethod))
+			if switchAllRemotes {
+				fmt.Printf("    Would ensure all remotes use: %s\n", strings.ToUpper(profile.AuthMethod))
+			} else {
+				fmt.Printf("    Would ensure remote '%s' uses: %s\n", switchRemote, strings.ToUpper(profile.AuthMethod))
+			}
+			if len(profile.MirrorRemotes) > 0 && !switchAllRemotes {
+				fmt.Printf("    Would also update mirror remotes: %s\n", strings.Join(profile.MirrorRemotes, ", "))
+			}
+			if profile.GnupghomeOverride != "" {
+				fmt.Printf("    Would export GNUPGHOME: %s\n", profile.GnupghomeOverride)
+			}
+			if profile.GPGKey != "" {
+				fmt.Printf("    Would sign commits with GPG key: %s\n", profile.GPGKey)
+			} else {
+				fmt.Printf("    Would disable commit signing\n")
+			}
+			if profile.MaxSessionDuration > 0 {
+				fmt.Printf("    Would start a session expiring in %s\n", profile.MaxSessionDuration)
+			}
 			return nil
 		}
 
 		// --- Start applying changes ---
 
 		// 1. Set as current profile in gat config
+		previousProfile := validConfig.Current
 		validConfig.Current = profileName
 		// Pass address of validConfig as SaveConfig expects a pointer
 		if err := config.SaveConfig(&validConfig); err != nil {
@@ -104,14 +216,28 @@ If run inside a Git repository, it also:
 			// Non-fatal, continue with other steps
 		}
 
-		// 2. Update Git global identity
-		if err := git.SetIdentity(profile.Username, profile.Email); err != nil {
-			// This is more critical, return error
-			return fmt.Errorf(color.RedString("  ❌ Failed to set Git identity: %v"), err)
+		// 2. Update Git identity (global, or the repository's in local scope)
+		if _, err := git.GetRepoRoot(); localScope && err != nil {
+			fmt.Println(color.YellowString("  ⚠️ Not inside a Git repository; Git identity not set (identity scope: local)"))
+		} else {
+			if err := git.SetIdentity(profile.Username, profile.Email, localScope); err != nil {
+				// This is more critical, return error
+				return fmt.Errorf(color.RedString("  ❌ Failed to set Git identity: %v"), err)
+			}
+			fmt.Printf("  ✅ Git identity set%s: %s <%s>\n",
+				utils.Ternary(localScope, " in .git/config", ""),
+				color.CyanString(profile.Username),
+				color.CyanString(profile.Email))
+
+			// Sign commits with the profile's GPG key, or not at all
+			if err := git.SetGPGKey(profile.GPGKey, localScope); err != nil {
+				fmt.Printf(color.RedString("  ⚠️ Failed to configure commit signing: %v\n"), err)
+			} else if profile.GPGKey != "" {
+				fmt.Printf("  ✅ Commits signed with GPG key %s\n", color.CyanString(profile.GPGKey))
+			} else {
+				fmt.Println("  ℹ️ Commit signing disabled (no GPG key in profile)")
+			}
 		}
-		fmt.Printf("  ✅ Git identity set: %s <%s>\n",
-			color.CyanString(profile.Username),
-			color.CyanString(profile.Email))
 
 		// 3. Handle Auth Method specific logic
 		if profile.AuthMethod == "ssh" {
@@ -130,38 +256,58 @@ If run inside a Git repository, it also:
 				}
 
 				// 3c. Add the profile's identity
-				if profile.SSHIdentity == "" {
+				if len(profile.SSHIdentities) == 0 {
 					fmt.Println(color.YellowString("    ⚠️ Profile '%s' uses SSH but has no SSH identity configured."), profileName)
 				} else {
-					// Check if identity file exists first
-					exists, checkErr := ssh.CheckSSHIdentity(profile.SSHIdentity)
+					// Check if the identity files exist first
+					missing, checkErr := ssh.CheckSSHIdentities(profile.SSHIdentities, profile.SSHCertPath)
 					if checkErr != nil {
-						fmt.Printf(color.RedString("    ⚠️ Error checking SSH identity file '%s': %v\n"), profile.SSHIdentity, checkErr)
-					} else if !exists {
-						fmt.Printf(color.RedString("    ⚠️ SSH identity file not found: %s\n"), profile.SSHIdentity)
+						fmt.Printf(color.RedString("    ⚠️ Error checking SSH identity files: %v\n"), checkErr)
+					} else if missing != "" {
+						fmt.Printf(color.RedString("    ⚠️ SSH identity file not found: %s\n"), missing)
 						fmt.Println(color.YellowString("      💡 Please ensure the key exists or update the profile."))
 					} else {
-						// Add identity to agent
-						if err := ssh.AddIdentity(profile.SSHIdentity); err != nil {
-							fmt.Printf(color.RedString("    ❌ Failed to add SSH identity '%s' to agent: %v\n"), profile.SSHIdentity, err)
+						// Add identities to agent
+						if err := ssh.AddIdentity(profile.SSHIdentities...); err != nil {
+							fmt.Printf(color.RedString("    ❌ Failed to add SSH identity to agent: %v\n"), err)
 							// Consider this potentially fatal? Or just warn? Warn for now.
 						} else {
-							fmt.Printf("    ✅ SSH identity loaded: %s\n", color.CyanString(profile.SSHIdentity))
+							fmt.Printf("    ✅ SSH identity loaded: %s\n", color.CyanString(strings.Join(profile.SSHIdentities, ", ")))
 						}
 					}
 				}
 			}
 			// 3d. Ensure SSH config includes host alias (done by 'add' or manually)
 			// We assume the host alias config is correct here, but maybe add a check later?
-			// ssh.ConfigureSSH(platformID, profileName, profile.SSHIdentity) // Re-running this might be too aggressive
+			// ssh.ConfigureSSH(platformID, profileName, profile.SSHIdentities) // Re-running this might be too aggressive
 
 		} else {
 			// --- HTTPS Logic ---
 			fmt.Println(color.YellowString("  🔑 Handling HTTPS Configuration..."))
-			// 3e. Update Git credentials (uses token)
-			if profile.GetToken() == "" {
+			// 3e. Update Git credentials (uses token), unlocking a
+			// password-protected token first. Platforms with a credential
+			// helper need no token.
+			usesHelper := plat != nil && plat.CredentialHelper != ""
+			if profile.IsPasswordProtected() && !usesHelper {
+				if err := config.UnlockProfileToken(profileName, &profile); err != nil {
+					fmt.Println(color.RedString("    %v", err))
+				}
+			}
+			if usesHelper && localScope {
+				fmt.Println(color.YellowString("    ℹ️ Leaving the global credential helper unchanged (identity scope: local)"))
+			} else if usesHelper {
+				if err := git.SetCredentialHelper(plat.CredentialHelper); err != nil {
+					fmt.Printf(color.RedString("    ⚠️ Failed to set the Git credential helper: %v\n"), err)
+				} else {
+					fmt.Printf("    ✅ Git credential helper set for %s: %s\n", plat.Name, color.CyanString(plat.CredentialHelper))
+				}
+			} else if profile.IsPasswordProtected() {
+				fmt.Println(color.YellowString("      💡 Git might prompt for credentials manually."))
+			} else if profile.GetToken() == "" {
 				fmt.Println(color.YellowString("    ⚠️ Profile '%s' uses HTTPS but has no token configured."), profileName)
 				fmt.Println(color.YellowString("      💡 Git might prompt for credentials manually."))
+			} else if localScope {
+				fmt.Println(color.YellowString("    ℹ️ Leaving ~/.git-credentials unchanged (identity scope: local)"))
 			} else {
 				if err := git.UpdateGitCredentials(&profile); err != nil {
 					fmt.Printf(color.RedString("    ⚠️ Failed to update Git credentials: %v\n"), err)
@@ -173,34 +319,189 @@ If run inside a Git repository, it also:
 		}
 
 		// 4. Update Git remote URL if in a repository
-		if git.IsInGitRepo() {
-			fmt.Println(color.YellowString("  🔗 Handling Git Remote URL..."))
-			finalURL, err := git.RewriteRemote(&profile, profileName)
-			if err != nil {
-				fmt.Printf(color.RedString("    ⚠️ Failed to rewrite remote URL: %v\n"), err)
-				// Non-fatal
-			} else if finalURL != "" {
-				fmt.Printf("    ✅ Remote 'origin' set to use %s: %s\n",
-					color.CyanString(strings.ToUpper(profile.AuthMethod)),
-					color.CyanString(finalURL))
+		if repoRoot, err := git.GetRepoRoot(); err == nil {
+			fmt.Printf(color.YellowString("  🔗 Handling Git Remote URL for %s...\n"), repoRoot)
+			if switchAllRemotes {
+				rewriteAllRemotes(&profile, profileName)
 			} else {
-				// This case happens if RewriteRemote couldn't get the current URL
-				fmt.Println(color.YellowString("    ℹ️ Skipping remote rewrite (could not determine current remote)."))
+				rewriteSwitchRemote(&profile, profileName)
 			}
 		} else {
 			fmt.Println(color.YellowString("  ℹ️ Not inside a Git repository, skipping remote URL update."))
 		}
 
+		// 5. Write shell activation scripts for the profile's environment
+		if err := config.WriteActivationScripts(profile); err != nil {
+			fmt.Printf(color.RedString("  ⚠️ Failed to write shell activation scripts: %v\n"), err)
+		} else if profile.GnupghomeOverride != "" {
+			fmt.Printf("  ✅ GNUPGHOME set to %s in ~/.gat/activate.sh\n", color.CyanString(profile.GnupghomeOverride))
+			if !switchEval {
+				fmt.Println(color.YellowString("    💡 Run 'source ~/.gat/activate.sh' or use 'eval \"$(gat switch %s --eval)\"'", profileName))
+			}
+		}
+
+		// 6. Record the session start for 'gat session check'
+		if err := config.StartSession(profileName); err != nil {
+			fmt.Printf(color.RedString("  ⚠️ Failed to record session start: %v\n"), err)
+		} else if profile.MaxSessionDuration > 0 {
+			fmt.Printf("  ⏰ Session expires in %s\n", color.CyanString(profile.MaxSessionDuration.String()))
+			fmt.Println(color.YellowString("    💡 Run 'gat session check' from a shell hook or cron to clear credentials once it expires"))
+		}
+
 		// --- End applying changes ---
 
 		fmt.Println(color.GreenString("\n✅ Switched successfully to profile: %s", profileName))
+		notifyWebhooks(config.WebhookEventSwitch, profileName)
+		if previousProfile != "" && previousProfile != profileName {
+			logAudit(audit.EventSwitch, profileName, "from "+previousProfile)
+		} else {
+			logAudit(audit.EventSwitch, profileName, "")
+		}
+
+		if switchEval {
+			script, err := config.ActivationScript(profile, activationShell())
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(evalOutput, script)
+		}
 
 		return nil
 	},
 }
 
+// activationShell returns the activation script flavour for the user's
+// login shell ("sh", "fish" or "csh")
+func activationShell() string {
+	switch filepath.Base(os.Getenv("SHELL")) {
+	case "fish":
+		return "fish"
+	case "csh", "tcsh":
+		return "csh"
+	default:
+		return "sh"
+	}
+}
+
+// rewriteSwitchRemote points the --remote remote ('origin' by default) and
+// the profile's mirror remotes at the profile's auth method
+func rewriteSwitchRemote(profile *config.Profile, profileName string) {
+	finalURL, err := git.RewriteNamedRemote(profile, profileName, switchRemote)
+	if err != nil {
+		fmt.Printf(color.RedString("    ⚠️ Failed to rewrite remote URL: %v\n"), err)
+		// Non-fatal
+	} else if finalURL != "" {
+		fmt.Printf("    ✅ Remote '%s' set to use %s: %s\n", switchRemote,
+			color.CyanString(strings.ToUpper(profile.AuthMethod)),
+			color.CyanString(finalURL))
+	} else {
+		// This case happens if RewriteNamedRemote couldn't get the current URL
+		fmt.Println(color.YellowString("    ℹ️ Skipping remote rewrite (could not determine current remote)."))
+	}
+
+	// Keep the profile's mirror remotes on the same auth method as origin
+	updated, missing, err := git.RewriteMirrorRemotes(profile, profileName)
+	if err != nil {
+		fmt.Printf(color.RedString("    ⚠️ Failed to rewrite mirror remotes: %v\n"), err)
+	}
+	for _, name := range profile.MirrorRemotes {
+		if url, ok := updated[name]; ok {
+			fmt.Printf("    ✅ Mirror remote '%s' set to use %s: %s\n", name,
+				color.CyanString(strings.ToUpper(profile.AuthMethod)),
+				color.CyanString(url))
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Println(color.YellowString("    ℹ️ Mirror remote(s) not in this repository: %s", strings.Join(missing, ", ")))
+	}
+}
+
+// rewriteAllRemotes points every remote of the repository at the profile's
+// auth method, for 'gat switch --all-remotes'
+func rewriteAllRemotes(profile *config.Profile, profileName string) {
+	updated, err := git.UpdateAllRemotes(profile, profileName)
+	if err != nil {
+		fmt.Printf(color.RedString("    ⚠️ Failed to rewrite remotes: %v\n"), err)
+	}
+	names := make([]string, 0, len(updated))
+	for name := range updated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("    ✅ Remote '%s' set to use %s: %s\n", name,
+			color.CyanString(strings.ToUpper(profile.AuthMethod)),
+			color.CyanString(updated[name]))
+	}
+	if err == nil && len(updated) == 0 {
+		fmt.Printf("    ✅ All remotes already use %s\n", color.CyanString(strings.ToUpper(profile.AuthMethod)))
+	}
+}
+
+// pickTaggedProfile lets the user pick one of the profiles that have all of
+// tags, for the --tag flag of 'gat switch' and 'gat remove'
+func pickTaggedProfile(tags []string, label string) (string, error) {
+	if err := config.ValidateTags(tags); err != nil {
+		return "", err
+	}
+	validConfig, _, ioErr := config.LoadConfig()
+	if ioErr != nil {
+		return "", ioErr
+	}
+	names := config.ProfilesWithTags(&validConfig, tags)
+	if len(names) == 0 {
+		return "", utils.Errorf(config.ErrProfileNotFound, "❌ no profile is tagged %s", strings.Join(tags, ", "))
+	}
+	if !stdinIsTerminal() {
+		return "", fmt.Errorf("❌ --tag asks which profile to use and needs a terminal; give the profile name instead")
+	}
+
+	items := make([]string, len(names))
+	for i, name := range names {
+		profile := validConfig.Profiles[name]
+		items[i] = fmt.Sprintf("%s (%s on %s)", name, profile.Username, profile.GetPlatform())
+		if name == validConfig.Current {
+			items[i] += " ✅"
+		}
+	}
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("%s (tagged %s)", label, strings.Join(tags, ", ")),
+		Items: items,
+		Size:  min(len(items), 10),
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("❌ no profile picked")
+	}
+	return names[index], nil
+}
+
+// resolveRepoProfile resolves the profile for the current directory's
+// repository (if any) with git.Manager.GetRepoProfile
+func resolveRepoProfile() (string, string, error) {
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		repoRoot = "" // Not in a repository: only GAT_PROFILE and the active profile apply
+	}
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		return "", "", err
+	}
+	manager := git.NewManager(config.NewManager(configPath), platform.NewRegistry())
+	return manager.GetRepoProfile(repoRoot)
+}
+
 func init() {
 	rootCmd.AddCommand(switchCmd)
 
 	switchCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Simulate the switch without making changes")
+	switchCmd.Flags().StringVar(&sessionTimeout, "session-timeout", "", "Clear credentials this long after switching (e.g. '8h', '1d'; '0' disables); saved on the profile")
+	switchCmd.Flags().BoolVar(&switchNoGlobal, "no-global", false, "Set the identity in the repository's .git/config only and skip ~/.git-credentials; remembered for later switches")
+	switchCmd.Flags().BoolVar(&switchEval, "eval", false, "Print shell commands applying the profile's environment (for eval) and send other output to stderr")
+	switchCmd.Flags().StringVar(&switchRemote, "remote", "origin", "Remote to point at the profile's auth method")
+	switchCmd.Flags().BoolVar(&switchAllRemotes, "all-remotes", false, "Point every remote of the repository at the profile's auth method")
+	switchCmd.MarkFlagsMutuallyExclusive("remote", "all-remotes")
+	switchCmd.RegisterFlagCompletionFunc("remote", completeRemoteFlag)
+	switchCmd.Flags().StringArrayVar(&switchTags, "tag", nil, "Pick from the profiles with this tag (repeatable: profiles with all the tags)")
+	switchCmd.RegisterFlagCompletionFunc("tag", completeTagFlag)
 }
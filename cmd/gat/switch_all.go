@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/utils"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// switchAllCmd represents the switch-all command
+var switchAllCmd = &cobra.Command{
+	Use:   "switch-all <profile>",
+	Short: "🔁 Point every repository under the current directory at a profile",
+	Long: `🔁 Finds every Git repository under the current directory and rewrites its
+'origin' remote to match the profile's auth method (SSH host alias or HTTPS).
+
+Directories ignored by the .gitignore in the current directory are skipped.
+The active profile and global Git identity are not changed; use 'gat switch' for that.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName := args[0]
+
+		// Validate profile name for security
+		if err := config.ValidateProfileName(profileName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+
+		validConfig, validationErrors, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		// Accept an alias in place of the profile name (see 'gat alias')
+		profileName = config.ResolveAlias(&validConfig, profileName)
+		if validationErr, isInvalid := validationErrors[profileName]; isInvalid {
+			return fmt.Errorf("❌ cannot switch to profile '%s' because it failed validation: %v", profileName, validationErr)
+		}
+		profile, exists := validConfig.Profiles[profileName]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+		}
+
+		startDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("❌ could not get current directory: %w", err)
+		}
+		// Remote rewriting works on the current directory, so visit each repo in turn
+		defer os.Chdir(startDir)
+
+		var changed, skipped, failed []string
+		walkErr := git.WalkRepos(startDir, func(repoRoot string) error {
+			fmt.Printf("\n📁 %s\n", color.CyanString(repoRoot))
+			if err := os.Chdir(repoRoot); err != nil {
+				failed = append(failed, repoRoot)
+				fmt.Printf(color.RedString("  ⚠️ Could not enter repository: %v\n"), err)
+				return nil
+			}
+
+			before, err := git.GetCurrentRemoteURL()
+			if err != nil {
+				if errors.Is(err, git.ErrNoRemote) {
+					skipped = append(skipped, repoRoot)
+					fmt.Println(color.YellowString("  ℹ️ No 'origin' remote, skipping."))
+				} else {
+					failed = append(failed, repoRoot)
+					fmt.Printf(color.RedString("  ⚠️ %v\n"), err)
+				}
+				return nil
+			}
+
+			after, err := git.RewriteRemote(&profile, profileName)
+			switch {
+			case err != nil:
+				failed = append(failed, repoRoot)
+				fmt.Printf(color.RedString("  ⚠️ Failed to rewrite remote URL: %v\n"), err)
+			case after != before:
+				changed = append(changed, repoRoot)
+			default:
+				skipped = append(skipped, repoRoot)
+			}
+			return nil
+		})
+		if walkErr != nil {
+			return fmt.Errorf("❌ could not scan for repositories: %w", walkErr)
+		}
+
+		total := len(changed) + len(skipped) + len(failed)
+		if total == 0 {
+			fmt.Println(color.YellowString("ℹ️ No Git repositories found under %s", startDir))
+			return nil
+		}
+
+		fmt.Println("\n" + color.YellowString("🔍 Summary:"))
+		fmt.Printf("  %s Changed: %d\n", color.GreenString("✓"), len(changed))
+		fmt.Printf("  %s Skipped: %d\n", color.CyanString("-"), len(skipped))
+		fmt.Printf("  %s Errored: %d\n", color.RedString("✗"), len(failed))
+		for _, repoRoot := range failed {
+			fmt.Printf("    - %s\n", repoRoot)
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("❌ %d of %d repositories could not be switched", len(failed), total)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(switchAllCmd)
+}
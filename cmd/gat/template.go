@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templatePlatform    string
+	templateAuthMethod  string
+	templateHost        string
+	templateSSHIdentity string
+	templateTags        string
+
+	templateUsername  string
+	templateEmail     string
+	templateToken     string
+	templateSetupSSH  bool
+	templateOverwrite bool
+)
+
+// templateCmd represents the template command
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "📐 Manage templates for creating profiles",
+	Long: `📐 Templates hold the settings that profiles of one organization share, so
+new profiles only need a username and email. {name} in the SSH identity is
+replaced with the profile name:
+
+  gat template add corp --platform github --auth-method ssh --ssh-identity "~/.ssh/gat_{name}"
+  gat template use corp alice --username alice --email alice@example.com`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Default behavior is to list templates
+		return listTemplateCmd.RunE(cmd, args)
+	},
+}
+
+// addTemplateCmd represents the add subcommand of template
+var addTemplateCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a profile template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("platform") {
+			if _, err := platform.NewRegistry().GetPlatform(strings.ToLower(templatePlatform)); err != nil {
+				return fmt.Errorf("❌ invalid platform ID '%s': %w", templatePlatform, err)
+			}
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		_, exists := validConfig.Templates[args[0]]
+		template := config.ProfileTemplate{
+			Name:                args[0],
+			Platform:            templatePlatform,
+			AuthMethod:          templateAuthMethod,
+			Host:                templateHost,
+			SSHIdentityTemplate: ssh.CollapseIdentityPath(templateSSHIdentity),
+			TagsTemplate:        templateTags,
+		}
+		if err := config.AddTemplate(&validConfig, template); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		if exists {
+			fmt.Printf("✅ Replaced template %s\n", color.GreenString(args[0]))
+		} else {
+			fmt.Printf("✅ Added template %s\n", color.GreenString(args[0]))
+		}
+		fmt.Printf("ℹ️ Create a profile from it with: %s\n", color.YellowString("gat template use %s <profile> --username <user> --email <email>", args[0]))
+		return nil
+	},
+}
+
+// listTemplateCmd represents the list subcommand of template
+var listTemplateCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all profile templates",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if len(validConfig.Templates) == 0 {
+			fmt.Println("😶 No templates defined. Add one with 'gat template add <name>'")
+			return nil
+		}
+
+		names := make([]string, 0, len(validConfig.Templates))
+		for name := range validConfig.Templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println("📐 Profile templates:")
+		for _, name := range names {
+			template := validConfig.Templates[name]
+			fmt.Printf("  %s\n", color.GreenString(name))
+			if template.Platform != "" {
+				fmt.Printf("     Platform: %s\n", template.Platform)
+			}
+			if template.Host != "" {
+				fmt.Printf("     Host: %s\n", template.Host)
+			}
+			if template.AuthMethod != "" {
+				fmt.Printf("     Auth: %s\n", template.AuthMethod)
+			}
+			if template.SSHIdentityTemplate != "" {
+				fmt.Printf("     SSH Identity: %s\n", template.SSHIdentityTemplate)
+			}
+			if template.TagsTemplate != "" {
+				fmt.Printf("     Tags: %s\n", template.TagsTemplate)
+			}
+		}
+		return nil
+	},
+}
+
+// removeTemplateCmd represents the remove subcommand of template
+var removeTemplateCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a profile template (profiles created from it are kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if err := config.RemoveTemplate(&validConfig, args[0]); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed template %s\n", color.GreenString(args[0]))
+		return nil
+	},
+}
+
+// useTemplateCmd represents the use subcommand of template
+var useTemplateCmd = &cobra.Command{
+	Use:   "use <template> <profile>",
+	Short: "Create a profile from a template",
+	Long: `Creates a new profile with the template's platform, host, auth method, SSH
+identity and tags ({name} replaced with the profile name). Flags override the
+template's values.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templateName, profileName := args[0], args[1]
+		if err := config.ValidateProfileName(profileName); err != nil {
+			return fmt.Errorf("❌ %v", err)
+		}
+		if templateUsername == "" || templateEmail == "" {
+			return fmt.Errorf("❌ --username and --email are required")
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		template, exists := validConfig.Templates[templateName]
+		if !exists {
+			return fmt.Errorf("❌ template '%s' does not exist", templateName)
+		}
+
+		profile := template.NewProfile(profileName)
+		profile.Username = templateUsername
+		profile.Email = templateEmail
+		if cmd.Flags().Changed("host") {
+			profile.Host = templateHost
+		}
+		if cmd.Flags().Changed("ssh-identity") {
+			profile.SetSSHIdentity(ssh.CollapseIdentityPath(templateSSHIdentity))
+		}
+		if cmd.Flags().Changed("auth-method") {
+			profile.AuthMethod = strings.ToLower(templateAuthMethod)
+		}
+
+		plat, err := platform.NewRegistry().GetPlatform(profile.Platform)
+		if err != nil {
+			return fmt.Errorf("❌ template '%s' has an invalid platform ID '%s': %w", templateName, profile.Platform, err)
+		}
+		if templateToken != "" {
+			if err := plat.ValidateToken(templateToken); err != nil {
+				return err
+			}
+			if err := plat.CheckTokenPrefix(templateToken); err != nil {
+				fmt.Println(color.YellowString("⚠️ Warning: %v", err))
+			}
+			profile.SetToken(templateToken, validConfig.StoreEncrypted, validConfig.EncryptionSecret())
+		}
+
+		if err := config.AddProfile(&validConfig, profileName, profile, templateOverwrite); err != nil {
+			return err
+		}
+		if len(validConfig.Profiles) == 1 {
+			validConfig.Current = profileName
+			fmt.Printf("✅ Set as current profile: %s\n", profileName)
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		if templateSetupSSH && len(profile.SSHIdentities) > 0 && profile.AuthMethod == "ssh" {
+			fmt.Println("🔐 Setting up SSH configuration...")
+			if err := ssh.UpdateSSHConfig(profile.Platform, profileName, profile.SSHIdentities, profile.SSHCertPath); err != nil {
+				fmt.Printf(color.YellowString("⚠️ Warning: Failed to update SSH config: %v\n"), err)
+			}
+		}
+
+		fmt.Printf("✅ Added profile %s from template %s (%s on %s, auth: %s)\n",
+			color.GreenString(profileName),
+			templateName,
+			color.CyanString(profile.Username),
+			color.MagentaString(profile.Platform),
+			color.BlueString(profile.AuthMethod))
+		if len(profile.SSHIdentities) > 0 && !ssh.KeyExists(profile.SSHIdentity()) {
+			fmt.Printf("💡 Generate its SSH key with: %s\n", color.YellowString("gat ssh generate "+profileName))
+		}
+		if validConfig.Current != profileName {
+			fmt.Printf("\nℹ️ To use this profile, run: %s\n", color.YellowString("gat switch "+profileName))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(addTemplateCmd)
+	templateCmd.AddCommand(listTemplateCmd)
+	templateCmd.AddCommand(removeTemplateCmd)
+	templateCmd.AddCommand(useTemplateCmd)
+
+	addTemplateCmd.Flags().StringVar(&templatePlatform, "platform", "", "Git platform of the profiles (e.g., github, gitlab; default github)")
+	addTemplateCmd.Flags().StringVar(&templateAuthMethod, "auth-method", "", "Authentication method ('ssh' or 'https'). Defaults based on --ssh-identity.")
+	addTemplateCmd.Flags().StringVar(&templateHost, "host", "", "Custom hostname for self-hosted instances")
+	addTemplateCmd.Flags().StringVar(&templateSSHIdentity, "ssh-identity", "", "SSH identity path, {name} is replaced with the profile name (e.g. \"~/.ssh/gat_{name}\")")
+	addTemplateCmd.Flags().StringVar(&templateTags, "tags", "", "Comma-separated tags for the profiles, {name} is replaced with the profile name")
+
+	useTemplateCmd.Flags().StringVar(&templateUsername, "username", "", "Git username (required)")
+	useTemplateCmd.Flags().StringVar(&templateEmail, "email", "", "Git email (required)")
+	useTemplateCmd.Flags().StringVar(&templateToken, "token", "", "Git personal access token (used for HTTPS)")
+	useTemplateCmd.Flags().StringVar(&templateHost, "host", "", "Override the template's host")
+	useTemplateCmd.Flags().StringVar(&templateSSHIdentity, "ssh-identity", "", "Override the template's SSH identity")
+	useTemplateCmd.Flags().StringVar(&templateAuthMethod, "auth-method", "", "Override the template's authentication method")
+	useTemplateCmd.Flags().BoolVar(&templateOverwrite, "overwrite", false, "Overwrite profile if it already exists")
+	useTemplateCmd.Flags().BoolVar(&templateSetupSSH, "setup-ssh", true, "Set up SSH host alias in ~/.ssh/gat_config if using SSH auth method")
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/utils"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "⌛ Manage profile tokens",
+	Long: `⌛ Commands for the personal access tokens of profiles. Tokens themselves are
+set with 'gat add <name> --token <token> --overwrite'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// tokenSetExpiryCmd represents the set-expiry subcommand of token
+var tokenSetExpiryCmd = &cobra.Command{
+	Use:   "set-expiry <profile> <date>",
+	Short: "Record when a profile's token expires",
+	Long: `Records the date (YYYY-MM-DD) a profile's token expires without entering the
+token again. 'gat doctor' warns when it expires within 30 days and reports it
+once it has expired; 'gat status' shows it. Use "none" to remove the date.
+
+Examples:
+  gat token set-expiry work 2025-12-31
+  gat token set-expiry work none`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		expiry, err := config.ParseTokenExpiry(args[1])
+		if err != nil {
+			return err
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		name := config.ResolveAlias(&validConfig, args[0])
+		profile, exists := validConfig.Profiles[name]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", name)
+		}
+
+		profile.SetTokenExpiry(expiry)
+		validConfig.Profiles[name] = profile
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		if expiry == nil {
+			fmt.Printf("✅ Removed the token expiry of %s\n", color.GreenString(name))
+			return nil
+		}
+		fmt.Printf("✅ Token of %s expires on %s\n", color.GreenString(name), formatTokenExpiry(profile))
+		if profile.GetToken() == "" && !profile.IsPasswordProtected() {
+			fmt.Println(color.YellowString("⚠️ Profile '%s' has no token", name))
+		}
+		return nil
+	},
+}
+
+// tokenVerifyCmd represents the verify subcommand of token
+var tokenVerifyCmd = &cobra.Command{
+	Use:   "verify [profile]",
+	Short: "Check a profile's token against the platform API",
+	Long: `Calls the platform API with the profile's token (the active profile if no
+name is given), the same as 'gat verify'. It reports whether the token is
+valid, the account it authenticates as, and its scopes and expiry where the
+platform reports them (GitHub reports both for classic tokens).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return verifyCmd.RunE(cmd, args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenSetExpiryCmd)
+	tokenCmd.AddCommand(tokenVerifyCmd)
+}
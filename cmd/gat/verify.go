@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/utils"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [name]",
+	Short: "🔎 Check a profile's token against the platform API",
+	Long: `🔎 Calls the platform API with the profile's token (the active profile if no
+name is given) and reports the account it authenticates as, the token's
+scopes and expiry where the platform reports them, and any missing scopes.
+The account's avatar URL is saved to the profile and shown by
+'gat list --verbose'; a reported expiry replaces the one recorded with
+'gat token set-expiry'.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		profileName := validConfig.Current
+		if len(args) > 0 {
+			if err := config.ValidateProfileName(args[0]); err != nil {
+				return fmt.Errorf("❌ %v", err)
+			}
+			profileName = config.ResolveAlias(&validConfig, args[0])
+		}
+		if profileName == "" {
+			return fmt.Errorf("❌ no active profile; pass a profile name")
+		}
+		profile, exists := validConfig.Profiles[profileName]
+		if !exists {
+			return utils.Errorf(config.ErrProfileNotFound, "❌ profile '%s' does not exist", profileName)
+		}
+
+		if err := config.UnlockProfileToken(profileName, &profile); err != nil {
+			return err
+		}
+		token := profile.GetToken()
+		if token == "" {
+			return fmt.Errorf("❌ profile '%s' has no token configured", profileName)
+		}
+
+		plat, err := platform.NewRegistry().GetPlatform(profile.GetPlatform())
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+
+		fmt.Printf("🔎 Verifying the token of '%s' with %s...\n", profileName, plat.Name)
+		info, err := plat.VerifyToken(cmd.Context(), profile.Host, token)
+		if err != nil {
+			return fmt.Errorf("❌ token verification failed: %w", err)
+		}
+
+		fmt.Printf("✅ Token is valid and authenticates as %s\n", color.GreenString(info.Username))
+		if !strings.EqualFold(info.Username, profile.Username) {
+			fmt.Println(color.YellowString("⚠️ The profile's username is '%s'", profile.Username))
+		}
+		if info.Scopes != nil {
+			if len(info.Scopes) == 0 {
+				fmt.Println("🔑 Scopes: none")
+			} else {
+				fmt.Printf("🔑 Scopes: %s\n", strings.Join(info.Scopes, ", "))
+			}
+			if missing := plat.MissingScopes(info.Scopes); len(missing) > 0 {
+				fmt.Println(color.YellowString("⚠️ Token is missing scopes: %s", strings.Join(missing, ", ")))
+			}
+		}
+
+		changed := false
+		if info.Expiry != nil {
+			if expiry := info.Expiry.Local(); profile.TokenExpiry == nil || !profile.TokenExpiry.Equal(expiry) {
+				profile.SetTokenExpiry(&expiry)
+				changed = true
+			}
+			fmt.Printf("⌛ Token expires on %s\n", formatTokenExpiry(profile))
+		}
+		if info.AvatarURL != "" && info.AvatarURL != profile.AvatarURL {
+			profile.AvatarURL = info.AvatarURL
+			changed = true
+			fmt.Printf("🖼️ Saved avatar: %s\n", info.AvatarURL)
+		}
+		if changed {
+			validConfig.Profiles[profileName] = profile
+			if err := config.SaveConfig(&validConfig); err != nil {
+				return fmt.Errorf("❌ could not save the profile: %w", err)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/webhook"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	webhookMethod  string
+	webhookHeaders []string
+	webhookEvents  []string
+)
+
+// webhookCmd represents the webhook command
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "🪝 Manage webhooks notified of profile changes",
+	Long: `🪝 Webhooks are URLs gat calls after 'gat switch', 'gat add' and 'gat remove'
+succeed, e.g. to trigger CI jobs. Each gets a JSON body like
+
+  {"event":"switch","profile":"work","timestamp":"2025-01-01T12:00:00Z"}
+
+and has 5 seconds to answer. A failing webhook is reported as a warning; the
+command itself still succeeds.
+
+  gat webhook add https://ci.example.com/hooks/gat --event switch
+  gat webhook add https://hooks.example.com/gat --method PUT --header "Authorization: Bearer s3cret"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Default behavior is to list webhooks
+		return listWebhookCmd.RunE(cmd, args)
+	},
+}
+
+// addWebhookCmd represents the add subcommand of webhook
+var addWebhookCmd = &cobra.Command{
+	Use:   "add <url>",
+	Short: "Add or replace a webhook",
+	Long: `Adds a webhook, or replaces the one with the same URL. Without --event it
+receives every event (switch, add and remove).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hook := config.WebhookConfig{URL: args[0], Method: webhookMethod}
+		for _, header := range webhookHeaders {
+			name, value, ok := strings.Cut(header, ":")
+			if !ok {
+				return fmt.Errorf("❌ invalid header '%s'; use 'Name: value'", header)
+			}
+			if hook.Headers == nil {
+				hook.Headers = make(map[string]string)
+			}
+			hook.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		for _, events := range webhookEvents {
+			for _, event := range strings.Split(events, ",") {
+				if event = strings.TrimSpace(event); event != "" {
+					hook.Events = append(hook.Events, event)
+				}
+			}
+		}
+
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		if err := config.AddWebhook(&validConfig, hook); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Added webhook %s (%s)\n", color.GreenString(hook.URL), webhookEventList(hook))
+		return nil
+	},
+}
+
+// listWebhookCmd represents the list subcommand of webhook
+var listWebhookCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all webhooks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if len(validConfig.Webhooks) == 0 {
+			fmt.Println("😶 No webhooks defined. Add one with 'gat webhook add <url>'")
+			return nil
+		}
+
+		fmt.Println("🪝 Webhooks:")
+		for _, hook := range validConfig.Webhooks {
+			fmt.Printf("  %s %s\n", color.CyanString(hook.HTTPMethod()), color.GreenString(hook.URL))
+			fmt.Printf("     Events: %s\n", webhookEventList(hook))
+			if len(hook.Headers) > 0 {
+				// Only the names: the values are often credentials
+				names := make([]string, 0, len(hook.Headers))
+				for name := range hook.Headers {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				fmt.Printf("     Headers: %s\n", strings.Join(names, ", "))
+			}
+		}
+		return nil
+	},
+}
+
+// removeWebhookCmd represents the remove subcommand of webhook
+var removeWebhookCmd = &cobra.Command{
+	Use:   "remove <url>",
+	Short: "Remove a webhook",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+
+		if err := config.RemoveWebhook(&validConfig, args[0]); err != nil {
+			return err
+		}
+		if err := config.SaveConfig(&validConfig); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Removed webhook %s\n", color.GreenString(args[0]))
+		return nil
+	},
+}
+
+// webhookEventList describes the events a webhook receives
+func webhookEventList(hook config.WebhookConfig) string {
+	if len(hook.Events) == 0 {
+		return "all events"
+	}
+	return strings.Join(hook.Events, ", ")
+}
+
+// notifyWebhooks sends event for profile to the configured webhooks, warning
+// on stderr about the ones that fail
+func notifyWebhooks(event, profile string) {
+	if err := webhook.Notify(event, webhook.NewPayload(event, profile)); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintln(os.Stderr, color.YellowString("⚠️ Warning: %s", line))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd)
+	webhookCmd.AddCommand(addWebhookCmd)
+	webhookCmd.AddCommand(listWebhookCmd)
+	webhookCmd.AddCommand(removeWebhookCmd)
+
+	addWebhookCmd.Flags().StringVar(&webhookMethod, "method", "POST", "HTTP method of the request ('POST' or 'PUT')")
+	addWebhookCmd.Flags().StringArrayVar(&webhookHeaders, "header", nil, "Header sent with each request, as 'Name: value' (repeatable)")
+	addWebhookCmd.Flags().StringArrayVar(&webhookEvents, "event", nil, "Event to send: switch, add or remove (repeatable or comma-separated; default all)")
+	addWebhookCmd.RegisterFlagCompletionFunc("event", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.WebhookEvents, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	// 'gat webhook remove' completes the URLs of the webhooks
+	removeWebhookCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		validConfig, _, err := config.LoadConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		urls := make([]string, 0, len(validConfig.Webhooks))
+		for _, hook := range validConfig.Webhooks {
+			urls = append(urls, hook.URL)
+		}
+		return urls, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"gat/pkg/config"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	whoamiJSON bool
+)
+
+// whoamiIdentity is the --json output of 'gat whoami'
+type whoamiIdentity struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Platform string `json:"platform"`
+}
+
+// whoamiCmd represents the whoami command
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "👤 Print the active profile in one line",
+	Long: `👤 Prints the active profile as 'name (email on platform)', for shell prompts
+and scripts. Exits with an error if no profile is active.
+
+Examples:
+  gat whoami
+  gat whoami --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validConfig, _, ioErr := config.LoadConfig()
+		if ioErr != nil {
+			return ioErr
+		}
+		profile, profileName, err := config.GetCurrentProfile(&validConfig)
+		if err != nil {
+			return err
+		}
+
+		if whoamiJSON {
+			identity := whoamiIdentity{
+				Name:     profileName,
+				Username: profile.Username,
+				Email:    profile.Email,
+				Platform: profile.GetPlatform(),
+			}
+			if err := json.NewEncoder(os.Stdout).Encode(identity); err != nil {
+				return fmt.Errorf("❌ could not encode JSON output: %w", err)
+			}
+			return nil
+		}
+
+		fmt.Printf("%s (%s on %s)\n", profileName, profile.Email, profile.GetPlatform())
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+
+	whoamiCmd.Flags().BoolVar(&whoamiJSON, "json", false, "Print the profile as a single line of JSON")
+}
@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/ssh"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Diagnostic statuses, matching the DiagnosticStatus enum
+const (
+	statusPass = "PASS"
+	statusWarn = "WARN"
+	statusFail = "FAIL"
+	statusInfo = "INFO"
+)
+
+// Interval bounds for the diagnostics subscription
+const (
+	defaultDiagnosticsInterval = 30 * time.Second
+	minDiagnosticsInterval     = time.Second
+)
+
+// DiagnosticResult represents the result of the diagnostic checks
+type DiagnosticResult struct {
+	Checks        []*DiagnosticCheck
+	Summary       string
+	OverallStatus string
+}
+
+// DiagnosticCheck represents a single diagnostic check
+type DiagnosticCheck struct {
+	Name    string
+	Status  string
+	Message *string
+	Details *string
+}
+
+// Diagnostics re-runs the diagnostic checks every intervalSeconds (default 30)
+// and pushes each result to the subscriber until it disconnects
+func (r *Resolver) Diagnostics(ctx context.Context, args struct{ IntervalSeconds *int32 }) <-chan *DiagnosticResult {
+	interval := defaultDiagnosticsInterval
+	if args.IntervalSeconds != nil {
+		interval = time.Duration(*args.IntervalSeconds) * time.Second
+		if interval < minDiagnosticsInterval {
+			interval = minDiagnosticsInterval
+		}
+	}
+
+	results := make(chan *DiagnosticResult)
+	go func() {
+		defer close(results)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case results <- runDiagnostics():
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// runDiagnostics checks the config, the active profile, the global Git
+// identity and the active profile's credentials
+func runDiagnostics() *DiagnosticResult {
+	var checks []*DiagnosticCheck
+	addCheck := func(name, status, message, details string) {
+		checks = append(checks, &DiagnosticCheck{
+			Name:    name,
+			Status:  status,
+			Message: optionalString(message),
+			Details: optionalString(details),
+		})
+	}
+
+	validConfig, validationErrors, ioErr := config.LoadConfig()
+	if ioErr != nil {
+		addCheck("Config", statusFail, "Configuration could not be loaded", ioErr.Error())
+		return summarizeDiagnostics(checks)
+	}
+	if len(validationErrors) > 0 {
+		var invalid []string
+		for name, err := range validationErrors {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", name, err))
+		}
+		sort.Strings(invalid)
+		addCheck("Config", statusWarn, fmt.Sprintf("%d invalid profile(s)", len(validationErrors)), strings.Join(invalid, "; "))
+	} else {
+		addCheck("Config", statusPass, fmt.Sprintf("%d profile(s) configured", len(validConfig.Profiles)), "")
+	}
+
+	profile, exists := validConfig.Profiles[validConfig.Current]
+	if validConfig.Current == "" || !exists {
+		addCheck("Active Profile", statusWarn, "No active profile", "Switch to a profile with 'gat switch <name>'")
+		return summarizeDiagnostics(checks)
+	}
+	addCheck("Active Profile", statusPass, validConfig.Current, "")
+
+	identity, err := git.DiagnoseGitIdentity()
+	if err != nil {
+		addCheck("Git Identity", statusFail, "Could not read Git identity", err.Error())
+	} else if identity["username"] != profile.Username || identity["email"] != profile.Email {
+		addCheck("Git Identity", statusWarn, "Global Git identity does not match the active profile",
+			fmt.Sprintf("git: %s <%s>, profile: %s <%s>", identity["username"], identity["email"], profile.Username, profile.Email))
+	} else {
+		addCheck("Git Identity", statusPass, fmt.Sprintf("%s <%s>", profile.Username, profile.Email), "")
+	}
+
+	if profile.AuthMethod == "ssh" {
+		missing, err := ssh.CheckSSHIdentities(profile.SSHIdentities, profile.SSHCertPath)
+		switch {
+		case err != nil:
+			addCheck("SSH Identity", statusFail, "Could not check SSH identity", err.Error())
+		case len(profile.SSHIdentities) == 0 || missing != "":
+			addCheck("SSH Identity", statusFail, "SSH identity or public key not found", missing)
+		default:
+			var permErr error
+			for _, identity := range profile.SSHIdentities {
+				if permErr = ssh.ValidateIdentityPermissions(identity); permErr != nil {
+					break
+				}
+			}
+			if permErr != nil {
+				addCheck("SSH Identity", statusFail, "SSH identity permissions are too open", permErr.Error())
+			} else {
+				addCheck("SSH Identity", statusPass, strings.Join(profile.SSHIdentities, ", "), "")
+			}
+		}
+	} else if profile.GetToken() == "" {
+		addCheck("Token", statusWarn, "HTTPS profile has no token configured", "")
+	} else {
+		addCheck("Token", statusPass, "Token configured", "")
+	}
+
+	return summarizeDiagnostics(checks)
+}
+
+// summarizeDiagnostics derives the overall status and summary from the checks
+func summarizeDiagnostics(checks []*DiagnosticCheck) *DiagnosticResult {
+	warnings, failures := 0, 0
+	for _, check := range checks {
+		switch check.Status {
+		case statusWarn:
+			warnings++
+		case statusFail:
+			failures++
+		}
+	}
+
+	result := &DiagnosticResult{Checks: checks, OverallStatus: statusPass, Summary: "All checks passed"}
+	switch {
+	case failures > 0:
+		result.OverallStatus = statusFail
+		result.Summary = fmt.Sprintf("%d check(s) failed, %d warning(s)", failures, warnings)
+	case warnings > 0:
+		result.OverallStatus = statusWarn
+		result.Summary = fmt.Sprintf("%d warning(s)", warnings)
+	}
+	return result
+}
@@ -3,14 +3,39 @@ package graphql
 import (
 	"net/http"
 
+	"github.com/gorilla/websocket"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 )
 
+// APIHandler serves GraphQL queries and mutations over HTTP and
+// subscriptions over WebSocket (graphql-transport-ws) on the same path
+type APIHandler struct {
+	http *relay.Handler
+	ws   *wsHandler
+}
+
 // Handler returns an HTTP handler for the GraphQL API
-func Handler(resolver *Resolver) http.Handler {
-	schema := graphql.MustParseSchema(Schema, resolver)
-	return &relay.Handler{Schema: schema}
+func Handler(resolver *Resolver) *APIHandler {
+	schema := graphql.MustParseSchema(Schema, resolver, graphql.UseFieldResolvers())
+	return &APIHandler{
+		http: &relay.Handler{Schema: schema},
+		ws:   newWSHandler(schema),
+	}
+}
+
+// ServeHTTP dispatches WebSocket upgrade requests to the subscription transport
+func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		h.ws.ServeHTTP(w, r)
+		return
+	}
+	h.http.ServeHTTP(w, r)
+}
+
+// CloseSubscriptions sends a close frame to every subscription client
+func (h *APIHandler) CloseSubscriptions() {
+	h.ws.CloseAll()
 }
 
 // PlaygroundHandler returns an HTTP handler for the GraphQL playground UI
@@ -29,10 +29,13 @@ type Profile struct {
 	Username    string
 	Email       string
 	Platform    string
-	Host        string
+	Host        *string
 	Token       string
-	SSHIdentity string
+	SSHIdentity *string
 	IsActive    bool
+	Description *string
+
+	platformReg *platform.Registry // Used to resolve platformDetails
 }
 
 // Platform represents a Git hosting platform
@@ -52,14 +55,36 @@ func (p *Profile) HasToken() bool {
 	return p.Token != ""
 }
 
-// ProfileDetailsResolver resolves platform details for a profile
-func (r *Resolver) ProfileDetailsResolver(ctx context.Context, profile *Profile) (*Platform, error) {
-	platID := profile.Platform
-	plat, err := r.platformReg.GetPlatform(platID)
+// PlatformDetails resolves the full platform definition for a profile
+func (p *Profile) PlatformDetails() *Platform {
+	if p.platformReg == nil {
+		return nil
+	}
+	plat, err := p.platformReg.GetPlatform(p.Platform)
 	if err != nil {
-		return nil, err
+		return nil
 	}
+	return newPlatform(plat)
+}
 
+// newProfile converts a stored profile to its GraphQL representation
+func (r *Resolver) newProfile(name string, profile config.Profile, isActive bool) *Profile {
+	return &Profile{
+		Name:        name,
+		Username:    profile.Username,
+		Email:       profile.Email,
+		Platform:    profile.GetPlatform(),
+		Host:        optionalString(profile.Host),
+		Token:       profile.GetToken(),
+		SSHIdentity: optionalString(profile.SSHIdentity()),
+		IsActive:    isActive,
+		Description: optionalString(profile.Description),
+		platformReg: r.platformReg,
+	}
+}
+
+// newPlatform converts a registry platform to its GraphQL representation
+func newPlatform(plat *platform.Platform) *Platform {
 	return &Platform{
 		ID:             plat.ID,
 		Name:           plat.Name,
@@ -69,7 +94,7 @@ func (r *Resolver) ProfileDetailsResolver(ctx context.Context, profile *Profile)
 		SSHUser:        plat.SSHUser,
 		TokenAuthScope: plat.TokenAuthScope,
 		IsCustom:       plat.Custom,
-	}, nil
+	}
 }
 
 // Profiles returns all profiles
@@ -82,16 +107,7 @@ func (r *Resolver) Profiles(ctx context.Context) ([]*Profile, error) {
 	var profiles []*Profile
 	for name, profile := range profilesMap {
 		isActive := name == r.configManager.GetCurrent()
-		profiles = append(profiles, &Profile{
-			Name:        name,
-			Username:    profile.Username,
-			Email:       profile.Email,
-			Platform:    profile.Platform,
-			Host:        profile.Host,
-			Token:       profile.Token,
-			SSHIdentity: profile.SSHIdentity,
-			IsActive:    isActive,
-		})
+		profiles = append(profiles, r.newProfile(name, profile, isActive))
 	}
 
 	return profiles, nil
@@ -110,16 +126,7 @@ func (r *Resolver) Profile(ctx context.Context, args struct{ Name string }) (*Pr
 	}
 
 	isActive := args.Name == r.configManager.GetCurrent()
-	return &Profile{
-		Name:        args.Name,
-		Username:    profile.Username,
-		Email:       profile.Email,
-		Platform:    profile.Platform,
-		Host:        profile.Host,
-		Token:       profile.Token,
-		SSHIdentity: profile.SSHIdentity,
-		IsActive:    isActive,
-	}, nil
+	return r.newProfile(args.Name, profile, isActive), nil
 }
 
 // CurrentProfile returns the current active profile
@@ -139,16 +146,7 @@ func (r *Resolver) CurrentProfile(ctx context.Context) (*Profile, error) {
 		return nil, nil // Should not happen, but handle anyway
 	}
 
-	return &Profile{
-		Name:        currentName,
-		Username:    profile.Username,
-		Email:       profile.Email,
-		Platform:    profile.Platform,
-		Host:        profile.Host,
-		Token:       profile.Token,
-		SSHIdentity: profile.SSHIdentity,
-		IsActive:    true,
-	}, nil
+	return r.newProfile(currentName, profile, true), nil
 }
 
 // Platforms returns all platforms
@@ -157,16 +155,7 @@ func (r *Resolver) Platforms(ctx context.Context) ([]*Platform, error) {
 
 	var platforms []*Platform
 	for _, plat := range platsList {
-		platforms = append(platforms, &Platform{
-			ID:             plat.ID,
-			Name:           plat.Name,
-			DefaultHost:    plat.DefaultHost,
-			SSHPrefix:      plat.SSHPrefix,
-			HTTPSPrefix:    plat.HTTPSPrefix,
-			SSHUser:        plat.SSHUser,
-			TokenAuthScope: plat.TokenAuthScope,
-			IsCustom:       plat.Custom,
-		})
+		platforms = append(platforms, newPlatform(plat))
 	}
 
 	return platforms, nil
@@ -179,16 +168,12 @@ func (r *Resolver) Platform(ctx context.Context, args struct{ ID string }) (*Pla
 		return nil, nil // Return nil for not found
 	}
 
-	return &Platform{
-		ID:             plat.ID,
-		Name:           plat.Name,
-		DefaultHost:    plat.DefaultHost,
-		SSHPrefix:      plat.SSHPrefix,
-		HTTPSPrefix:    plat.HTTPSPrefix,
-		SSHUser:        plat.SSHUser,
-		TokenAuthScope: plat.TokenAuthScope,
-		IsCustom:       plat.Custom,
-	}, nil
+	return newPlatform(plat), nil
+}
+
+// Doctor runs the diagnostic checks once
+func (r *Resolver) Doctor(ctx context.Context) (*DiagnosticResult, error) {
+	return runDiagnostics(), nil
 }
 
 // SwitchProfileInput represents input for switching profiles
@@ -200,10 +185,10 @@ type SwitchProfileInput struct {
 
 // SwitchProfileResult represents the result of a profile switch
 type SwitchProfileResult struct {
-	Success   bool
-	Message   *string
-	Profile   *Profile
-	GitConfig []*GitConfigChange
+	Success          bool
+	Message          *string
+	Profile          *Profile
+	GitConfigChanges *[]*GitConfigChange
 }
 
 // GitConfigChange represents a Git config change
@@ -220,7 +205,7 @@ func (r *Resolver) SwitchProfile(ctx context.Context, args struct{ Input SwitchP
 	return &SwitchProfileResult{
 		Success: true,
 		Message: strPtr("Profile switched successfully"),
-		// Would populate Profile and GitConfig
+		// Would populate Profile and GitConfigChanges
 	}, nil
 }
 
@@ -228,3 +213,11 @@ func (r *Resolver) SwitchProfile(ctx context.Context, args struct{ Input SwitchP
 func strPtr(s string) *string {
 	return &s
 }
+
+// optionalString returns nil for an empty string so it resolves to null
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -25,15 +25,11 @@ const Schema = `
   type Mutation {
     # Switch to a different profile
     switchProfile(input: SwitchProfileInput!): SwitchProfileResult!
-    
-    # Add a new profile
-    addProfile(input: AddProfileInput!): AddProfileResult!
-    
-    # Remove a profile
-    removeProfile(name: String!): RemoveProfileResult!
-    
-    # Register a custom platform
-    registerPlatform(input: RegisterPlatformInput!): RegisterPlatformResult!
+  }
+
+  type Subscription {
+    # Re-run diagnostic checks every intervalSeconds (default 30) and push the results
+    diagnostics(intervalSeconds: Int): DiagnosticResult!
   }
 
   # A Git profile with identity information
@@ -47,6 +43,8 @@ const Schema = `
     hasToken: Boolean!
     sshIdentity: String
     isActive: Boolean!
+    # Markdown description of the profile
+    description: String
   }
 
   # A Git hosting platform definition
@@ -89,52 +87,6 @@ const Schema = `
     newValue: String
   }
 
-  # Input for adding a new profile
-  input AddProfileInput {
-    name: String!
-    username: String!
-    email: String!
-    platform: String!
-    host: String
-    token: String
-    sshIdentity: String
-    setupSsh: Boolean
-    overwrite: Boolean
-  }
-
-  # Result of an add profile operation
-  type AddProfileResult {
-    success: Boolean!
-    message: String
-    profile: Profile
-  }
-
-  # Result of a remove profile operation
-  type RemoveProfileResult {
-    success: Boolean!
-    message: String
-    profileName: String!
-  }
-
-  # Input for registering a custom platform
-  input RegisterPlatformInput {
-    id: String!
-    name: String!
-    defaultHost: String!
-    sshPrefix: String!
-    httpsPrefix: String!
-    sshUser: String
-    tokenAuthScope: String
-    force: Boolean
-  }
-
-  # Result of a platform registration operation
-  type RegisterPlatformResult {
-    success: Boolean!
-    message: String
-    platform: Platform
-  }
-
   # Result of diagnostic checks
   type DiagnosticResult {
     checks: [DiagnosticCheck!]!
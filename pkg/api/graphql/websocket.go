@@ -0,0 +1,268 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Message types of the graphql-transport-ws protocol
+// (https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md)
+const (
+	wsProtocol = "graphql-transport-ws"
+
+	msgConnectionInit = "connection_init"
+	msgConnectionAck  = "connection_ack"
+	msgPing           = "ping"
+	msgPong           = "pong"
+	msgSubscribe      = "subscribe"
+	msgNext           = "next"
+	msgError          = "error"
+	msgComplete       = "complete"
+)
+
+// Close codes defined by graphql-transport-ws
+const (
+	closeBadRequest         = 4400
+	closeUnauthorized       = 4401
+	closeInitTimeout        = 4408
+	closeSubscriberExists   = 4409
+	closeTooManyInitRequest = 4429
+)
+
+// How long a client has to send connection_init after connecting
+const connectionInitTimeout = 10 * time.Second
+
+// wsMessage is a single graphql-transport-ws message
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscribePayload is the payload of a subscribe message
+type subscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// wsConnection is one WebSocket client and its active operations
+type wsConnection struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex // gorilla/websocket allows one concurrent writer
+
+	mu   sync.Mutex
+	subs map[string]context.CancelFunc
+}
+
+// write sends a message to the client
+func (c *wsConnection) write(msg wsMessage) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(msg)
+}
+
+// close sends a close frame with the given code and closes the connection
+func (c *wsConnection) close(code int, reason string) {
+	c.writeMu.Lock()
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	c.writeMu.Unlock()
+	c.conn.Close()
+}
+
+// wsHandler upgrades GraphQL requests to WebSocket connections speaking
+// graphql-transport-ws and tracks them so they can be closed on shutdown
+type wsHandler struct {
+	schema   *graphql.Schema
+	upgrader websocket.Upgrader
+
+	mu    sync.Mutex
+	conns map[*wsConnection]struct{}
+}
+
+// newWSHandler creates a WebSocket handler for the schema
+func newWSHandler(schema *graphql.Schema) *wsHandler {
+	return &wsHandler{
+		schema:   schema,
+		upgrader: websocket.Upgrader{Subprotocols: []string{wsProtocol}},
+		conns:    make(map[*wsConnection]struct{}),
+	}
+}
+
+// ServeHTTP upgrades the request and serves the connection until it closes
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade has already written an HTTP error
+	}
+
+	c := &wsConnection{conn: conn, subs: make(map[string]context.CancelFunc)}
+	if conn.Subprotocol() != wsProtocol {
+		c.close(websocket.CloseProtocolError, "Subprotocol not acceptable")
+		return
+	}
+
+	h.mu.Lock()
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, c)
+		h.mu.Unlock()
+
+		c.mu.Lock()
+		for _, cancel := range c.subs {
+			cancel()
+		}
+		c.mu.Unlock()
+		conn.Close()
+	}()
+
+	h.serveConnection(r.Context(), c)
+}
+
+// serveConnection runs the read loop for one client
+func (h *wsHandler) serveConnection(ctx context.Context, c *wsConnection) {
+	acknowledged := false
+	initTimer := time.AfterFunc(connectionInitTimeout, func() {
+		c.close(closeInitTimeout, "Connection initialisation timeout")
+	})
+	defer initTimer.Stop()
+
+	for {
+		var msg wsMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if _, isCloseErr := err.(*websocket.CloseError); !isCloseErr {
+				c.close(closeBadRequest, "Invalid message received")
+			}
+			return
+		}
+
+		switch msg.Type {
+		case msgConnectionInit:
+			if acknowledged {
+				c.close(closeTooManyInitRequest, "Too many initialisation requests")
+				return
+			}
+			initTimer.Stop()
+			acknowledged = true
+			if err := c.write(wsMessage{Type: msgConnectionAck}); err != nil {
+				return
+			}
+
+		case msgPing:
+			if err := c.write(wsMessage{Type: msgPong, Payload: msg.Payload}); err != nil {
+				return
+			}
+
+		case msgPong:
+			// Nothing to do
+
+		case msgSubscribe:
+			if !acknowledged {
+				c.close(closeUnauthorized, "Unauthorized")
+				return
+			}
+			var payload subscribePayload
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil {
+				c.close(closeBadRequest, "Invalid subscribe message")
+				return
+			}
+
+			c.mu.Lock()
+			_, exists := c.subs[msg.ID]
+			var subCtx context.Context
+			if !exists {
+				var cancel context.CancelFunc
+				subCtx, cancel = context.WithCancel(ctx)
+				c.subs[msg.ID] = cancel
+			}
+			c.mu.Unlock()
+			if exists {
+				c.close(closeSubscriberExists, "Subscriber for "+msg.ID+" already exists")
+				return
+			}
+
+			go h.runOperation(subCtx, c, msg.ID, payload)
+
+		case msgComplete:
+			c.mu.Lock()
+			if cancel, exists := c.subs[msg.ID]; exists {
+				cancel()
+				delete(c.subs, msg.ID)
+			}
+			c.mu.Unlock()
+
+		default:
+			c.close(closeBadRequest, "Unknown message type "+msg.Type)
+			return
+		}
+	}
+}
+
+// runOperation executes a subscribe request and streams its results as
+// next messages, followed by complete unless the client cancelled it
+func (h *wsHandler) runOperation(ctx context.Context, c *wsConnection, id string, payload subscribePayload) {
+	responses, err := h.schema.Subscribe(ctx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		errPayload, _ := json.Marshal([]map[string]string{{"message": err.Error()}})
+		c.write(wsMessage{ID: id, Type: msgError, Payload: errPayload})
+		h.finishOperation(c, id)
+		return
+	}
+
+	for response := range responses {
+		// Requests that fail before execution (e.g. validation) get a single error message
+		if resp, ok := response.(*graphql.Response); ok && resp.Data == nil && len(resp.Errors) > 0 {
+			errPayload, _ := json.Marshal(resp.Errors)
+			c.write(wsMessage{ID: id, Type: msgError, Payload: errPayload})
+			h.finishOperation(c, id)
+			return
+		}
+
+		data, err := json.Marshal(response)
+		if err != nil {
+			continue
+		}
+		if err := c.write(wsMessage{ID: id, Type: msgNext, Payload: data}); err != nil {
+			return
+		}
+	}
+
+	// The stream also ends when the client sent complete; don't echo it back then
+	if ctx.Err() == nil {
+		c.write(wsMessage{ID: id, Type: msgComplete})
+	}
+	h.finishOperation(c, id)
+}
+
+// finishOperation forgets a finished operation
+func (h *wsHandler) finishOperation(c *wsConnection, id string) {
+	c.mu.Lock()
+	if cancel, exists := c.subs[id]; exists {
+		cancel()
+		delete(c.subs, id)
+	}
+	c.mu.Unlock()
+}
+
+// CloseAll sends a close frame to every connected client and disconnects it
+func (h *wsHandler) CloseAll() {
+	h.mu.Lock()
+	conns := make([]*wsConnection, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range conns {
+		c.close(websocket.CloseGoingAway, "Server shutting down")
+	}
+}
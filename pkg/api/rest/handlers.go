@@ -2,30 +2,54 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"gat/pkg/config"
+	"gat/pkg/git"
 	"gat/pkg/platform"
+	"gat/pkg/ssh"
+	"gat/pkg/utils"
 	"net/http"
+	"strings"
+	"sync"
 )
 
+// maxProfileRequestBytes bounds the size of a POST /profiles body
+const maxProfileRequestBytes = 1 << 20
+
+// profilesMu serializes profile changes, which rewrite the config file
+var profilesMu sync.Mutex
+
 // Handler contains all REST API handlers
 type Handler struct {
 	configManager *config.Manager
 	platformReg   *platform.Registry
+	gitManager    *git.Manager
+	verifyLimiter *rateLimiter
+	apiToken      string // The server's API key, required by /config/settings
 }
 
-// NewHandler creates a new REST API handler
-func NewHandler(configManager *config.Manager, platformReg *platform.Registry) *Handler {
+// NewHandler creates a new REST API handler. apiToken is the server's API
+// key; /config/settings is disabled without one.
+func NewHandler(configManager *config.Manager, platformReg *platform.Registry, gitManager *git.Manager, apiToken string) *Handler {
 	return &Handler{
 		configManager: configManager,
 		platformReg:   platformReg,
+		gitManager:    gitManager,
+		verifyLimiter: newRateLimiter(verifyRateLimit, verifyRateWindow),
+		apiToken:      apiToken,
 	}
 }
 
-// RegisterRoutes registers all REST API routes with the provided ServeMux
+// RegisterRoutes registers all REST API routes with the provided ServeMux,
+// wrapped in LoggingMiddleware
 func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("/profiles", h.handleProfiles)
-	mux.HandleFunc("/platforms", h.handlePlatforms)
-	mux.HandleFunc("/doctor", h.handleDoctor)
+	mux.Handle("/profiles", LoggingMiddleware(http.HandlerFunc(h.handleProfiles)))
+	mux.Handle("/profiles/", LoggingMiddleware(http.HandlerFunc(h.handleProfileRoutes)))
+	mux.Handle("/platforms", LoggingMiddleware(http.HandlerFunc(h.handlePlatforms)))
+	mux.Handle("/doctor", LoggingMiddleware(http.HandlerFunc(h.handleDoctor)))
+	mux.Handle("/switch", LoggingMiddleware(LocalhostOnly(SameOriginJSON(http.HandlerFunc(h.handleSwitch)))))
+	mux.Handle("/config/settings", LoggingMiddleware(BearerAuth(h.apiToken, http.HandlerFunc(h.handleSettings))))
 }
 
 // ProfileResponse is the JSON response for profile requests
@@ -45,6 +69,40 @@ type Profile struct {
 	HasToken    bool   `json:"hasToken"`
 	SSHIdentity string `json:"sshIdentity,omitempty"`
 	IsActive    bool   `json:"isActive"`
+	Description string `json:"description,omitempty"`
+}
+
+// SingleProfileResponse is the JSON response for requests about one profile
+type SingleProfileResponse struct {
+	Profile *Profile `json:"profile,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// CreateProfileRequest is the JSON body of a POST /profiles request, with
+// the fields of 'gat add'
+type CreateProfileRequest struct {
+	Name        string `json:"name"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	Platform    string `json:"platform"`
+	Host        string `json:"host"`
+	Token       string `json:"token"`
+	SSHIdentity string `json:"ssh_identity"`
+	AuthMethod  string `json:"auth_method"`
+	SetupSSH    bool   `json:"setup_ssh"`
+}
+
+// UpdateProfileRequest is the JSON body of a PUT /profiles/{name} request.
+// Omitted fields are left unchanged, like the flags of 'gat add --overwrite'.
+type UpdateProfileRequest struct {
+	Username    *string `json:"username"`
+	Email       *string `json:"email"`
+	Platform    *string `json:"platform"`
+	Host        *string `json:"host"`
+	Token       *string `json:"token"`
+	SSHIdentity *string `json:"ssh_identity"`
+	AuthMethod  *string `json:"auth_method"`
+	SetupSSH    bool    `json:"setup_ssh"`
 }
 
 // PlatformResponse is the JSON response for platform requests
@@ -65,37 +123,70 @@ type Platform struct {
 	IsCustom       bool   `json:"isCustom"`
 }
 
-// handleProfiles handles GET requests for profiles
+// handleProfiles handles GET requests listing profiles and POST requests
+// creating one. Creating is only allowed from localhost, like switching.
 func (h *Handler) handleProfiles(w http.ResponseWriter, r *http.Request) {
-	// Only handle GET requests
-	if r.Method != http.MethodGet {
+	switch r.Method {
+	case http.MethodGet:
+		h.listProfiles(w, r)
+	case http.MethodPost:
+		LocalhostOnly(SameOriginJSON(http.HandlerFunc(h.createProfile))).ServeHTTP(w, r)
+	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
 	}
+}
 
+// handleProfileRoutes handles requests below /profiles/: GET, PUT and
+// DELETE /profiles/{name}, and POST /profiles/{name}/verify. Changing and
+// removing profiles is only allowed from localhost.
+func (h *Handler) handleProfileRoutes(w http.ResponseWriter, r *http.Request) {
+	name, action, found := strings.Cut(strings.TrimPrefix(r.URL.Path, "/profiles/"), "/")
+	switch {
+	case name == "":
+		http.NotFound(w, r)
+	case !found:
+		switch r.Method {
+		case http.MethodGet:
+			h.getProfile(w, r, name)
+		case http.MethodPut:
+			LocalhostOnly(SameOriginJSON(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				h.updateProfile(w, r, name)
+			}))).ServeHTTP(w, r)
+		case http.MethodDelete:
+			LocalhostOnly(SameOriginJSON(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				h.deleteProfile(w, r, name)
+			}))).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	case action == "verify":
+		h.handleVerifyProfile(w, r, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// listProfiles lists the profiles in the user's display order
+func (h *Handler) listProfiles(w http.ResponseWriter, r *http.Request) {
 	// Get profiles from config
 	profilesMap, _, err := h.configManager.GetProfiles()
 	if err != nil {
-		writeJSON(w, ProfileResponse{Error: err.Error()}, http.StatusInternalServerError)
+		writeJSON(w, ProfileResponse{Error: err.Error()}, statusForError(err))
 		return
 	}
 
-	// Convert to response format
+	names, err := h.configManager.ListProfileNames()
+	if err != nil {
+		writeJSON(w, ProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	// Convert to response format, in the user's display order
 	var profiles []Profile
 	currentName := h.configManager.GetCurrent()
 
-	for name, profile := range profilesMap {
-		isActive := name == currentName
-		profiles = append(profiles, Profile{
-			Name:        name,
-			Username:    profile.Username,
-			Email:       profile.Email,
-			Platform:    profile.Platform,
-			Host:        profile.Host,
-			HasToken:    profile.Token != "",
-			SSHIdentity: profile.SSHIdentity,
-			IsActive:    isActive,
-		})
+	for _, name := range names {
+		profiles = append(profiles, newProfile(name, profilesMap[name], name == currentName))
 	}
 
 	// Send response
@@ -105,6 +196,232 @@ func (h *Handler) handleProfiles(w http.ResponseWriter, r *http.Request) {
 	}, http.StatusOK)
 }
 
+// getProfile returns a single profile, by name or alias
+func (h *Handler) getProfile(w http.ResponseWriter, r *http.Request, name string) {
+	profile, resolved, err := h.configManager.GetProfile(name)
+	if err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	result := newProfile(resolved, profile, resolved == h.configManager.GetCurrent())
+	writeJSON(w, SingleProfileResponse{Profile: &result}, http.StatusOK)
+}
+
+// createProfile adds the profile of a CreateProfileRequest, validated as by
+// 'gat add', and answers 201 with it. It must only be reached behind
+// LocalhostOnly and SameOriginJSON.
+func (h *Handler) createProfile(w http.ResponseWriter, r *http.Request) {
+	var req CreateProfileRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProfileRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: fmt.Sprintf("invalid request body: %v", err)}, http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.profileFromRequest(req)
+	if err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	// Reload so a profile added by the CLI since the last request is kept
+	if _, _, err := h.configManager.GetProfiles(); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	if err := h.gitManager.AddProfile(req.Name, profile, req.SetupSSH, false); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	result := newProfile(req.Name, profile, req.Name == h.configManager.GetCurrent())
+	writeJSON(w, SingleProfileResponse{Profile: &result}, http.StatusCreated)
+}
+
+// updateProfile applies an UpdateProfileRequest to a profile, with the
+// checks of 'gat add --overwrite', and answers with the updated profile. It
+// must only be reached behind LocalhostOnly and SameOriginJSON.
+func (h *Handler) updateProfile(w http.ResponseWriter, r *http.Request, name string) {
+	if err := config.ValidateProfileName(name); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	var req UpdateProfileRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProfileRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: fmt.Sprintf("invalid request body: %v", err)}, http.StatusBadRequest)
+		return
+	}
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	profile, resolved, err := h.configManager.GetProfile(name)
+	if err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	if err := h.applyProfileUpdate(&profile, req); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	if err := h.gitManager.AddProfile(resolved, profile, req.SetupSSH, true); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	result := newProfile(resolved, profile, resolved == h.configManager.GetCurrent())
+	writeJSON(w, SingleProfileResponse{Profile: &result}, http.StatusOK)
+}
+
+// deleteProfile removes a profile, keeping a backup, and answers 204. It
+// must only be reached behind LocalhostOnly and SameOriginJSON (which checks
+// only the Origin of a DELETE, as it has no body).
+func (h *Handler) deleteProfile(w http.ResponseWriter, r *http.Request, name string) {
+	if err := config.ValidateProfileName(name); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+
+	// Reload so the removal does not undo changes made by the CLI
+	_, resolved, err := h.configManager.GetProfile(name)
+	if err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	if err := h.configManager.RemoveProfile(resolved, false); err != nil {
+		writeJSON(w, SingleProfileResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyProfileUpdate changes the fields of profile given in req, like 'gat
+// add --overwrite': a new SSH identity switches the auth method to ssh and a
+// new token to https unless both are given or auth_method is, and a new
+// token forgets the expiry of the old one
+func (h *Handler) applyProfileUpdate(profile *config.Profile, req UpdateProfileRequest) error {
+	if req.Username != nil {
+		profile.Username = *req.Username
+	}
+	if req.Email != nil {
+		profile.Email = *req.Email
+	}
+	if req.Platform != nil {
+		platformID := strings.ToLower(*req.Platform)
+		if _, err := h.platformReg.GetPlatform(platformID); err != nil {
+			return utils.Errorf(config.ErrInvalidProfile, "❌ invalid platform ID '%s': %w", platformID, err)
+		}
+		profile.Platform = platformID
+	}
+	if req.Host != nil {
+		profile.Host = *req.Host
+	}
+	if req.SSHIdentity != nil {
+		profile.SetSSHIdentity(ssh.CollapseIdentityPath(*req.SSHIdentity))
+	}
+
+	switch {
+	case req.AuthMethod != nil:
+		profile.AuthMethod = strings.ToLower(*req.AuthMethod)
+	case req.SSHIdentity != nil && req.Token == nil:
+		profile.AuthMethod = "ssh"
+	case req.Token != nil && req.SSHIdentity == nil:
+		profile.AuthMethod = "https"
+	}
+
+	if req.Token != nil {
+		if *req.Token != "" {
+			plat, err := h.platformReg.GetPlatform(profile.GetPlatform())
+			if err == nil {
+				if err := plat.ValidateToken(*req.Token); err != nil {
+					return utils.Errorf(config.ErrInvalidProfile, "%w", err)
+				}
+			}
+		}
+		// SaveConfig applies the configured token storage policy
+		profile.SetToken(*req.Token, false, "")
+		profile.SetTokenExpiry(nil)
+	}
+	return nil
+}
+
+// profileFromRequest builds the profile of a CreateProfileRequest with the
+// defaults and checks of 'gat add': the platform must be registered, the
+// auth method defaults to the default-auth-method setting or else to ssh
+// when an SSH identity is given, and the token must match the platform's
+// format
+func (h *Handler) profileFromRequest(req CreateProfileRequest) (config.Profile, error) {
+	if err := config.ValidateProfileName(req.Name); err != nil {
+		return config.Profile{}, err
+	}
+	if req.Username == "" || req.Email == "" {
+		return config.Profile{}, utils.Errorf(config.ErrInvalidProfile, "❌ username and email are required")
+	}
+
+	platformID := strings.ToLower(req.Platform)
+	if platformID == "" {
+		platformID = "github"
+	}
+	plat, err := h.platformReg.GetPlatform(platformID)
+	if err != nil {
+		return config.Profile{}, utils.Errorf(config.ErrInvalidProfile, "❌ invalid platform ID '%s': %w", platformID, err)
+	}
+
+	profile := config.Profile{
+		Username:   req.Username,
+		Email:      req.Email,
+		Platform:   platformID,
+		Host:       req.Host,
+		AuthMethod: strings.ToLower(req.AuthMethod),
+	}
+	if req.SSHIdentity != "" {
+		profile.SetSSHIdentity(ssh.CollapseIdentityPath(req.SSHIdentity))
+	}
+	if profile.AuthMethod == "" {
+		switch {
+		case h.configManager.DefaultAuthMethod() != "":
+			profile.AuthMethod = h.configManager.DefaultAuthMethod()
+		case req.SSHIdentity != "":
+			profile.AuthMethod = "ssh"
+		default:
+			profile.AuthMethod = "https"
+		}
+	}
+	if req.Token != "" {
+		if err := plat.ValidateToken(req.Token); err != nil {
+			return config.Profile{}, utils.Errorf(config.ErrInvalidProfile, "%w", err)
+		}
+		// SaveConfig applies the configured token storage policy
+		profile.SetToken(req.Token, false, "")
+	}
+	return profile, nil
+}
+
+// newProfile returns the JSON representation of a profile
+func newProfile(name string, profile config.Profile, isActive bool) Profile {
+	return Profile{
+		Name:        name,
+		Username:    profile.Username,
+		Email:       profile.Email,
+		Platform:    profile.Platform,
+		Host:        profile.Host,
+		HasToken:    profile.Token != "",
+		SSHIdentity: profile.SSHIdentity(),
+		IsActive:    isActive,
+		Description: profile.Description,
+	}
+}
+
 // handlePlatforms handles GET requests for platforms
 func (h *Handler) handlePlatforms(w http.ResponseWriter, r *http.Request) {
 	// Only handle GET requests
@@ -173,6 +490,23 @@ func (h *Handler) handleDoctor(w http.ResponseWriter, r *http.Request) {
 	}, http.StatusOK)
 }
 
+// statusForError maps well-known error kinds to HTTP status codes,
+// falling back to 500 for anything unexpected
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, config.ErrProfileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, config.ErrProfileExists):
+		return http.StatusConflict
+	case errors.Is(err, config.ErrInvalidProfile), errors.Is(err, config.ErrInvalidAuthMethod),
+		errors.Is(err, git.ErrInvalidUsername), errors.Is(err, git.ErrInvalidEmail):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, git.ErrNotInRepo), errors.Is(err, git.ErrNoRemote):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
 // writeJSON writes a JSON response with the given status code
 func writeJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
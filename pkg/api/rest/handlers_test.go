@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"encoding/json"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"gat/pkg/platform"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestHandler returns the routes of a Handler backed by a config file in a
+// temporary home directory holding the profiles "work" and "personal"
+func newTestHandler(t *testing.T) http.Handler {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GAT_CONFIG_FILE", filepath.Join(home, ".gat", "creds.json"))
+
+	cfg := config.Config{
+		Current: "work",
+		Profiles: map[string]config.Profile{
+			"work":     {Username: "workuser", Email: "work@example.com", Platform: "github", AuthMethod: "https"},
+			"personal": {Username: "me", Email: "me@example.com", Platform: "github", AuthMethod: "https"},
+		},
+	}
+	if err := config.SaveConfig(&cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	configDir, err := config.ConfigPath()
+	if err != nil {
+		t.Fatalf("ConfigPath: %v", err)
+	}
+	configManager := config.NewManager(configDir)
+	platformReg := platform.NewRegistry()
+	mux := http.NewServeMux()
+	NewHandler(configManager, platformReg, git.NewManager(configManager, platformReg), "").RegisterRoutes(mux)
+	return mux
+}
+
+// serve sends a request from localhost, with a JSON body if body is not
+// empty, and returns the recorded response
+func serve(handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = "127.0.0.1:1234"
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUpdateProfile(t *testing.T) {
+	handler := newTestHandler(t)
+
+	rec := serve(handler, http.MethodPut, "/profiles/personal", `{"email": "new@example.com"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT /profiles/personal = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var resp SingleProfileResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if resp.Profile == nil || resp.Profile.Email != "new@example.com" || resp.Profile.Username != "me" {
+		t.Errorf("PUT /profiles/personal returned %+v, want the new email and the old username", resp.Profile)
+	}
+
+	cfg, _, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := cfg.Profiles["personal"].Email; got != "new@example.com" {
+		t.Errorf("saved email = %q, want %q", got, "new@example.com")
+	}
+}
+
+func TestUpdateProfileErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		contentType string
+		want        int
+	}{
+		{"missing profile", "/profiles/nobody", `{"email": "new@example.com"}`, "application/json", http.StatusNotFound},
+		{"malformed body", "/profiles/personal", `{"email": `, "application/json", http.StatusBadRequest},
+		{"unknown field", "/profiles/personal", `{"nickname": "me"}`, "application/json", http.StatusBadRequest},
+		{"invalid name", "/profiles/bad$name", `{"email": "new@example.com"}`, "application/json", http.StatusUnprocessableEntity},
+		{"not JSON", "/profiles/personal", `{"email": "new@example.com"}`, "text/plain", http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(t)
+			req := httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			req.RemoteAddr = "127.0.0.1:1234"
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("PUT %s = %d, want %d: %s", tt.path, rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestDeleteProfile(t *testing.T) {
+	handler := newTestHandler(t)
+
+	rec := serve(handler, http.MethodDelete, "/profiles/personal", "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE /profiles/personal = %d, want %d: %s", rec.Code, http.StatusNoContent, rec.Body)
+	}
+	cfg, _, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if _, exists := cfg.Profiles["personal"]; exists {
+		t.Error("profile 'personal' still exists after DELETE")
+	}
+
+	if rec := serve(handler, http.MethodDelete, "/profiles/personal", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("second DELETE /profiles/personal = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeleteProfileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		remote string
+		origin string
+		want   int
+	}{
+		{"missing profile", "/profiles/nobody", "127.0.0.1:1234", "", http.StatusNotFound},
+		{"invalid name", "/profiles/bad$name", "127.0.0.1:1234", "", http.StatusUnprocessableEntity},
+		{"remote client", "/profiles/personal", "192.0.2.1:1234", "", http.StatusForbidden},
+		{"cross-site origin", "/profiles/personal", "127.0.0.1:1234", "https://evil.example", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestHandler(t)
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			req.RemoteAddr = tt.remote
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("DELETE %s = %d, want %d: %s", tt.path, rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// APITokenEnvVar holds the server's API key when no --api-key is given (see
+// BearerAuth)
+const APITokenEnvVar = "GAT_API_TOKEN"
+
+// accessLogger receives one structured line per logged request
+var accessLogger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+// quietPaths are excluded from access logging to reduce noise
+var quietPaths = map[string]bool{
+	"/ping":    true,
+	"/metrics": true,
+}
+
+// SetAccessLogOutput redirects access logs to the given writer (stderr by default)
+func SetAccessLogOutput(w io.Writer) {
+	accessLogger = slog.New(slog.NewTextHandler(w, nil))
+}
+
+// responseRecorder wraps an http.ResponseWriter to capture the status code
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before passing it on
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Hijack lets WebSocket upgrades pass through the recorder
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// LoggingMiddleware logs the method, path, status code and latency of each request
+func LoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if quietPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		accessLogger.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"latency", time.Since(start),
+		)
+	})
+}
+
+// LocalhostOnly rejects requests that don't come from a loopback address
+// with 403. It is meant for endpoints that change system-wide state, and
+// deliberately ignores X-Forwarded-For and similar headers.
+func LocalhostOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			http.Error(w, "Forbidden: only available from localhost", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SameOriginJSON guards endpoints that change state against cross-site
+// requests from web pages (CSRF): it rejects requests whose Origin header is
+// not the server's own with 403, and POST, PUT and PATCH requests whose body
+// is not application/json with 415, since browsers send those cross-origin
+// (as text/plain) without a preflight.
+func SameOriginJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			parsed, err := url.Parse(origin)
+			if err != nil || !strings.EqualFold(parsed.Host, r.Host) {
+				http.Error(w, "Forbidden: cross-origin requests are not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				http.Error(w, "Unsupported Media Type: the body must be application/json", http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// BearerAuth rejects requests without an "Authorization: Bearer <token>"
+// header matching token, the server's API key, with 401 and a JSON error.
+// With no key configured the endpoint is disabled and every request gets 403.
+func BearerAuth(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.Error(w, "Forbidden: start the server with an API key to enable this endpoint", http.StatusForbidden)
+			return
+		}
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="gat"`)
+			writeJSON(w, map[string]string{"error": "missing or invalid API key"}, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter allows at most limit events per key within a sliding window
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+// newRateLimiter creates a rate limiter allowing limit events per window
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow records an event for key and reports whether it is within the limit.
+// When it is not, it also returns how long until the next event is allowed.
+func (l *rateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-l.window)
+
+	// Drop events that have left the window
+	recent := l.hits[key][:0]
+	for _, hit := range l.hits[key] {
+		if hit.After(cutoff) {
+			recent = append(recent, hit)
+		}
+	}
+
+	if len(recent) >= l.limit {
+		l.hits[key] = recent
+		return false, recent[0].Sub(cutoff)
+	}
+
+	l.hits[key] = append(recent, now)
+	return true, 0
+}
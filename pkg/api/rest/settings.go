@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// maxSettingsRequestBytes bounds the size of a PUT /config/settings body
+const maxSettingsRequestBytes = 1 << 16
+
+// settingsMu serializes settings updates, which rewrite the config file
+var settingsMu sync.Mutex
+
+// Settings is the JSON representation of the global config settings (see
+// 'gat config list'). The salt and password check are never included.
+type Settings struct {
+	AutoSwitch            bool   `json:"auto_switch"`
+	StoreEncrypted        bool   `json:"store_encrypted"`
+	NoStoreTokens         bool   `json:"no_store_tokens"`
+	Compress              bool   `json:"compress"`
+	StrictEmailValidation bool   `json:"strict_email_validation"`
+	PreferLocalScope      bool   `json:"prefer_local_scope"`
+	MaxBackups            int    `json:"max_backups"`
+	DefaultAuthMethod     string `json:"default_auth_method"`
+}
+
+// SettingsRequest is the JSON body of a settings update; omitted fields are
+// left unchanged
+type SettingsRequest struct {
+	AutoSwitch            *bool   `json:"auto_switch"`
+	StoreEncrypted        *bool   `json:"store_encrypted"`
+	NoStoreTokens         *bool   `json:"no_store_tokens"`
+	Compress              *bool   `json:"compress"`
+	StrictEmailValidation *bool   `json:"strict_email_validation"`
+	PreferLocalScope      *bool   `json:"prefer_local_scope"`
+	MaxBackups            *int    `json:"max_backups"`
+	DefaultAuthMethod     *string `json:"default_auth_method"`
+}
+
+// SettingsResponse is the JSON response for settings requests
+type SettingsResponse struct {
+	Settings *Settings `json:"settings,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// handleSettings handles GET and PUT requests for the global settings. It
+// must only be registered behind BearerAuth.
+func (h *Handler) handleSettings(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		cfg, _, err := config.LoadConfig()
+		if err != nil {
+			writeJSON(w, SettingsResponse{Error: err.Error()}, statusForError(err))
+			return
+		}
+		writeJSON(w, SettingsResponse{Settings: settingsFromConfig(&cfg)}, http.StatusOK)
+	case http.MethodPut:
+		h.updateSettings(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// updateSettings applies a SettingsRequest with config.SetSetting, so values
+// are validated as by 'gat config set', and saves the config
+func (h *Handler) updateSettings(w http.ResponseWriter, r *http.Request) {
+	var req SettingsRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSettingsRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			err = fmt.Errorf("%s must be a %s", typeErr.Field, typeErr.Type)
+		}
+		writeJSON(w, SettingsResponse{Error: fmt.Sprintf("invalid request body: %v", err)}, http.StatusBadRequest)
+		return
+	}
+
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+
+	cfg, _, err := config.LoadConfig()
+	if err != nil {
+		writeJSON(w, SettingsResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	for _, change := range req.changes() {
+		if err := config.SetSetting(&cfg, change.key, change.value); err != nil {
+			writeJSON(w, SettingsResponse{Error: err.Error()}, http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	if err := config.SaveConfig(&cfg); err != nil {
+		writeJSON(w, SettingsResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	h.configManager.SetConfig(cfg)
+
+	writeJSON(w, SettingsResponse{Settings: settingsFromConfig(&cfg)}, http.StatusOK)
+}
+
+// settingChange is one setting to update, as a 'gat config set' key and value
+type settingChange struct {
+	key   string
+	value string
+}
+
+// changes lists the settings present in the request
+func (req SettingsRequest) changes() []settingChange {
+	var changes []settingChange
+	addBool := func(key string, value *bool) {
+		if value != nil {
+			changes = append(changes, settingChange{key, strconv.FormatBool(*value)})
+		}
+	}
+	addBool("auto-switch", req.AutoSwitch)
+	addBool("store-encrypted", req.StoreEncrypted)
+	addBool("no-store-tokens", req.NoStoreTokens)
+	addBool("compress", req.Compress)
+	addBool("strict-email-validation", req.StrictEmailValidation)
+	addBool("prefer-local-scope", req.PreferLocalScope)
+	if req.MaxBackups != nil {
+		changes = append(changes, settingChange{"max-backups", strconv.Itoa(*req.MaxBackups)})
+	}
+	if req.DefaultAuthMethod != nil {
+		changes = append(changes, settingChange{"default-auth-method", *req.DefaultAuthMethod})
+	}
+	return changes
+}
+
+// settingsFromConfig returns the settings of cfg
+func settingsFromConfig(cfg *config.Config) *Settings {
+	maxBackups := cfg.MaxBackups
+	if maxBackups < 1 {
+		maxBackups = 1
+	}
+	return &Settings{
+		AutoSwitch:            cfg.AutoSwitch,
+		StoreEncrypted:        cfg.StoreEncrypted,
+		NoStoreTokens:         cfg.NoStoreTokens,
+		Compress:              cfg.Compress,
+		StrictEmailValidation: cfg.StrictEmailValidation,
+		PreferLocalScope:      cfg.PreferLocalScope,
+		MaxBackups:            maxBackups,
+		DefaultAuthMethod:     cfg.DefaultAuthMethod,
+	}
+}
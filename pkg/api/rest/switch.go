@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/git"
+	"net/http"
+	"sync"
+)
+
+// maxSwitchRequestBytes bounds the size of a POST /switch body
+const maxSwitchRequestBytes = 1 << 20
+
+// switchMu serializes switches, which rewrite global Git and SSH config
+var switchMu sync.Mutex
+
+// SwitchRequest is the JSON body of a switch request
+type SwitchRequest struct {
+	Profile string `json:"profile"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+// SwitchResponse is the JSON response for switch requests
+type SwitchResponse struct {
+	Success            bool     `json:"success"`
+	Message            string   `json:"message,omitempty"`
+	GitIdentityChanged bool     `json:"git_identity_changed"`
+	RemoteURLChanged   bool     `json:"remote_url_changed"`
+	OldRemote          string   `json:"old_remote,omitempty"`
+	NewRemote          string   `json:"new_remote,omitempty"`
+	Warnings           []string `json:"warnings"`
+	Error              string   `json:"error,omitempty"`
+}
+
+// handleSwitch handles POST requests to switch the active profile. It runs
+// the same logic as 'gat switch' (git.Manager.SwitchProfile), so it must
+// only be registered behind LocalhostOnly and SameOriginJSON.
+func (h *Handler) handleSwitch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req SwitchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSwitchRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, SwitchResponse{Warnings: []string{}, Error: fmt.Sprintf("invalid request body: %v", err)}, http.StatusBadRequest)
+		return
+	}
+	if err := config.ValidateProfileName(req.Profile); err != nil {
+		writeJSON(w, SwitchResponse{Warnings: []string{}, Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	switchMu.Lock()
+	defer switchMu.Unlock()
+
+	// Record the state before switching so the response can report changes
+	oldName, _ := git.GetGitConfig("user.name")
+	oldEmail, _ := git.GetGitConfig("user.email")
+	var oldRemote string
+	if git.IsInGitRepo() {
+		oldRemote, _ = git.GetCurrentRemoteURL()
+	}
+
+	result, err := h.gitManager.SwitchProfile(req.Profile, false, req.DryRun)
+	if err != nil {
+		writeJSON(w, SwitchResponse{Warnings: []string{}, Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	response := SwitchResponse{
+		Success:   true,
+		OldRemote: oldRemote,
+		NewRemote: oldRemote,
+		Warnings:  []string{},
+	}
+	if profile, ok := result["profile"].(config.Profile); ok {
+		response.GitIdentityChanged = profile.Username != oldName || profile.Email != oldEmail
+	}
+	if req.DryRun {
+		response.Message = fmt.Sprintf("Dry run: would switch to profile '%s'", req.Profile)
+	} else {
+		response.Message = fmt.Sprintf("Switched to profile '%s'", req.Profile)
+		if oldRemote != "" {
+			response.NewRemote, _ = git.GetCurrentRemoteURL()
+			response.RemoteURLChanged = response.NewRemote != oldRemote
+		}
+	}
+	for _, key := range []string{"ssh_error", "remote_error"} {
+		if warning, ok := result[key].(string); ok {
+			response.Warnings = append(response.Warnings, warning)
+		}
+	}
+
+	writeJSON(w, response, http.StatusOK)
+}
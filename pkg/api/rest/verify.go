@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Token verification calls the platform API, so it is limited per profile
+const (
+	verifyRateLimit  = 5
+	verifyRateWindow = time.Minute
+)
+
+// VerifyResponse is the JSON response for profile verification requests
+type VerifyResponse struct {
+	Valid           bool   `json:"valid"`
+	AuthenticatedAs string `json:"authenticated_as,omitempty"`
+	Match           *bool  `json:"match,omitempty"` // Whether AuthenticatedAs is the profile's username
+	AvatarURL       string `json:"avatar_url,omitempty"`
+	Error           string `json:"error,omitempty"`
+
+	// Token scopes, only when the platform reports them (see platform.TokenInfo)
+	Scopes        []string `json:"scopes,omitempty"`
+	MissingScopes []string `json:"missing_scopes,omitempty"` // Required platform OAuthScopes the token lacks
+}
+
+// handleVerifyProfile checks a profile's token against the platform API
+// without changing anything
+func (h *Handler) handleVerifyProfile(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := config.ValidateProfileName(name); err != nil {
+		writeJSON(w, VerifyResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+
+	if allowed, retryAfter := h.verifyLimiter.Allow(name); !allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		writeJSON(w, VerifyResponse{Error: fmt.Sprintf("rate limit exceeded: at most %d verifications per minute per profile", verifyRateLimit)}, http.StatusTooManyRequests)
+		return
+	}
+
+	profiles, _, err := h.configManager.GetProfiles()
+	if err != nil {
+		writeJSON(w, VerifyResponse{Error: err.Error()}, statusForError(err))
+		return
+	}
+	profile, exists := profiles[name]
+	if !exists {
+		writeJSON(w, VerifyResponse{Error: fmt.Sprintf("profile '%s' does not exist", name)}, http.StatusNotFound)
+		return
+	}
+
+	token := profile.GetToken()
+	if token == "" {
+		writeJSON(w, VerifyResponse{Error: "profile has no token configured"}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	plat, err := h.platformReg.GetPlatform(profile.GetPlatform())
+	if err != nil {
+		writeJSON(w, VerifyResponse{Error: err.Error()}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	info, err := plat.VerifyToken(r.Context(), profile.Host, token)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, platform.ErrTokenRejected) {
+			status = http.StatusOK // The check worked; the token is invalid
+		}
+		writeJSON(w, VerifyResponse{Error: err.Error()}, status)
+		return
+	}
+
+	match := strings.EqualFold(info.Username, profile.Username)
+	response := VerifyResponse{
+		Valid:           true,
+		AuthenticatedAs: info.Username,
+		Match:           &match,
+		AvatarURL:       info.AvatarURL,
+		Scopes:          info.Scopes,
+	}
+	if info.Scopes != nil {
+		response.MissingScopes = plat.MissingScopes(info.Scopes)
+	}
+	writeJSON(w, response, http.StatusOK)
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// APIKeyFileName is the file in the config directory holding the API key
+const APIKeyFileName = "api_key"
+
+// apiKeyBytes is the number of random bytes in a generated API key
+const apiKeyBytes = 32
+
+// APIKeyFilePath returns the path of the API key file in configDir
+func APIKeyFilePath(configDir string) string {
+	return filepath.Join(configDir, APIKeyFileName)
+}
+
+// GenerateAPIKey returns a random API key of 32 bytes, hex-encoded
+func GenerateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("could not generate API key: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// SaveAPIKey writes key to the API key file in configDir, readable only by
+// its owner
+func SaveAPIKey(configDir, key string) error {
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+	path := APIKeyFilePath(configDir)
+	if err := os.WriteFile(path, []byte(key+"\n"), 0600); err != nil {
+		return fmt.Errorf("could not write API key: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("could not restrict API key permissions: %w", err)
+	}
+	return nil
+}
+
+// LoadAPIKey reads the API key saved in configDir. It returns "" if none was
+// saved.
+func LoadAPIKey(configDir string) (string, error) {
+	data, err := os.ReadFile(APIKeyFilePath(configDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read API key: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	// PIDFileName is the file in the config directory holding the daemon's PID
+	PIDFileName = "gat.pid"
+	// LogFileName is the file in the config directory receiving the daemon's output
+	LogFileName = "server.log"
+	// DaemonEnvVar is set in the environment of a server started with --daemon
+	DaemonEnvVar = "GAT_SERVE_DAEMON"
+)
+
+// DaemonInfo describes a server running in the background
+type DaemonInfo struct {
+	PID  int
+	Addr string // host:port the server listens on
+	TLS  bool   // Whether the server serves HTTPS
+}
+
+// URL returns the base URL of the daemon
+func (i DaemonInfo) URL() string {
+	if i.TLS {
+		return "https://" + i.Addr
+	}
+	return "http://" + i.Addr
+}
+
+// PIDFilePath returns the path of the PID file in configDir
+func PIDFilePath(configDir string) string {
+	return filepath.Join(configDir, PIDFileName)
+}
+
+// LogFilePath returns the path of the daemon log file in configDir
+func LogFilePath(configDir string) string {
+	return filepath.Join(configDir, LogFileName)
+}
+
+// WritePIDFile records a daemon in configDir. The file holds the PID on its
+// first line, the server address on its second and "https" on a third if the
+// server serves HTTPS.
+func WritePIDFile(configDir string, info DaemonInfo) error {
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+	data := fmt.Sprintf("%d\n%s\n", info.PID, info.Addr)
+	if info.TLS {
+		data += "https\n"
+	}
+	if err := os.WriteFile(PIDFilePath(configDir), []byte(data), 0600); err != nil {
+		return fmt.Errorf("could not write PID file: %w", err)
+	}
+	return nil
+}
+
+// ReadPIDFile reads the daemon recorded in configDir. The error wraps
+// os.ErrNotExist when no daemon was started.
+func ReadPIDFile(configDir string) (DaemonInfo, error) {
+	data, err := os.ReadFile(PIDFilePath(configDir))
+	if err != nil {
+		return DaemonInfo{}, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	pid, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil || pid <= 0 {
+		return DaemonInfo{}, fmt.Errorf("invalid PID file %s", PIDFilePath(configDir))
+	}
+	info := DaemonInfo{PID: pid}
+	if len(lines) > 1 {
+		info.Addr = strings.TrimSpace(lines[1])
+	}
+	if len(lines) > 2 {
+		info.TLS = strings.TrimSpace(lines[2]) == "https"
+	}
+	return info, nil
+}
+
+// RemovePIDFile deletes the PID file in configDir. A missing file is not an error.
+func RemovePIDFile(configDir string) error {
+	if err := os.Remove(PIDFilePath(configDir)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove PID file: %w", err)
+	}
+	return nil
+}
+
+// DaemonStatus returns the daemon recorded in configDir and whether its
+// process is still running. A missing PID file yields running == false.
+func DaemonStatus(configDir string) (DaemonInfo, bool, error) {
+	info, err := ReadPIDFile(configDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return DaemonInfo{}, false, nil
+	}
+	if err != nil {
+		return DaemonInfo{}, false, err
+	}
+	return info, ProcessRunning(info.PID), nil
+}
+
+// ProcessRunning reports whether a process with the given PID exists
+func ProcessRunning(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// On Windows FindProcess already fails for processes that have exited
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// StopDaemon asks the daemon recorded in configDir to shut down (SIGTERM, or
+// a kill on Windows) and removes the PID file. It reports whether the process
+// was still running, as opposed to a stale PID file.
+func StopDaemon(configDir string) (DaemonInfo, bool, error) {
+	info, running, err := DaemonStatus(configDir)
+	if err != nil {
+		return info, false, err
+	}
+	if info.PID == 0 {
+		return info, false, fmt.Errorf("no server is running in the background")
+	}
+	if running {
+		proc, err := os.FindProcess(info.PID)
+		if err != nil {
+			return info, true, fmt.Errorf("could not find process %d: %w", info.PID, err)
+		}
+		if runtime.GOOS == "windows" {
+			err = proc.Kill()
+		} else {
+			err = proc.Signal(syscall.SIGTERM)
+		}
+		if err != nil {
+			return info, true, fmt.Errorf("could not stop process %d: %w", info.PID, err)
+		}
+	}
+	return info, running, RemovePIDFile(configDir)
+}
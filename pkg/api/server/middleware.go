@@ -0,0 +1,19 @@
+package server
+
+import (
+	"gat/pkg/api/rest"
+	"net/http"
+)
+
+// APIKeyAuth requires the API key of rest.BearerAuth on every request but
+// /ping, which stays open for health checks
+func APIKeyAuth(key string, next http.Handler) http.Handler {
+	auth := rest.BearerAuth(key, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		auth.ServeHTTP(w, r)
+	})
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,6 +12,18 @@ type Config struct {
 	Port      int
 	Host      string
 	ConfigDir string
+
+	// TLSCert and TLSKey are the files of the certificate and private key to
+	// serve HTTPS with. TLSCertPEM and TLSKeyPEM give them in memory instead,
+	// e.g. from GenerateSelfSignedCert, and take precedence.
+	TLSCert    string
+	TLSKey     string
+	TLSCertPEM []byte
+	TLSKeyPEM  []byte
+
+	// APIKey, if set, is required as "Authorization: Bearer <key>" on every
+	// request but /ping
+	APIKey string
 }
 
 // Server represents the GAT API server
@@ -19,6 +32,7 @@ type Server struct {
 	mux     *http.ServeMux
 	server  *http.Server
 	running bool
+	onStop  []func()
 }
 
 // NewServer creates a new API server with the given configuration
@@ -32,13 +46,17 @@ func NewServer(config Config) *Server {
 	}
 
 	mux := http.NewServeMux()
+	var handler http.Handler = mux
+	if config.APIKey != "" {
+		handler = APIKeyAuth(config.APIKey, mux)
+	}
 
 	return &Server{
 		config: config,
 		mux:    mux,
 		server: &http.Server{
 			Addr:         fmt.Sprintf("%s:%d", config.Host, config.Port),
-			Handler:      mux,
+			Handler:      handler,
 			ReadTimeout:  15 * time.Second,
 			WriteTimeout: 15 * time.Second,
 			IdleTimeout:  60 * time.Second,
@@ -62,12 +80,37 @@ func (s *Server) RegisterHandlerFunc(path string, handler func(http.ResponseWrit
 	s.mux.HandleFunc(path, handler)
 }
 
-// Start starts the API server
+// OnStop registers a function to run when the server stops, before
+// connections are closed (e.g. to send WebSocket close frames)
+func (s *Server) OnStop(fn func()) {
+	s.onStop = append(s.onStop, fn)
+}
+
+// TLSEnabled reports whether the server serves HTTPS
+func (s *Server) TLSEnabled() bool {
+	return len(s.config.TLSCertPEM) > 0 || s.config.TLSCert != ""
+}
+
+// Start starts the API server, serving HTTPS if a certificate is configured
 func (s *Server) Start() error {
 	if s.running {
 		return fmt.Errorf("server is already running")
 	}
 
+	// Check the certificate now rather than failing in the background
+	switch {
+	case len(s.config.TLSCertPEM) > 0:
+		cert, err := tls.X509KeyPair(s.config.TLSCertPEM, s.config.TLSKeyPEM)
+		if err != nil {
+			return fmt.Errorf("invalid TLS certificate: %w", err)
+		}
+		s.server.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	case s.config.TLSCert != "":
+		if _, err := tls.LoadX509KeyPair(s.config.TLSCert, s.config.TLSKey); err != nil {
+			return fmt.Errorf("could not load TLS certificate: %w", err)
+		}
+	}
+
 	// Add health check endpoint
 	s.RegisterHandlerFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -75,13 +118,26 @@ func (s *Server) Start() error {
 	})
 
 	go func() {
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		switch {
+		case s.server.TLSConfig != nil:
+			err = s.server.ListenAndServeTLS("", "")
+		case s.config.TLSCert != "":
+			err = s.server.ListenAndServeTLS(s.config.TLSCert, s.config.TLSKey)
+		default:
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			fmt.Printf("Error starting server: %v\n", err)
 		}
 	}()
 
 	s.running = true
-	fmt.Printf("GAT API server started on %s\n", s.server.Addr)
+	if s.TLSEnabled() {
+		fmt.Printf("GAT API server started on %s (HTTPS)\n", s.server.Addr)
+	} else {
+		fmt.Printf("GAT API server started on %s\n", s.server.Addr)
+	}
 	return nil
 }
 
@@ -92,5 +148,8 @@ func (s *Server) Stop() error {
 	}
 
 	s.running = false
+	for _, fn := range s.onStop {
+		fn()
+	}
 	return s.server.Close()
 }
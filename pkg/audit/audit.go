@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"gat/pkg/config"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// FileName is the name of the audit log, next to the credentials file
+const FileName = "audit.log"
+
+// Events recorded in the audit log
+const (
+	EventSwitch = "switch"
+	EventAdd    = "add"
+	EventRemove = "remove"
+)
+
+// Entry is one line of the audit log
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Event   string    `json:"event"`
+	Profile string    `json:"profile"`
+	User    string    `json:"user"`
+	Detail  string    `json:"detail,omitempty"`
+}
+
+// Logger appends profile operations to an audit log, one JSON object per line
+type Logger struct {
+	Path string // Path of the log file
+}
+
+// NewLogger returns a Logger for ~/.gat/audit.log, or audit.log in the
+// directory of GAT_CONFIG_FILE when it is set
+func NewLogger() (*Logger, error) {
+	configPath, err := config.ConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{Path: filepath.Join(filepath.Dir(configPath), FileName)}, nil
+}
+
+// CurrentUser returns the name of the user running gat, for Log
+func CurrentUser() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME") // Windows
+}
+
+// Log appends an entry for event on profileName by user to the log, creating
+// it readable by the owner only
+func (l *Logger) Log(event, profileName, user, detail string) error {
+	line, err := json.Marshal(Entry{
+		Time:    time.Now().UTC().Truncate(time.Second),
+		Event:   event,
+		Profile: profileName,
+		User:    user,
+		Detail:  detail,
+	})
+	if err != nil {
+		return fmt.Errorf("could not encode audit entry: %w", err)
+	}
+
+	file, err := os.OpenFile(l.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("could not write audit log: %w", err)
+	}
+	return nil
+}
+
+// Tail returns the last n entries of the log, oldest first (all of them if
+// n is 0 or less). Lines that are not entries are skipped, and a missing log
+// has no entries.
+func (l *Logger) Tail(n int) ([]Entry, error) {
+	file, err := os.Open(l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Event == "" {
+			continue
+		}
+		entries = append(entries, entry)
+		if n > 0 && len(entries) > n {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("❌ could not read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Clear empties the log. A missing log is not an error.
+func (l *Logger) Clear() error {
+	if err := os.Truncate(l.Path, 0); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("❌ could not clear audit log: %w", err)
+	}
+	return nil
+}
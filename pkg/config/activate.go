@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Shells supported by the profile activation scripts, keyed by the
+// extension of the script written to ~/.gat (activate.sh, activate.fish, ...)
+var ActivationShells = []string{"sh", "fish", "csh"}
+
+// ActivationScript returns the shell commands that apply a profile's
+// environment: GNUPGHOME is exported when the profile overrides it and
+// unset otherwise, so switching away from such a profile restores the default.
+func ActivationScript(profile Profile, shell string) (string, error) {
+	gnupgHome, err := profile.GnupgHomePath()
+	if err != nil {
+		return "", err
+	}
+
+	if gnupgHome != "" {
+		return ExportStatement(shell, "GNUPGHOME", gnupgHome) + "\n", nil
+	}
+	switch shell {
+	case "fish":
+		return "set -e GNUPGHOME\n", nil
+	case "csh":
+		return "unsetenv GNUPGHOME\n", nil
+	default:
+		return "unset GNUPGHOME\n", nil
+	}
+}
+
+// ExportStatement returns the command that sets an environment variable in
+// a shell: "sh" (or "bash", "zsh"), "fish", "csh" or "powershell". The value
+// is quoted so the shell takes it literally.
+func ExportStatement(shell, name, value string) string {
+	switch shell {
+	case "fish":
+		return fmt.Sprintf("set -gx %s %s", name, quoteFish(value))
+	case "csh":
+		return fmt.Sprintf("setenv %s %s", name, quotePOSIX(value))
+	case "powershell":
+		return fmt.Sprintf("$env:%s = %s", name, quotePowerShell(value))
+	default:
+		return fmt.Sprintf("export %s=%s", name, quotePOSIX(value))
+	}
+}
+
+// GnupgHomePath returns the profile's GNUPGHOME override with a leading ~
+// expanded, or "" if the profile uses the default keyring
+func (p *Profile) GnupgHomePath() (string, error) {
+	if p.GnupghomeOverride == "" {
+		return "", nil
+	}
+	path, err := utils.ExpandHome(p.GnupghomeOverride)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(path), nil
+}
+
+// WriteActivationScripts writes ~/.gat/activate.sh, activate.fish and
+// activate.csh for the given profile so they can be sourced by the shell
+func WriteActivationScripts(profile Profile) error {
+	configDir, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("❌ could not create config directory: %w", err)
+	}
+
+	for _, shell := range ActivationShells {
+		path := filepath.Join(configDir, "activate."+shell)
+		script, err := ActivationScript(profile, shell)
+		if err != nil {
+			return err
+		}
+		content := "# Generated by 'gat switch'; do not edit\n" + script
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			return fmt.Errorf("❌ could not write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// quotePOSIX single-quotes a value for sh and csh
+func quotePOSIX(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// quoteFish single-quotes a value for fish
+func quoteFish(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
+
+// quotePowerShell single-quotes a value for PowerShell
+func quotePowerShell(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
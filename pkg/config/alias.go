@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"sort"
+)
+
+// ResolveAlias returns the profile name an alias points to, following
+// aliases of aliases, or name unchanged if it is a profile or not an alias.
+// Profile names take precedence over aliases. A circular alias also resolves
+// to name; AddAlias refuses to create one.
+func ResolveAlias(cfg *Config, name string) string {
+	if _, isProfile := cfg.Profiles[name]; isProfile {
+		return name
+	}
+
+	seen := map[string]bool{}
+	current := name
+	for {
+		target, isAlias := cfg.Aliases[current]
+		if !isAlias {
+			return current
+		}
+		if seen[current] {
+			return name
+		}
+		seen[current] = true
+		current = target
+	}
+}
+
+// AddAlias makes alias an alternative name for target, which may itself be
+// an alias. The alias must be a valid profile name that is not already used
+// by a profile, and target must resolve to an existing profile without a cycle.
+func AddAlias(cfg *Config, alias, target string) error {
+	if err := ValidateProfileName(alias); err != nil {
+		return fmt.Errorf("❌ invalid alias: %w", err)
+	}
+	if _, exists := cfg.Profiles[alias]; exists {
+		return utils.Errorf(ErrProfileExists, "❌ '%s' is already a profile name and cannot be used as an alias", alias)
+	}
+	if alias == target {
+		return fmt.Errorf("❌ alias '%s' cannot point to itself", alias)
+	}
+
+	// Walk the chain from target; reaching alias again means a cycle
+	for current := target; ; {
+		if current == alias {
+			return fmt.Errorf("❌ alias '%s' -> '%s' would create a circular alias", alias, target)
+		}
+		next, isAlias := cfg.Aliases[current]
+		if !isAlias {
+			if _, exists := cfg.Profiles[current]; !exists {
+				return utils.Errorf(ErrProfileNotFound, "❌ profile '%s' does not exist", current)
+			}
+			break
+		}
+		current = next
+	}
+
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[alias] = target
+	return nil
+}
+
+// RemoveAlias deletes an alias
+func RemoveAlias(cfg *Config, alias string) error {
+	if _, exists := cfg.Aliases[alias]; !exists {
+		return fmt.Errorf("❌ alias '%s' does not exist", alias)
+	}
+	delete(cfg.Aliases, alias)
+	return nil
+}
+
+// AliasesFor returns the sorted aliases that resolve to the given profile
+func AliasesFor(cfg *Config, profileName string) []string {
+	var aliases []string
+	for alias := range cfg.Aliases {
+		if ResolveAlias(cfg, alias) == profileName && alias != profileName {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// rotatedBackupSuffix matches the ".backup.json" and ".backup.<n>.json"
+// suffixes written by BackupProfile
+var rotatedBackupSuffix = regexp.MustCompile(`^\.backup(\.[0-9]+)?\.json$`)
+
+// profileBackupName matches the file names written by BackupProfile,
+// capturing the profile name
+var profileBackupName = regexp.MustCompile(`^(.+?)\.backup(\.[0-9]+)?\.json$`)
+
+// ProfileBackup is a backup file written by BackupProfile
+type ProfileBackup struct {
+	Profile string    // Name of the profile it holds
+	Path    string    // Full path of the file
+	ModTime time.Time // When it was written
+}
+
+// BackupDir returns the path to the backup directory (~/.gat/backups)
+func BackupDir() (string, error) {
+	configDir, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "backups"), nil
+}
+
+// ProfileBackupFiles returns the paths of all backups of a profile, sorted
+func ProfileBackupFiles(name string) ([]string, error) {
+	backupDir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read backup directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.Type().IsRegular() && len(fileName) > len(name) && fileName[:len(name)] == name &&
+			rotatedBackupSuffix.MatchString(fileName[len(name):]) {
+			files = append(files, filepath.Join(backupDir, fileName))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ProfileSize estimates the disk space used by a profile: the size of its
+// JSON entry in creds.json plus all of its backup files
+func ProfileSize(config *Config, name string) (int64, error) {
+	profile, exists := config.Profiles[name]
+	if !exists {
+		return 0, fmt.Errorf("profile '%s' does not exist", name)
+	}
+
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return 0, fmt.Errorf("could not marshal profile: %w", err)
+	}
+	size := int64(len(data))
+
+	backups, err := ProfileBackupFiles(name)
+	if err != nil {
+		return 0, err
+	}
+	for _, path := range backups {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // Removed since listing
+		}
+		size += info.Size()
+	}
+
+	return size, nil
+}
+
+// PruneBackups removes files in the backup directory last modified more than
+// minAge ago and returns their paths. With dryRun, nothing is removed.
+func PruneBackups(minAge time.Duration, dryRun bool) ([]string, error) {
+	backupDir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read backup directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-minAge)
+	var pruned []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+
+		path := filepath.Join(backupDir, entry.Name())
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return pruned, fmt.Errorf("❌ could not remove %s: %w", path, err)
+			}
+		}
+		pruned = append(pruned, path)
+	}
+
+	return pruned, nil
+}
+
+// ListProfileBackups returns the backups of all profiles in the backup
+// directory, newest first
+func ListProfileBackups() ([]ProfileBackup, error) {
+	backupDir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read backup directory: %w", err)
+	}
+
+	var backups []ProfileBackup
+	for _, entry := range entries {
+		match := profileBackupName.FindStringSubmatch(entry.Name())
+		if !entry.Type().IsRegular() || match == nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since listing
+		}
+		backups = append(backups, ProfileBackup{
+			Profile: match[1],
+			Path:    filepath.Join(backupDir, entry.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].ModTime.After(backups[j].ModTime)
+	})
+	return backups, nil
+}
+
+// ReadProfileBackup reads a backup written by BackupProfile and returns the
+// name and profile it holds
+func ReadProfileBackup(path string) (string, Profile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", Profile{}, fmt.Errorf("❌ backup file not found: %s", path)
+	}
+	if err != nil {
+		return "", Profile{}, fmt.Errorf("❌ could not read backup file: %w", err)
+	}
+
+	var backup map[string]Profile
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return "", Profile{}, fmt.Errorf("❌ could not parse backup file %s: %w", path, err)
+	}
+	if len(backup) != 1 {
+		return "", Profile{}, fmt.Errorf("❌ backup file %s holds %d profiles; expected one", path, len(backup))
+	}
+	for name, profile := range backup {
+		return name, profile, nil
+	}
+	return "", Profile{}, nil // Unreachable
+}
+
+// RestoreProfile adds a profile read from a backup to config as name, the
+// same way as AddProfile. The backed-up token is kept when it can still be
+// read: an encrypted one with the config's key and a keychain one while the
+// keychain entry exists. Otherwise the profile is restored without a token
+// and a warning is printed.
+func RestoreProfile(config *Config, name string, profile Profile, overwrite bool) error {
+	if err := ValidateProfile(&profile); err != nil {
+		return err
+	}
+	profile.SetTokenExpiry(profile.TokenExpiry)
+
+	switch {
+	case strings.HasPrefix(profile.Token, "enc:"):
+		token, err := DecryptToken(profile.Token, config.EncryptionSecret())
+		if err != nil {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Could not decrypt the backed-up token of [%s] (%v); restoring it without a token\n"), name, err)
+			token = ""
+		}
+		profile.SetToken(token, config.StoreEncrypted, config.EncryptionSecret())
+	case profile.tokenInKeychain():
+		token, err := keychainStore.Get(strings.TrimPrefix(profile.Token, keychainTokenPrefix))
+		if err != nil {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: The token of [%s] is no longer in the keychain; restoring it without a token\n"), name)
+			token = ""
+		}
+		profile.SetToken(token, config.StoreEncrypted, config.EncryptionSecret())
+	}
+
+	return AddProfile(config, name, profile, overwrite)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// compressedPrefix marks a credentials file holding gzip-compressed JSON
+// rather than plain JSON
+const compressedPrefix = "enc:gz:"
+
+// CompressConfig returns the config as compact, gzip-compressed JSON prefixed
+// with "enc:gz:", the format SaveConfig writes when the compress setting is on
+func CompressConfig(cfg *Config) ([]byte, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not marshal config: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(compressedPrefix)
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("❌ could not compress config: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("❌ could not compress config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decodeConfigData returns the JSON content of a credentials file,
+// decompressing it first if it was written by CompressConfig
+func decodeConfigData(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(compressedPrefix)) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data[len(compressedPrefix):]))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress config: %w", err)
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress config: %w", err)
+	}
+	return decoded, nil
+}
@@ -1,21 +1,29 @@
 package config
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"gat/pkg/utils"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
+	"golang.org/x/crypto/argon2"
 )
 
 // Validate GitHub username format - moved from pkg/git
@@ -24,36 +32,125 @@ var ValidGitHubUsernameRegex = regexp.MustCompile(`^[a-zA-Z0-9]$|^[a-zA-Z0-9][a-
 // Validate Git email format
 var ValidEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
+// WarningOutput receives non-fatal warnings printed while loading and editing
+// profiles. Commands whose stdout is consumed by a shell can point it at stderr.
+var WarningOutput io.Writer = os.Stdout
+
 // Profile represents a Git identity with its associated credentials
 type Profile struct {
-	Username    string `json:"username"`
-	Email       string `json:"email"`
-	Token       string `json:"token,omitempty"` // Encrypted token when saved to file
-	SSHIdentity string `json:"ssh_identity,omitempty"`
-	Platform    string `json:"platform,omitempty"` // Platform ID (e.g., "github", "gitlab")
-	Host        string `json:"host,omitempty"`     // Custom hostname if different from platform default
-	AuthMethod  string `json:"auth_method"`        // Preferred authentication method ("ssh" or "https")
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	Token         string   `json:"token,omitempty"`          // Encrypted token when saved to file
+	SSHIdentities []string `json:"ssh_identities,omitempty"` // The first is the primary identity, see SSHIdentity
+	SSHCertPath   string   `json:"ssh_cert_path,omitempty"`  // CA-signed certificate for the primary identity (.cert.pub)
+	Platform      string   `json:"platform,omitempty"`       // Platform ID (e.g., "github", "gitlab")
+	Host          string   `json:"host,omitempty"`           // Custom hostname if different from platform default
+	AuthMethod    string   `json:"auth_method"`              // Preferred authentication method ("ssh" or "https")
+
+	// Single SSH identity of configs written before SSHIdentities, moved
+	// there by ValidateProfile
+	LegacySSHIdentity string `json:"ssh_identity,omitempty"`
+
+	// Glob pattern (e.g. "/home/user/work/*") for directories that should
+	// automatically use this profile via shell integration ('gat check-dir')
+	WorkingDirectory string `json:"working_directory,omitempty"`
+
+	// GNUPGHOME for this identity's GPG keyring, exported by the activation
+	// scripts written on 'gat switch' (see ActivationScript)
+	GnupghomeOverride string `json:"gnupghome_override,omitempty"`
+
+	// GPG key ID or fingerprint that 'gat switch' sets as user.signingkey,
+	// enabling commit.gpgsign (see ValidateGPGKey)
+	GPGKey string `json:"gpg_key,omitempty"`
+
+	// Credentials are cleared by 'gat session check' once the session started
+	// by 'gat switch' is older than this (0 = no timeout)
+	MaxSessionDuration time.Duration `json:"max_session_duration,omitempty"`
+
+	// When the profile was last added or overwritten by AddProfile, used by
+	// ImportProfiles' KeepNewer strategy (nil for older configs)
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+
+	// Avatar image of the platform account, recorded by 'gat verify'
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// Remotes besides origin (e.g. a self-hosted mirror) that 'gat switch'
+	// rewrites to the profile's auth method
+	MirrorRemotes []string `json:"mirror_remotes,omitempty"`
+
+	// Markdown documentation of the profile (repositories, rate limits,
+	// owners), up to MaxDescriptionLength characters
+	Description string `json:"description,omitempty"`
+
+	// Labels for filtering profiles, e.g. with 'gat list --tag' (see ValidateTag)
+	Tags []string `json:"tags,omitempty"`
+
+	// Date the token expires, for the warnings of 'gat doctor' (nil if unknown)
+	TokenExpiry *time.Time `json:"token_expiry,omitempty"`
 
 	// Internal fields not serialized to JSON
-	rawToken string `json:"-"` // Raw, decrypted token for in-memory use
+	rawToken        string `json:"-"` // Raw, decrypted token for in-memory use
+	tokenExpired    bool   `json:"-"` // Set by LoadConfig, see TokenExpired
+	tokenExpirySoon bool   `json:"-"` // Set by LoadConfig, see TokenExpirySoon
 }
 
 // Config represents the structure of the gat configuration file
 type Config struct {
 	Current  string             `json:"current"`
 	Profiles map[string]Profile `json:"profiles"`
+	Aliases  map[string]string  `json:"aliases,omitempty"` // Alias -> profile (or alias) name, see ResolveAlias
+
+	// Display order of profiles, see ListProfileNames and 'gat profile reorder'
+	ProfileOrdering []string `json:"profile_ordering,omitempty"`
+
+	// Reusable defaults for new profiles, see 'gat template'
+	Templates map[string]ProfileTemplate `json:"templates,omitempty"`
 
 	// Security settings
 	StoreEncrypted bool   `json:"store_encrypted"` // Whether to encrypt tokens
 	NoStoreTokens  bool   `json:"no_store_tokens"` // Whether to not store tokens at all
 	Salt           string `json:"salt,omitempty"`  // Salt for encryption
+
+	// Where tokens are kept: SecretBackendFile ("" too) or SecretBackendKeychain
+	SecretBackend string   `json:"secret_backend"`
+	keychainKeys  []string `json:"-"` // Keys of the tokens read from the keychain
+
+	// Password mode: the token key is derived from a user password and Salt
+	// with Argon2id instead of from Salt alone (see EnablePasswordEncryption)
+	UsePasswordEncryption bool   `json:"use_password_encryption,omitempty"`
+	PasswordCheck         string `json:"password_check,omitempty"` // Known value encrypted with the derived key
+	secret                string `json:"-"`                        // Derived key, once unlocked
+
+	// Behaviour settings (see 'gat config list')
+	AutoSwitch bool `json:"auto_switch"`           // Whether to detect the profile for the current directory automatically
+	MaxBackups int  `json:"max_backups,omitempty"` // Number of backups kept per profile (default 1)
+	Compress   bool `json:"compress,omitempty"`    // Whether to write the file as gzip-compressed JSON (see CompressConfig)
+
+	// Whether AddProfile rejects emails that fail the strict RFC 5321 check
+	// instead of only warning about them (see ValidateEmail)
+	StrictEmailValidation bool `json:"strict_email_validation,omitempty"`
+
+	// Whether 'gat switch' writes the Git identity to the repository's
+	// .git/config instead of ~/.gitconfig and leaves ~/.git-credentials alone
+	PreferLocalScope bool `json:"prefer_local_scope,omitempty"`
+
+	// Auth method 'gat add' uses when --auth-method is not given ("ssh",
+	// "https", or empty to infer it from --ssh-identity)
+	DefaultAuthMethod string `json:"default_auth_method,omitempty"`
+
+	// URLs notified of switches, additions and removals, see 'gat webhook'
+	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
 }
 
-// GetToken returns the decrypted token from a profile
+// GetToken returns the decrypted token from a profile. A password-protected
+// token reads as empty until UnlockProfileToken is called.
 func (p *Profile) GetToken() string {
 	if p.rawToken != "" {
 		return p.rawToken
 	}
+	if p.IsPasswordProtected() || p.tokenInKeychain() {
+		return ""
+	}
 	return p.Token
 }
 
@@ -67,6 +164,45 @@ func (p *Profile) SetToken(token string, encrypt bool, salt string) {
 	}
 }
 
+// TokenFingerprint returns a short, non-reversible identifier for a token
+// (first 8 hex characters of its SHA-256) so tokens can be told apart without
+// revealing them. Returns an empty string for an empty token.
+func TokenFingerprint(token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// SSHIdentity returns the primary SSH identity of the profile, or "" if it
+// has none
+func (p *Profile) SSHIdentity() string {
+	if len(p.SSHIdentities) == 0 {
+		return ""
+	}
+	return p.SSHIdentities[0]
+}
+
+// SetSSHIdentity makes path the only SSH identity of the profile ("" for none)
+func (p *Profile) SetSSHIdentity(path string) {
+	if path == "" {
+		p.SSHIdentities = nil
+	} else {
+		p.SSHIdentities = []string{path}
+	}
+}
+
+// SetPrimarySSHIdentity makes path the primary SSH identity of the profile in
+// place of the current one, keeping the others
+func (p *Profile) SetPrimarySSHIdentity(path string) {
+	others := slices.DeleteFunc(slices.Clone(p.SSHIdentities), func(identity string) bool { return identity == path })
+	if len(p.SSHIdentities) > 0 && p.SSHIdentities[0] != path {
+		others = others[1:]
+	}
+	p.SSHIdentities = append([]string{path}, others...)
+}
+
 // GetPlatform returns the platform for this profile, defaulting to "github" for backwards compatibility
 func (p *Profile) GetPlatform() string {
 	if p.Platform == "" {
@@ -85,11 +221,7 @@ func (p *Profile) GetHost() string {
 
 // ConfigPath returns the path to the configuration directory
 func ConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("❌ could not find home directory: %w", err)
-	}
-	return filepath.Join(homeDir, ".gat"), nil
+	return utils.ExpandHome("~/.gat")
 }
 
 // ConfigFilePath returns the path to the credentials file.
@@ -118,11 +250,28 @@ func ConfigFilePath() (string, error) {
 // and returns a Config containing only valid profiles, a map of validation
 // errors for invalid profiles, and any file I/O or parsing errors.
 func LoadConfig() (Config, map[string]error, error) {
+	return LoadConfigWithContext(context.Background())
+}
+
+// LoadConfigWithContext is LoadConfig bounded by ctx. The deadline is checked
+// on entry and after each step, and the file read itself is abandoned if ctx
+// expires first (e.g. on a hung network filesystem).
+func LoadConfigWithContext(ctx context.Context) (Config, map[string]error, error) {
+	if err := ctx.Err(); err != nil {
+		return Config{}, nil, err
+	}
+
 	configPath, err := ConfigFilePath()
 	if err != nil {
 		return Config{}, nil, err
 	}
+	return loadConfigFile(ctx, configPath)
+}
 
+// loadConfigFile implements LoadConfigWithContext for the file at configPath.
+// While the file's size and modification time are unchanged, a copy of the
+// previous result is returned without reading, decrypting or validating again.
+func loadConfigFile(ctx context.Context, configPath string) (Config, map[string]error, error) {
 	// Initialize map for validation errors
 	validationErrors := make(map[string]error)
 	emptyValidConfig := Config{ // Used for early returns
@@ -130,7 +279,8 @@ func LoadConfig() (Config, map[string]error, error) {
 	}
 
 	// Check if the file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	fileInfo, statErr := os.Stat(configPath)
+	if os.IsNotExist(statErr) {
 		// Create directory if it doesn't exist
 		configDir := filepath.Dir(configPath)
 		if err := os.MkdirAll(configDir, 0700); err != nil {
@@ -154,15 +304,34 @@ func LoadConfig() (Config, map[string]error, error) {
 		// Return the newly created empty config (no profiles, no errors)
 		return emptyConfig, validationErrors, nil
 	}
+	if statErr == nil {
+		if cached, cachedErrors, ok := cachedLoad(configPath, fileInfo); ok {
+			return cached, cachedErrors, nil
+		}
+	}
 
-	data, err := os.ReadFile(configPath)
+	data, err := readFileContext(ctx, configPath)
 	if err != nil {
+		if ctx.Err() != nil {
+			return emptyValidConfig, nil, err
+		}
 		return emptyValidConfig, nil, fmt.Errorf("❌ could not read config file: %w", err)
 	}
 
-	var loadedConfig Config // Holds the raw loaded config, possibly with invalid profiles
-	if err := json.Unmarshal(data, &loadedConfig); err != nil {
-		return emptyValidConfig, nil, fmt.Errorf("❌ could not parse config file: %w", err)
+	// Holds the raw loaded config, possibly with invalid profiles
+	loadedConfig, err := parseConfigData(data)
+	backupPath := ""
+	if err != nil {
+		// Fall back to the newest readable backup (unless GAT_NO_AUTO_RECOVER=1)
+		recovered, path, recoverErr := recoverConfigFromBackup()
+		if recoverErr != nil {
+			return emptyValidConfig, nil, utils.Errorf(ErrConfigCorrupt, "❌ could not parse config file: %w", err)
+		}
+		fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Could not parse %s (%v); loaded backup %s instead\n"), configPath, err, path)
+		loadedConfig, backupPath = recovered, path
+	}
+	if err := ctx.Err(); err != nil {
+		return emptyValidConfig, nil, err
 	}
 
 	// If this is an old config file, initialize security settings
@@ -172,11 +341,24 @@ func LoadConfig() (Config, map[string]error, error) {
 		// Note: SaveConfig will handle persistence of these on next save
 	}
 
+	// Password-protected tokens need the password before anything else
+	if loadedConfig.UsePasswordEncryption {
+		if err := loadedConfig.unlockPassword(); err != nil {
+			return emptyValidConfig, nil, err
+		}
+	}
+
+	// Tokens kept in the keychain are read whatever the current backend, so
+	// that switching back to the file can move them
+	for name, err := range loadKeychainTokens(&loadedConfig) {
+		fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Could not read the token of profile [%s]: %v\n"), name, err)
+	}
+
 	// Attempt to decrypt any tokens if they're stored encrypted
 	if loadedConfig.StoreEncrypted {
 		for name, profile := range loadedConfig.Profiles {
 			if profile.Token != "" && strings.HasPrefix(profile.Token, "enc:") {
-				decryptedToken, err := DecryptToken(profile.Token, loadedConfig.Salt)
+				decryptedToken, err := DecryptToken(profile.Token, loadedConfig.EncryptionSecret())
 				if err == nil {
 					profile.rawToken = decryptedToken
 					// Update profile in the original loaded map temporarily for validation
@@ -193,6 +375,10 @@ func LoadConfig() (Config, map[string]error, error) {
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		return emptyValidConfig, nil, err
+	}
+
 	// Check and fix permissions
 	EnsureSecurePermissions(configPath) // Best effort
 
@@ -200,9 +386,25 @@ func LoadConfig() (Config, map[string]error, error) {
 	validConfig := Config{
 		Current:        loadedConfig.Current,
 		Profiles:       make(map[string]Profile),
+		Aliases:        loadedConfig.Aliases,
+		Templates:      loadedConfig.Templates,
 		StoreEncrypted: loadedConfig.StoreEncrypted,
 		NoStoreTokens:  loadedConfig.NoStoreTokens,
 		Salt:           loadedConfig.Salt,
+		SecretBackend:  loadedConfig.SecretBackend,
+		keychainKeys:   loadedConfig.keychainKeys,
+		AutoSwitch:     loadedConfig.AutoSwitch,
+		MaxBackups:     loadedConfig.MaxBackups,
+		Compress:       loadedConfig.Compress,
+
+		UsePasswordEncryption: loadedConfig.UsePasswordEncryption,
+		PasswordCheck:         loadedConfig.PasswordCheck,
+		secret:                loadedConfig.secret,
+		StrictEmailValidation: loadedConfig.StrictEmailValidation,
+		PreferLocalScope:      loadedConfig.PreferLocalScope,
+		DefaultAuthMethod:     loadedConfig.DefaultAuthMethod,
+		ProfileOrdering:       loadedConfig.ProfileOrdering,
+		Webhooks:              loadedConfig.Webhooks,
 	}
 
 	// Validate profiles after loading
@@ -213,34 +415,18 @@ profileLoop:
 			continue profileLoop
 		}
 
-		// Validate Username
-		if !ValidGitHubUsernameRegex.MatchString(profile.Username) {
-			validationErrors[name] = fmt.Errorf("❌ invalid username format: '%s'", profile.Username)
+		// Validate and normalize the stored fields
+		if err := ValidateProfile(&profile); err != nil {
+			validationErrors[name] = err
 			continue profileLoop
 		}
+		profile.updateTokenExpiry(time.Now())
 
 		// Validate Email
 		if !ValidEmailRegex.MatchString(profile.Email) {
 			// Warn instead of error for email, as Git itself allows weird emails sometimes
-			fmt.Printf(color.YellowString("⚠️ Warning: Profile [%s] has potentially invalid email format: %s\n"), name, profile.Email)
-		}
-
-		// Validate AuthMethod
-		if profile.AuthMethod == "" {
-			validationErrors[name] = fmt.Errorf("❌ missing required field 'auth_method'. Please reconfigure profile")
-			continue profileLoop
-		}
-		profile.AuthMethod = strings.ToLower(profile.AuthMethod) // Normalize
-		if profile.AuthMethod != "ssh" && profile.AuthMethod != "https" {
-			validationErrors[name] = fmt.Errorf("❌ invalid auth_method: '%s'. Must be 'ssh' or 'https'", profile.AuthMethod)
-			continue profileLoop
-		}
-
-		// Normalize Platform (handle legacy empty platform)
-		if profile.Platform == "" {
-			profile.Platform = "github" // Default for backwards compatibility
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Profile [%s] has potentially invalid email format: %s\n"), name, profile.Email)
 		}
-		profile.Platform = strings.ToLower(profile.Platform)
 
 		// If all checks passed, add the profile (with potentially updated fields) to the valid map
 		validConfig.Profiles[name] = profile
@@ -250,22 +436,104 @@ profileLoop:
 	if _, exists := validConfig.Profiles[validConfig.Current]; !exists && validConfig.Current != "" {
 		// If the current profile is listed but failed validation
 		if _, invalid := validationErrors[validConfig.Current]; invalid {
-			fmt.Printf(color.YellowString("⚠️ Warning: Current profile [%s] is invalid, unsetting active profile.\n"), validConfig.Current)
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Current profile [%s] is invalid, unsetting active profile.\n"), validConfig.Current)
 			validConfig.Current = ""
 			// Optionally, save the config here to persist the unset current profile? Or let next command handle it.
 		} else {
 			// This case shouldn't happen if logic is correct (current not in valid map and not in error map)
 			// Maybe it was deleted manually?
-			fmt.Printf(color.YellowString("⚠️ Warning: Current profile [%s] not found, unsetting active profile.\n"), validConfig.Current)
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Current profile [%s] not found, unsetting active profile.\n"), validConfig.Current)
 			validConfig.Current = ""
 		}
 	}
 
+	setLastLoadBackup(backupPath)
+	// A config recovered from a backup is not cached so the next load retries the file
+	if statErr == nil && backupPath == "" {
+		storeLoad(configPath, fileInfo, validConfig, validationErrors)
+	}
 	return validConfig, validationErrors, nil
 }
 
+// readFileContext reads a file in a goroutine so the caller can give up
+// when ctx is done; the read itself cannot be interrupted
+func readFileContext(ctx context.Context, path string) ([]byte, error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1) // Buffered so an abandoned read doesn't leak the goroutine forever
+
+	go func() {
+		data, err := os.ReadFile(path)
+		done <- result{data, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		return res.data, res.err
+	}
+}
+
+// ValidateProfile runs the checks a stored profile must pass to be usable and
+// normalizes its auth method and platform in place.
+// Email format is not checked here since an unusual email is only a warning.
+func ValidateProfile(profile *Profile) error {
+	// Validate Username
+	if !ValidGitHubUsernameRegex.MatchString(profile.Username) {
+		return utils.Errorf(ErrInvalidProfile, "❌ invalid username format: '%s'", profile.Username)
+	}
+
+	if err := ValidateDescription(profile.Description); err != nil {
+		return err
+	}
+	if err := ValidateGPGKey(profile.GPGKey); err != nil {
+		return err
+	}
+	if err := ValidateTags(profile.Tags); err != nil {
+		return err
+	}
+
+	// Promote the single ssh_identity of older configs
+	if profile.LegacySSHIdentity != "" {
+		if !slices.Contains(profile.SSHIdentities, profile.LegacySSHIdentity) {
+			profile.SSHIdentities = append([]string{profile.LegacySSHIdentity}, profile.SSHIdentities...)
+		}
+		profile.LegacySSHIdentity = ""
+	}
+
+	// Validate AuthMethod
+	if profile.AuthMethod == "" {
+		return utils.Errorf(ErrInvalidAuthMethod, "❌ missing required field 'auth_method'. Please reconfigure profile")
+	}
+	profile.AuthMethod = strings.ToLower(profile.AuthMethod) // Normalize
+	if profile.AuthMethod != "ssh" && profile.AuthMethod != "https" {
+		return utils.Errorf(ErrInvalidAuthMethod, "❌ invalid auth_method: '%s'. Must be 'ssh' or 'https'", profile.AuthMethod)
+	}
+
+	// Normalize Platform (handle legacy empty platform)
+	if profile.Platform == "" {
+		profile.Platform = "github" // Default for backwards compatibility
+	}
+	profile.Platform = strings.ToLower(profile.Platform)
+
+	return nil
+}
+
 // SaveConfig saves the configuration to disk
 func SaveConfig(config *Config) error {
+	return saveConfig(config, false)
+}
+
+// SaveConfigAtomic is SaveConfig writing a temporary file that replaces the
+// config file, so it is never left half written
+func SaveConfigAtomic(config *Config) error {
+	return saveConfig(config, true)
+}
+
+func saveConfig(config *Config, atomic bool) error {
 	configPath, err := ConfigFilePath()
 	if err != nil {
 		return err
@@ -282,13 +550,19 @@ func SaveConfig(config *Config) error {
 
 	// Process profiles for encryption or removal of tokens
 	for name, profile := range processedConfig.Profiles {
-		if profile.rawToken != "" {
+		// Unlocked password-protected tokens keep their own encryption
+		if profile.rawToken != "" && !strings.HasPrefix(profile.Token, passwordTokenPrefix) {
 			if config.NoStoreTokens {
 				// Don't store token at all
 				profile.Token = ""
+			} else if config.SecretBackend == SecretBackendKeychain {
+				// Store a reference to the token in the keychain
+				if profile.Token, err = saveKeychainToken(name, profile.rawToken); err != nil {
+					return err
+				}
 			} else if config.StoreEncrypted {
 				// Encrypt token before storage
-				profile.Token = EncryptToken(profile.rawToken, config.Salt)
+				profile.Token = EncryptToken(profile.rawToken, config.EncryptionSecret())
 			} else {
 				// Store in plaintext (with warning)
 				profile.Token = profile.rawToken
@@ -299,12 +573,26 @@ func SaveConfig(config *Config) error {
 		}
 	}
 
-	data, err := json.MarshalIndent(processedConfig, "", "  ")
-	if err != nil {
-		return fmt.Errorf("❌ could not marshal config: %w", err)
+	var data []byte
+	if config.Compress {
+		data, err = CompressConfig(&processedConfig)
+		if err != nil {
+			return err
+		}
+	} else {
+		data, err = json.MarshalIndent(processedConfig, "", "  ")
+		if err != nil {
+			return fmt.Errorf("❌ could not marshal config: %w", err)
+		}
 	}
 
-	if err := os.WriteFile(configPath, data, 0600); err != nil {
+	invalidateLoadCache()
+	if atomic {
+		err = writeFileAtomic(configPath, data)
+	} else {
+		err = os.WriteFile(configPath, data, 0600)
+	}
+	if err != nil {
 		return fmt.Errorf("❌ could not write config file: %w", err)
 	}
 
@@ -313,9 +601,35 @@ func SaveConfig(config *Config) error {
 		return fmt.Errorf("❌ could not set secure permissions: %w", err)
 	}
 
+	deleteKeychainTokens(&processedConfig)
+	config.keychainKeys = processedConfig.keychainKeys
 	return nil
 }
 
+// writeFileAtomic writes data to a temporary file next to path, readable by
+// the owner only, and renames it over path
+func writeFileAtomic(path string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
 // EnsureSecurePermissions ensures that config files have appropriately restrictive permissions
 func EnsureSecurePermissions(path string) error {
 	// On Unix-like systems, set appropriate permissions
@@ -340,7 +654,7 @@ func GetCurrentProfile(config *Config) (*Profile, string, error) {
 
 	profile, exists := config.Profiles[config.Current]
 	if !exists {
-		return nil, "", fmt.Errorf("❌ active profile '%s' not found", config.Current)
+		return nil, "", utils.Errorf(ErrProfileNotFound, "❌ active profile '%s' not found", config.Current)
 	}
 
 	return &profile, config.Current, nil
@@ -353,39 +667,115 @@ func AddProfile(config *Config, name string, profile Profile, overwrite bool) er
 		return err
 	}
 
-	if _, exists := config.Profiles[name]; exists && !overwrite {
-		return fmt.Errorf("❌ profile [%s] already exists. Use --overwrite to replace it", name)
+	existing, exists := config.Profiles[name]
+	if exists && !overwrite {
+		return utils.Errorf(ErrProfileExists, "❌ profile [%s] already exists. Use --overwrite to replace it", name)
+	}
+	if target, isAlias := config.Aliases[name]; isAlias {
+		return utils.Errorf(ErrProfileExists, "❌ '%s' is an alias for '%s'. Remove it with 'gat alias remove %s' first", name, target, name)
 	}
 
 	// Basic validation before adding (more thorough validation happens on load)
 	if !ValidGitHubUsernameRegex.MatchString(profile.Username) {
-		return fmt.Errorf("❌ invalid username format: '%s'", profile.Username)
+		return utils.Errorf(ErrInvalidProfile, "❌ invalid username format: '%s'", profile.Username)
+	}
+	// Loose failures are only warned about; strict (RFC 5321) failures are
+	// errors when StrictEmailValidation is set, unless an overwrite keeps the
+	// stored email
+	strict, err := ValidateEmail(profile.Email)
+	emailChanged := !exists || profile.Email != existing.Email
+	if !strict && config.StrictEmailValidation && emailChanged {
+		if err == nil {
+			err = StrictEmailError(profile.Email)
+		}
+		return utils.Errorf(ErrInvalidProfile, "❌ %v", err)
+	}
+	if err != nil {
+		fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: Profile [%s] has potentially invalid email format: %s\n"), name, profile.Email)
+	}
+	if err := ValidateDescription(profile.Description); err != nil {
+		return err
+	}
+	if err := ValidateGPGKey(profile.GPGKey); err != nil {
+		return err
 	}
-	if !ValidEmailRegex.MatchString(profile.Email) {
-		// Allow potentially invalid emails but warn
-		fmt.Printf(color.YellowString("⚠️ Warning: Profile [%s] has potentially invalid email format: %s\n"), name, profile.Email)
+	if err := ValidateTags(profile.Tags); err != nil {
+		return err
 	}
 	if profile.AuthMethod == "" {
-		return fmt.Errorf("❌ 'auth_method' is required")
+		return utils.Errorf(ErrInvalidAuthMethod, "❌ 'auth_method' is required")
 	}
 	profile.AuthMethod = strings.ToLower(profile.AuthMethod)
 	if profile.AuthMethod != "ssh" && profile.AuthMethod != "https" {
-		return fmt.Errorf("❌ invalid 'auth_method': '%s'. Must be 'ssh' or 'https'", profile.AuthMethod)
+		return utils.Errorf(ErrInvalidAuthMethod, "❌ invalid 'auth_method': '%s'. Must be 'ssh' or 'https'", profile.AuthMethod)
 	}
 	if profile.Platform == "" {
 		profile.Platform = "github"
 	}
 	profile.Platform = strings.ToLower(profile.Platform)
 
+	shared, err := ProfilesSharingSSHIdentity(config, name, profile)
+	if err != nil {
+		return err
+	}
+	if len(shared) > 0 {
+		fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: SSH identity %s is already used by profile(s) [%s] on platform '%s'. SSH auth may use the wrong account.\n"),
+			strings.Join(profile.SSHIdentities, ", "), strings.Join(shared, ", "), profile.Platform)
+	}
+
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+	profile.UpdatedAt = &updatedAt
 	config.Profiles[name] = profile
+
+	for _, duplicate := range DetectDuplicateHosts(config) {
+		if slices.Contains(duplicate.Profiles, name) {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: SSH host alias '%s' is shared by profiles [%s]. Only one of them can be used over SSH; use another profile name.\n"),
+				duplicate.Alias, strings.Join(duplicate.Profiles, ", "))
+		}
+	}
 	return nil
 }
 
+// ProfilesSharingSSHIdentity returns the sorted names of other profiles that use
+// one of the SSH identities of the given profile on the same platform
+func ProfilesSharingSSHIdentity(config *Config, name string, profile Profile) ([]string, error) {
+	if len(profile.SSHIdentities) == 0 {
+		return nil, nil
+	}
+
+	identities := make(map[string]bool, len(profile.SSHIdentities))
+	for _, identity := range profile.SSHIdentities {
+		identity, err := utils.ExpandHome(identity)
+		if err != nil {
+			return nil, err
+		}
+		identities[filepath.Clean(identity)] = true
+	}
+	var shared []string
+	for otherName, other := range config.Profiles {
+		if otherName == name || other.GetPlatform() != profile.GetPlatform() {
+			continue
+		}
+		for _, identity := range other.SSHIdentities {
+			identity, err := utils.ExpandHome(identity)
+			if err != nil {
+				return nil, err
+			}
+			if identities[filepath.Clean(identity)] {
+				shared = append(shared, otherName)
+				break
+			}
+		}
+	}
+	sort.Strings(shared)
+	return shared, nil
+}
+
 // RemoveProfile removes a profile from the configuration
 // Note: Assumes config passed in contains only valid profiles (as returned by LoadConfig)
 func RemoveProfile(config *Config, name string, noBackup bool) error {
 	if _, exists := config.Profiles[name]; !exists {
-		return fmt.Errorf("❌ profile '%s' does not exist", name)
+		return utils.Errorf(ErrProfileNotFound, "❌ profile '%s' does not exist", name)
 	}
 
 	// Create backup before removal (unless explicitly disabled)
@@ -395,7 +785,12 @@ func RemoveProfile(config *Config, name string, noBackup bool) error {
 		}
 	}
 
+	// Aliases of the profile would dangle, so they go with it
+	for _, alias := range AliasesFor(config, name) {
+		delete(config.Aliases, alias)
+	}
 	delete(config.Profiles, name)
+	config.ProfileOrdering = slices.DeleteFunc(config.ProfileOrdering, func(ordered string) bool { return ordered == name })
 
 	// If we deleted the current profile, unset it
 	if config.Current == name {
@@ -405,15 +800,56 @@ func RemoveProfile(config *Config, name string, noBackup bool) error {
 	return nil
 }
 
+// RenameProfile moves a profile to a new name, keeping it active if it was
+// and pointing its aliases and display order at the new name. The new name
+// must be valid and not used by another profile or alias.
+// Note: Assumes config passed in contains only valid profiles (as returned by LoadConfig)
+func RenameProfile(config *Config, oldName, newName string) error {
+	profile, exists := config.Profiles[oldName]
+	if !exists {
+		return utils.Errorf(ErrProfileNotFound, "❌ profile '%s' does not exist", oldName)
+	}
+	if err := ValidateProfileName(newName); err != nil {
+		return fmt.Errorf("❌ invalid profile name: %w", err)
+	}
+	if newName == oldName {
+		return fmt.Errorf("❌ profile '%s' already has that name", oldName)
+	}
+	// Not ErrProfileExists: its hint (--overwrite) does not apply to renames
+	if _, exists := config.Profiles[newName]; exists {
+		return fmt.Errorf("❌ profile '%s' already exists; choose another name", newName)
+	}
+	if _, isAlias := config.Aliases[newName]; isAlias {
+		return fmt.Errorf("❌ '%s' is an alias; remove it with 'gat alias remove %s' first", newName, newName)
+	}
+
+	config.Profiles[newName] = profile
+	delete(config.Profiles, oldName)
+
+	for alias, target := range config.Aliases {
+		if target == oldName {
+			config.Aliases[alias] = newName
+		}
+	}
+	for i, ordered := range config.ProfileOrdering {
+		if ordered == oldName {
+			config.ProfileOrdering[i] = newName
+		}
+	}
+	if config.Current == oldName {
+		config.Current = newName
+	}
+	return nil
+}
+
 // BackupProfile creates a backup of a profile before deletion
 func BackupProfile(config *Config, name string) error {
 	// Create backup directory if it doesn't exist
-	configDir, err := ConfigPath()
+	backupDir, err := BackupDir()
 	if err != nil {
 		return err
 	}
 
-	backupDir := filepath.Join(configDir, "backups")
 	if err := os.MkdirAll(backupDir, 0700); err != nil {
 		return fmt.Errorf("could not create backup directory: %w", err)
 	}
@@ -424,8 +860,10 @@ func BackupProfile(config *Config, name string) error {
 		return fmt.Errorf("profile '%s' does not exist", name)
 	}
 
-	// Create a backup file with timestamp
+	// The newest backup is always <name>.backup.json; older ones are rotated
+	// to <name>.backup.1.json, <name>.backup.2.json, ... up to MaxBackups
 	backupFile := filepath.Join(backupDir, fmt.Sprintf("%s.backup.json", name))
+	rotateBackups(backupDir, name, config.MaxBackups)
 
 	// Create single-profile backup
 	backup := map[string]Profile{
@@ -444,30 +882,76 @@ func BackupProfile(config *Config, name string) error {
 	return nil
 }
 
+// rotateBackups shifts existing backups of a profile so that at most
+// maxBackups remain once a new one is written (best effort)
+func rotateBackups(backupDir, name string, maxBackups int) {
+	if maxBackups < 1 {
+		maxBackups = 1
+	}
+
+	backupPath := func(index int) string {
+		if index == 0 {
+			return filepath.Join(backupDir, fmt.Sprintf("%s.backup.json", name))
+		}
+		return filepath.Join(backupDir, fmt.Sprintf("%s.backup.%d.json", name, index))
+	}
+
+	os.Remove(backupPath(maxBackups - 1))
+	for i := maxBackups - 2; i >= 0; i-- {
+		if _, err := os.Stat(backupPath(i)); err == nil {
+			os.Rename(backupPath(i), backupPath(i+1))
+		}
+	}
+}
+
 // SwitchProfile sets the current active profile
 // Note: Assumes config passed in contains only valid profiles (as returned by LoadConfig)
 func SwitchProfile(config *Config, name string) error {
 	if _, exists := config.Profiles[name]; !exists {
-		return fmt.Errorf("❌ profile [%s] not found", name)
+		return utils.Errorf(ErrProfileNotFound, "❌ profile [%s] not found", name)
 	}
 
 	config.Current = name
 	return nil
 }
 
+// MaxDescriptionLength is the longest Profile.Description allowed, in characters
+const MaxDescriptionLength = 2000
+
+// ValidateDescription checks that a profile description is not too long
+func ValidateDescription(description string) error {
+	if n := utf8.RuneCountInString(description); n > MaxDescriptionLength {
+		return utils.Errorf(ErrInvalidProfile, "❌ description is %d characters long; the limit is %d", n, MaxDescriptionLength)
+	}
+	return nil
+}
+
+// validGPGKeyRegex matches a GPG key ID or fingerprint (8 to 40 hex digits,
+// optionally prefixed with 0x and suffixed with '!' to select a subkey)
+var validGPGKeyRegex = regexp.MustCompile(`^(0[xX])?[0-9A-Fa-f]{8,40}!?$`)
+
+// ValidateGPGKey checks that a profile's GPG key is a key ID or fingerprint.
+// An empty key is valid.
+func ValidateGPGKey(key string) error {
+	if key != "" && !validGPGKeyRegex.MatchString(key) {
+		return utils.Errorf(ErrInvalidProfile, "❌ invalid GPG key '%s': use the key ID or fingerprint shown by 'gpg --list-secret-keys --keyid-format long'", key)
+	}
+	return nil
+}
+
 // ValidateProfileName checks if a profile name is valid
 // (Basic check, more comprehensive validation can be added if needed)
 func ValidateProfileName(name string) error {
 	// Check for empty name
 	if name == "" {
-		return fmt.Errorf("profile name cannot be empty")
+		return utils.Errorf(ErrInvalidProfile, "profile name cannot be empty")
 	}
 
 	// Check for shell special characters
 	dangerousChars := []string{";", "&", "|", ">", "<", "`", "$", "\\", "\"", "'", " "}
 	for _, char := range dangerousChars {
 		if strings.Contains(name, char) {
-			return fmt.Errorf("profile name contains invalid character: '%s'", char)
+			return utils.Errorf(ErrInvalidProfile, "profile name contains invalid character: '%s'", char)
 		}
 	}
 
@@ -475,91 +959,139 @@ func ValidateProfileName(name string) error {
 	validPattern := "^[a-zA-Z0-9_.-]+$"
 	matched, _ := regexp.MatchString(validPattern, name)
 	if !matched {
-		return fmt.Errorf("profile name must contain only letters, numbers, underscore, dash, or period")
+		return utils.Errorf(ErrInvalidProfile, "profile name must contain only letters, numbers, underscore, dash, or period")
 	}
 
 	return nil
 }
 
-// EncryptToken encrypts a token using AES-256
+// Prefixes of encrypted tokens. Tokens written before versioning have the
+// bare "enc:" prefix and use the v1 key.
+const (
+	encryptedTokenPrefix = "enc:"
+	tokenPrefixV1        = "enc:v1:" // Key: SHA-256 of the secret
+	tokenPrefixV2        = "enc:v2:" // Key: Argon2id of the secret
+)
+
+// Argon2id parameters of the v2 token key. The secret is already random (the
+// salt, or a key derived from the password), so one pass is enough.
+const (
+	tokenKeyTime    = 1
+	tokenKeyMemory  = 64 * 1024 // KiB
+	tokenKeyThreads = 4
+)
+
+// tokenKeySalt is the Argon2id salt of the v2 token key
+const tokenKeySalt = "gat-token-key"
+
+// EncryptToken encrypts a token using AES-256-GCM with the v2 key derived
+// from salt
 func EncryptToken(token, salt string) string {
 	if token == "" {
 		return ""
 	}
+	encrypted, err := encryptTokenBytes([]byte(token), salt)
+	if err != nil {
+		// Fallback to plaintext on error
+		return token
+	}
+	return encrypted
+}
 
+// encryptTokenBytes is EncryptToken for a token held in a byte slice, which
+// the caller can zero afterwards
+func encryptTokenBytes(token []byte, salt string) (string, error) {
 	// Generate key from salt
-	key := deriveKey(salt)
+	key := deriveKeyV2(salt)
 
 	// Create a new cipher block
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		// Fallback to plaintext on error
-		return token
+		return "", err
 	}
 
 	// Create a GCM
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return token
+		return "", err
 	}
 
 	// Generate nonce
 	nonce := make([]byte, gcm.NonceSize())
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return token
+		return "", err
 	}
 
 	// Encrypt
-	ciphertext := gcm.Seal(nonce, nonce, []byte(token), nil)
+	ciphertext := gcm.Seal(nonce, nonce, token, nil)
 
 	// Return as base64
-	return "enc:" + base64.StdEncoding.EncodeToString(ciphertext)
+	return tokenPrefixV2 + base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-// DecryptToken decrypts a token
+// DecryptToken decrypts a token encrypted by EncryptToken, with the key of
+// the version in its prefix. Tokens without the "enc:" prefix are returned
+// as they are.
 func DecryptToken(encryptedToken, salt string) (string, error) {
-	if !strings.HasPrefix(encryptedToken, "enc:") {
+	if !strings.HasPrefix(encryptedToken, encryptedTokenPrefix) {
 		return encryptedToken, nil
 	}
+	plaintext, err := decryptTokenBytes(encryptedToken, salt)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
 
-	// Remove prefix
-	data := strings.TrimPrefix(encryptedToken, "enc:")
+// decryptTokenBytes is DecryptToken for a token with the "enc:" prefix,
+// returning the plaintext in a byte slice the caller can zero
+func decryptTokenBytes(encryptedToken, salt string) ([]byte, error) {
+	var data string
+	var key []byte
+	switch {
+	case strings.HasPrefix(encryptedToken, tokenPrefixV2):
+		data, key = strings.TrimPrefix(encryptedToken, tokenPrefixV2), deriveKeyV2(salt)
+	case strings.HasPrefix(encryptedToken, tokenPrefixV1):
+		data, key = strings.TrimPrefix(encryptedToken, tokenPrefixV1), deriveKey(salt)
+	case strings.HasPrefix(encryptedToken, encryptedTokenPrefix):
+		data, key = strings.TrimPrefix(encryptedToken, encryptedTokenPrefix), deriveKey(salt)
+	default:
+		return nil, fmt.Errorf("not an encrypted token")
+	}
 
 	// Decode base64
 	ciphertext, err := base64.StdEncoding.DecodeString(data)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Generate key from salt
-	key := deriveKey(salt)
-
 	// Create a new cipher block
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Create a GCM
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Split nonce and ciphertext
 	if len(ciphertext) < gcm.NonceSize() {
-		return "", fmt.Errorf("ciphertext too short")
+		return nil, fmt.Errorf("ciphertext too short")
 	}
 
 	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
 
 	// Decrypt
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
-	if err != nil {
-		return "", err
-	}
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
 
-	return string(plaintext), nil
+// IsLegacyEncryptedToken reports whether a stored token is encrypted with
+// the v1 key, which 'gat migrate-encryption' replaces
+func IsLegacyEncryptedToken(token string) bool {
+	return strings.HasPrefix(token, encryptedTokenPrefix) && !strings.HasPrefix(token, tokenPrefixV2)
 }
 
 // GenerateSalt generates a random salt
@@ -572,8 +1104,23 @@ func GenerateSalt() string {
 	return base64.StdEncoding.EncodeToString(salt)
 }
 
-// deriveKey derives a cryptographic key from a salt
+// deriveKey derives the v1 token key from a salt: a single SHA-256, kept to
+// read tokens encrypted before v2
 func deriveKey(salt string) []byte {
 	hash := sha256.Sum256([]byte(salt))
 	return hash[:]
 }
+
+// v2Keys caches the keys of deriveKeyV2 by salt, since Argon2id is slow on
+// purpose and every token of a config shares the salt
+var v2Keys sync.Map
+
+// deriveKeyV2 derives the v2 token key from a salt with Argon2id
+func deriveKeyV2(salt string) []byte {
+	if key, cached := v2Keys.Load(salt); cached {
+		return key.([]byte)
+	}
+	key := argon2.IDKey([]byte(salt), []byte(tokenKeySalt), tokenKeyTime, tokenKeyMemory, tokenKeyThreads, argon2KeyLen)
+	v2Keys.Store(salt, key)
+	return key
+}
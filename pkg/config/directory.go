@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FindProfileForDirectory returns the name of the profile whose WorkingDirectory
+// glob matches dir or one of its parent directories, or "" if none match.
+// When several profiles match, the one matching the deepest directory wins so
+// a pattern for a sub-tree can override a broader one.
+func FindProfileForDirectory(cfg *Config, dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("❌ could not resolve directory: %w", err)
+	}
+
+	// Iterate in name order so ties are deterministic
+	var names []string
+	for name, profile := range cfg.Profiles {
+		if profile.WorkingDirectory != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	bestName := ""
+	bestDepth := -1
+	for _, name := range names {
+		pattern, err := utils.ExpandHome(cfg.Profiles[name].WorkingDirectory)
+		if err != nil {
+			return "", err
+		}
+		if depth := matchDirectoryDepth(filepath.Clean(pattern), dir); depth > bestDepth {
+			bestName = name
+			bestDepth = depth
+		}
+	}
+
+	return bestName, nil
+}
+
+// matchDirectoryDepth returns the path depth of the deepest ancestor of dir
+// (including dir itself) matched by pattern, or -1 if none match
+func matchDirectoryDepth(pattern, dir string) int {
+	for current := dir; ; current = filepath.Dir(current) {
+		if matched, err := filepath.Match(pattern, current); err == nil && matched {
+			return strings.Count(current, string(filepath.Separator))
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return -1
+		}
+	}
+}
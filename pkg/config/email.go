@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RFC 5321 size limits (section 4.5.3.1)
+const (
+	maxEmailLocalPartLength = 64
+	maxEmailDomainLength    = 255
+	maxEmailDomainLabel     = 63
+	maxEmailLength          = 254 // 256-octet path minus the angle brackets
+)
+
+// ValidateEmail checks an email address at two levels. err is non-nil when
+// the address does not even match ValidEmailRegex (the loose check gat has
+// always applied as a warning). strict reports whether the address also
+// follows the RFC 5321 rules for length, dot placement and domain labels;
+// it is never true when err is non-nil.
+func ValidateEmail(email string) (strict bool, err error) {
+	if !ValidEmailRegex.MatchString(email) {
+		return false, fmt.Errorf("potentially invalid email format: %s", email)
+	}
+	return strictEmailProblem(email) == "", nil
+}
+
+// StrictEmailError describes why an address fails the strict RFC 5321
+// check, or returns nil if it passes
+func StrictEmailError(email string) error {
+	if problem := strictEmailProblem(email); problem != "" {
+		return fmt.Errorf("email '%s' is not RFC 5321 compliant: %s", email, problem)
+	}
+	return nil
+}
+
+// strictEmailProblem returns the first RFC 5321 rule an address breaks
+// (empty if none). The character set is already restricted by
+// ValidEmailRegex, so only the structural rules are checked here.
+func strictEmailProblem(email string) string {
+	if len(email) > maxEmailLength {
+		return fmt.Sprintf("longer than %d characters", maxEmailLength)
+	}
+
+	localPart, domain, found := strings.Cut(email, "@")
+	if !found || strings.Contains(domain, "@") {
+		return "must contain exactly one '@'"
+	}
+
+	if len(localPart) > maxEmailLocalPartLength {
+		return fmt.Sprintf("local part is longer than %d characters", maxEmailLocalPartLength)
+	}
+	if strings.HasPrefix(localPart, ".") || strings.HasSuffix(localPart, ".") || strings.Contains(localPart, "..") {
+		return "local part must not start or end with '.' or contain '..'"
+	}
+
+	if len(domain) > maxEmailDomainLength {
+		return fmt.Sprintf("domain is longer than %d characters", maxEmailDomainLength)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		switch {
+		case label == "":
+			return "domain must not contain empty labels"
+		case len(label) > maxEmailDomainLabel:
+			return fmt.Sprintf("domain label '%s' is longer than %d characters", label, maxEmailDomainLabel)
+		case strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-"):
+			return fmt.Sprintf("domain label '%s' must not start or end with '-'", label)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EnableEncryption turns on token encryption with a freshly generated salt
+// and re-encrypts every stored token. It returns the sorted names of the
+// profiles whose tokens were encrypted. Call SaveConfig to persist.
+func EnableEncryption(cfg *Config) ([]string, error) {
+	newSalt := GenerateSalt()
+
+	var affected []string
+	for name, profile := range cfg.Profiles {
+		token := profile.GetToken()
+		if token == "" {
+			continue
+		}
+		if strings.HasPrefix(token, "enc:") {
+			return nil, fmt.Errorf("❌ token for profile '%s' could not be decrypted; re-add it before changing encryption", name)
+		}
+		profile.SetToken(token, true, newSalt)
+		cfg.Profiles[name] = profile
+		affected = append(affected, name)
+	}
+
+	cfg.Salt = newSalt
+	cfg.StoreEncrypted = true
+	cfg.UsePasswordEncryption = false
+	cfg.PasswordCheck = ""
+	sort.Strings(affected)
+	return affected, nil
+}
+
+// DisableEncryption turns off token encryption so tokens are stored in
+// plaintext. It returns the sorted names of the profiles whose tokens were
+// decrypted. Call SaveConfig to persist.
+func DisableEncryption(cfg *Config) ([]string, error) {
+	var affected []string
+	for name, profile := range cfg.Profiles {
+		token := profile.GetToken()
+		if token == "" {
+			continue
+		}
+		if strings.HasPrefix(token, "enc:") {
+			return nil, fmt.Errorf("❌ token for profile '%s' could not be decrypted; re-add it before changing encryption", name)
+		}
+		profile.SetToken(token, false, cfg.Salt)
+		cfg.Profiles[name] = profile
+		affected = append(affected, name)
+	}
+
+	cfg.StoreEncrypted = false
+	cfg.UsePasswordEncryption = false
+	cfg.PasswordCheck = ""
+	sort.Strings(affected)
+	return affected, nil
+}
+
+// MigrateEncryption re-encrypts the stored tokens, and the password check of
+// password mode, that still use the v1 key (see IsLegacyEncryptedToken) with
+// the Argon2id v2 key. It returns the sorted names of the profiles whose
+// tokens were re-encrypted. Call SaveConfig to persist.
+func MigrateEncryption(cfg *Config) ([]string, error) {
+	secret := cfg.EncryptionSecret()
+
+	var affected []string
+	for name, profile := range cfg.Profiles {
+		if !IsLegacyEncryptedToken(profile.Token) {
+			continue
+		}
+		token := profile.GetToken()
+		if strings.HasPrefix(token, "enc:") {
+			return nil, fmt.Errorf("❌ token for profile '%s' could not be decrypted; re-add it before changing encryption", name)
+		}
+		profile.SetToken(token, true, secret)
+		cfg.Profiles[name] = profile
+		affected = append(affected, name)
+	}
+
+	if cfg.UsePasswordEncryption && IsLegacyEncryptedToken(cfg.PasswordCheck) {
+		cfg.PasswordCheck = EncryptToken(passwordCheckValue, secret)
+	}
+	sort.Strings(affected)
+	return affected, nil
+}
+
+// RotateEncryptionKey re-encrypts every encrypted token with the key of a
+// newly generated salt, for when the current salt may have leaked. Each token
+// is decrypted from its stored ciphertext into a buffer that is zeroed once
+// it is encrypted again. In password mode the key is derived from password
+// and the new salt; password must be the current one. If any token cannot
+// be decrypted, cfg is left unchanged and the error names its profile.
+// It returns the sorted names of the profiles whose tokens were
+// re-encrypted. Call SaveConfigAtomic to persist.
+func RotateEncryptionKey(cfg *Config, password string) ([]string, error) {
+	if !cfg.StoreEncrypted {
+		return nil, fmt.Errorf("❌ token encryption is not enabled; enable it with 'gat config init-encryption'")
+	}
+
+	oldSecret := cfg.EncryptionSecret()
+	newSalt := GenerateSalt()
+	newSecret := newSalt
+	if cfg.UsePasswordEncryption {
+		if derivePasswordSecret(password, cfg.Salt) != oldSecret {
+			return nil, fmt.Errorf("❌ wrong encryption password")
+		}
+		newSecret = derivePasswordSecret(password, newSalt)
+	}
+
+	rotated := make(map[string]Profile)
+	for name, profile := range cfg.Profiles {
+		if !strings.HasPrefix(profile.Token, encryptedTokenPrefix) {
+			continue // No token, or kept in the keychain or under its own password
+		}
+		token, err := decryptTokenBytes(profile.Token, oldSecret)
+		if err != nil {
+			return nil, fmt.Errorf("❌ token of profile '%s' could not be decrypted with the current key: %w", name, err)
+		}
+		encrypted, err := encryptTokenBytes(token, newSecret)
+		clear(token)
+		if err != nil {
+			return nil, fmt.Errorf("❌ could not encrypt the token of profile '%s': %w", name, err)
+		}
+		// SaveConfig keeps the new ciphertext as long as rawToken is empty
+		profile.Token, profile.rawToken = encrypted, ""
+		rotated[name] = profile
+	}
+
+	// Only now that every token has been re-encrypted is cfg changed
+	affected := make([]string, 0, len(rotated))
+	for name, profile := range rotated {
+		cfg.Profiles[name] = profile
+		affected = append(affected, name)
+	}
+	cfg.Salt = newSalt
+	if cfg.UsePasswordEncryption {
+		cfg.PasswordCheck = EncryptToken(passwordCheckValue, newSecret)
+		cfg.secret = newSecret
+	}
+	sort.Strings(affected)
+	return affected, nil
+}
+
+// BackupConfigFile copies the current config file to
+// ~/.gat/backups/creds.<timestamp>.json and returns the backup path
+func BackupConfigFile() (string, error) {
+	configPath, err := ConfigFilePath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("❌ could not read config file for backup: %w", err)
+	}
+
+	backupDir, err := BackupDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", fmt.Errorf("❌ could not create backup directory: %w", err)
+	}
+
+	backupPath := filepath.Join(backupDir, fmt.Sprintf("creds.%s.json", time.Now().Format("20060102-150405.000")))
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", fmt.Errorf("❌ could not write config backup: %w", err)
+	}
+	return backupPath, nil
+}
@@ -0,0 +1,13 @@
+package config
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by config operations.
+// Match them with errors.Is; the user-facing message is kept separately.
+var (
+	ErrProfileNotFound   = errors.New("profile not found")
+	ErrProfileExists     = errors.New("profile already exists")
+	ErrInvalidProfile    = errors.New("invalid profile")
+	ErrInvalidAuthMethod = errors.New("invalid auth method")
+	ErrConfigCorrupt     = errors.New("config file is corrupt")
+)
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"gat/pkg/utils"
+	"os"
+	"strings"
+	"time"
+)
+
+// ExportVersion is the format version written by ExportProfiles
+const ExportVersion = 1
+
+// exportTokenPrefix marks a token encrypted with an export passphrase by
+// EncryptExportTokens. The rest is EncryptTokenWithPassword's format without
+// its prefix, so it is not mistaken for a password-protected profile token.
+const exportTokenPrefix = "expenc:"
+
+// ExportFile is the portable profile file written by 'gat export'. It is a
+// subset of the credentials file format, so 'gat import' (ReadConfigFile)
+// reads it like any other config; the extra fields are ignored there.
+type ExportFile struct {
+	Version    int                        `json:"gat_export_version"`
+	ExportedAt time.Time                  `json:"exported_at"`
+	Current    string                     `json:"current,omitempty"`
+	Profiles   map[string]ExportedProfile `json:"profiles"`
+
+	// Whether tokens are encrypted with a passphrase, see EncryptExportTokens
+	TokensEncrypted bool `json:"tokens_encrypted,omitempty"`
+}
+
+// ExportedProfile is a profile in an ExportFile. Token is empty unless
+// tokens were included; HasToken tells whether the profile has one.
+type ExportedProfile struct {
+	Profile
+	HasToken bool `json:"has_token"`
+}
+
+// ExportProfiles builds an ExportFile from cfg, limited to the named
+// profile if name is not empty (aliases are accepted). With includeTokens
+// tokens are written in plaintext; password-protected tokens (see
+// SetPasswordProtectedToken) stay encrypted with their own password.
+func ExportProfiles(cfg *Config, name string, includeTokens bool) (ExportFile, error) {
+	export := ExportFile{
+		Version:    ExportVersion,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Profiles:   make(map[string]ExportedProfile),
+	}
+
+	names := ListProfileNames(cfg)
+	if name != "" {
+		name = ResolveAlias(cfg, name)
+		if _, exists := cfg.Profiles[name]; !exists {
+			return ExportFile{}, utils.Errorf(ErrProfileNotFound, "❌ profile '%s' does not exist", name)
+		}
+		names = []string{name}
+	}
+
+	for _, profileName := range names {
+		profile := cfg.Profiles[profileName]
+		exported := ExportedProfile{Profile: profile, HasToken: profile.Token != ""}
+		switch {
+		case !includeTokens:
+			exported.Token = ""
+		case strings.HasPrefix(profile.Token, passwordTokenPrefix):
+			// Only its own password can decrypt it
+		default:
+			exported.Token = profile.GetToken()
+		}
+		export.Profiles[profileName] = exported
+	}
+
+	if _, exported := export.Profiles[cfg.Current]; exported {
+		export.Current = cfg.Current
+	}
+	return export, nil
+}
+
+// EncryptExportTokens encrypts the plaintext tokens of export with
+// passphrase, for 'gat export --include-tokens --encrypt'. Import them with
+// DecryptExportTokens.
+func EncryptExportTokens(export *ExportFile, passphrase string) error {
+	for name, profile := range export.Profiles {
+		if profile.Token == "" || strings.HasPrefix(profile.Token, passwordTokenPrefix) {
+			continue
+		}
+		encrypted, err := EncryptTokenWithPassword(profile.Token, passphrase)
+		if err != nil {
+			return err
+		}
+		profile.Token = exportTokenPrefix + strings.TrimPrefix(encrypted, passwordTokenPrefix)
+		export.Profiles[name] = profile
+	}
+	export.TokensEncrypted = true
+	return nil
+}
+
+// HasExportEncryptedTokens reports whether cfg, read with ReadImportFile,
+// has tokens that need the export passphrase
+func HasExportEncryptedTokens(cfg *Config) bool {
+	for _, profile := range cfg.Profiles {
+		if strings.HasPrefix(profile.Token, exportTokenPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecryptExportTokens decrypts the tokens EncryptExportTokens encrypted in
+// cfg, read with ReadImportFile, so they can be imported
+func DecryptExportTokens(cfg *Config, passphrase string) error {
+	for name, profile := range cfg.Profiles {
+		if !strings.HasPrefix(profile.Token, exportTokenPrefix) {
+			continue
+		}
+		token, err := DecryptTokenWithPassword(passwordTokenPrefix+strings.TrimPrefix(profile.Token, exportTokenPrefix), passphrase)
+		if err != nil {
+			return fmt.Errorf("❌ could not decrypt the token of profile '%s': %w", name, err)
+		}
+		profile.SetToken(token, false, "")
+		cfg.Profiles[name] = profile
+	}
+	return nil
+}
+
+// WriteExportFile writes export to path as indented JSON, readable only by
+// the user since it may contain tokens
+func WriteExportFile(path string, export ExportFile) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("❌ could not encode export: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("❌ could not write export file '%s': %w", path, err)
+	}
+	return nil
+}
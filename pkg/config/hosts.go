@@ -0,0 +1,51 @@
+package config
+
+import (
+	"gat/pkg/platform"
+	"sort"
+	"strings"
+)
+
+// DuplicateHostWarning describes profiles whose SSH host aliases
+// (platform.GetProfileSSHHost) collide in ~/.ssh/gat_config
+type DuplicateHostWarning struct {
+	Alias    string   // Alias the profiles share, as generated for the first of them
+	Profiles []string // Sorted names of the profiles sharing the alias
+}
+
+// DetectDuplicateHosts finds profiles whose SSH host aliases collide. Aliases
+// are "<platform>-<profile>", so they can clash across platforms whose IDs
+// contain dashes (platform "acme" with profile "corp-dev" and platform
+// "acme-corp" with profile "dev"), and SSH matches host names without regard
+// to case ("Work" and "work"). Only the first matching Host block takes
+// effect, so all but one of the profiles would connect with the wrong key.
+func DetectDuplicateHosts(cfg *Config) []DuplicateHostWarning {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	byAlias := make(map[string]*DuplicateHostWarning)
+	var keys []string
+	for _, name := range names {
+		profile := cfg.Profiles[name]
+		alias := platform.GetProfileSSHHost(profile.GetPlatform(), name)
+		key := strings.ToLower(alias)
+		if warning, exists := byAlias[key]; exists {
+			warning.Profiles = append(warning.Profiles, name)
+			continue
+		}
+		byAlias[key] = &DuplicateHostWarning{Alias: alias, Profiles: []string{name}}
+		keys = append(keys, key)
+	}
+
+	var warnings []DuplicateHostWarning
+	sort.Strings(keys)
+	for _, key := range keys {
+		if warning := byAlias[key]; len(warning.Profiles) > 1 {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings
+}
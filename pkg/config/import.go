@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// MergeStrategy decides what ImportProfiles does with a profile that already
+// exists in the destination config
+type MergeStrategy int
+
+const (
+	// SkipExisting keeps the existing profile
+	SkipExisting MergeStrategy = iota
+	// OverwriteExisting always replaces the existing profile
+	OverwriteExisting
+	// KeepNewer keeps whichever profile has the later UpdatedAt
+	KeepNewer
+)
+
+// mergeStrategyNames maps MergeStrategy values to their flag names
+var mergeStrategyNames = map[MergeStrategy]string{
+	SkipExisting:      "skip",
+	OverwriteExisting: "overwrite",
+	KeepNewer:         "keep-newer",
+}
+
+// String returns the flag name of the strategy
+func (s MergeStrategy) String() string {
+	if name, ok := mergeStrategyNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("MergeStrategy(%d)", int(s))
+}
+
+// ParseMergeStrategy parses a strategy flag name: "skip", "overwrite" or
+// "keep-newer"
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	for strategy, strategyName := range mergeStrategyNames {
+		if strings.EqualFold(name, strategyName) {
+			return strategy, nil
+		}
+	}
+	return 0, fmt.Errorf("❌ invalid merge strategy '%s'. Must be 'skip', 'overwrite' or 'keep-newer'", name)
+}
+
+// ImportResult lists the profile names ImportProfiles imported, skipped
+// because they already existed, and rejected as invalid, each sorted
+type ImportResult struct {
+	Imported []string
+	Skipped  []string
+	Invalid  []string
+}
+
+// ImportProfiles copies the profiles of src into dst through AddProfile, so
+// they are validated as by 'gat add', resolving name clashes with strategy.
+// Clashing profiles with different usernames are reported to WarningOutput,
+// and imported profiles keep their UpdatedAt. dst.Current is only changed
+// by OverwriteExisting when src.Current is set. Call SaveConfig to persist.
+func ImportProfiles(dst *Config, src Config, strategy MergeStrategy) (ImportResult, error) {
+	var result ImportResult
+	if dst == nil {
+		return result, fmt.Errorf("❌ cannot import into a nil config")
+	}
+	if _, ok := mergeStrategyNames[strategy]; !ok {
+		return result, fmt.Errorf("❌ invalid merge strategy %s", strategy)
+	}
+	if dst.Profiles == nil {
+		dst.Profiles = make(map[string]Profile)
+	}
+
+	names := make([]string, 0, len(src.Profiles))
+	for name := range src.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		profile := src.Profiles[name]
+		if err := ValidateProfileName(name); err != nil {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Skipping invalid profile [%s]: %v\n"), name, err)
+			result.Invalid = append(result.Invalid, name)
+			continue
+		}
+		if err := ValidateProfile(&profile); err != nil {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Skipping invalid profile [%s]: %v\n"), name, err)
+			result.Invalid = append(result.Invalid, name)
+			continue
+		}
+
+		existing, exists := dst.Profiles[name]
+		if exists {
+			if !strings.EqualFold(existing.Username, profile.Username) {
+				fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Import conflict: profile [%s] is '%s' in the import but '%s' locally\n"),
+					name, profile.Username, existing.Username)
+			}
+
+			replace := strategy == OverwriteExisting ||
+				(strategy == KeepNewer && updatedAfter(profile.UpdatedAt, existing.UpdatedAt))
+			if !replace {
+				result.Skipped = append(result.Skipped, name)
+				continue
+			}
+		}
+
+		if err := AddProfile(dst, name, profile, true); err != nil {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Skipping invalid profile [%s]: %v\n"), name, err)
+			result.Invalid = append(result.Invalid, name)
+			continue
+		}
+		// AddProfile stamps the current time; keep the source's for KeepNewer
+		if profile.UpdatedAt != nil {
+			updatedAt := *profile.UpdatedAt
+			added := dst.Profiles[name]
+			added.UpdatedAt = &updatedAt
+			dst.Profiles[name] = added
+		}
+		result.Imported = append(result.Imported, name)
+	}
+
+	if strategy == OverwriteExisting && src.Current != "" {
+		if _, exists := dst.Profiles[src.Current]; exists {
+			dst.Current = src.Current
+		}
+	}
+
+	return result, nil
+}
+
+// updatedAfter reports whether UpdatedAt a is later than b; a profile
+// without one counts as never updated
+func updatedAfter(a, b *time.Time) bool {
+	if a == nil {
+		return false
+	}
+	return b == nil || a.After(*b)
+}
@@ -1,5 +1,12 @@
 package config
 
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"sort"
+	"strings"
+)
+
 // Manager handles configuration operations
 type Manager struct {
 	configDir string
@@ -25,6 +32,41 @@ func (m *Manager) GetProfiles() (map[string]Profile, string, error) {
 	return validConfig.Profiles, validConfig.Current, nil
 }
 
+// GetProfile returns the profile with the given name or alias, and its name
+func (m *Manager) GetProfile(name string) (Profile, string, error) {
+	validConfig, _, ioErr := LoadConfig()
+	if ioErr != nil {
+		return Profile{}, "", ioErr
+	}
+	m.config = &validConfig
+
+	resolved := ResolveAlias(&validConfig, name)
+	profile, exists := validConfig.Profiles[resolved]
+	if !exists {
+		return Profile{}, "", utils.Errorf(ErrProfileNotFound, "❌ profile '%s' does not exist", name)
+	}
+	return profile, resolved, nil
+}
+
+// SetConfig replaces the configuration cached by the manager, e.g. with one
+// reloaded by WatchConfig
+func (m *Manager) SetConfig(config Config) {
+	m.config = &config
+}
+
+// ListProfileNames returns the profile names in display order (see ListProfileNames)
+func (m *Manager) ListProfileNames() ([]string, error) {
+	if m.config == nil {
+		validConfig, _, ioErr := LoadConfig()
+		if ioErr != nil {
+			return nil, ioErr
+		}
+		m.config = &validConfig
+	}
+
+	return ListProfileNames(m.config), nil
+}
+
 // GetCurrent returns the name of the current active profile
 func (m *Manager) GetCurrent() string {
 	if m.config == nil {
@@ -41,6 +83,48 @@ func (m *Manager) GetCurrent() string {
 	return m.config.Current
 }
 
+// PreferLocalScope reports whether switches should only change the
+// repository's Git config (see Config.PreferLocalScope)
+func (m *Manager) PreferLocalScope() bool {
+	if m.config == nil {
+		validConfig, _, ioErr := LoadConfig()
+		if ioErr != nil {
+			return false
+		}
+		m.config = &validConfig
+	}
+
+	return m.config.PreferLocalScope
+}
+
+// AutoSwitch reports whether the profile for a repository may come from its
+// .gatprofile or .gat pin file (see Config.AutoSwitch)
+func (m *Manager) AutoSwitch() bool {
+	if m.config == nil {
+		validConfig, _, ioErr := LoadConfig()
+		if ioErr != nil {
+			return false
+		}
+		m.config = &validConfig
+	}
+
+	return m.config.AutoSwitch
+}
+
+// DefaultAuthMethod returns the auth method of profiles added without one
+// (see Config.DefaultAuthMethod)
+func (m *Manager) DefaultAuthMethod() string {
+	if m.config == nil {
+		validConfig, _, ioErr := LoadConfig()
+		if ioErr != nil {
+			return ""
+		}
+		m.config = &validConfig
+	}
+
+	return m.config.DefaultAuthMethod
+}
+
 // AddProfile adds a new profile
 func (m *Manager) AddProfile(name string, profile Profile, overwrite bool) error {
 	if m.config == nil {
@@ -103,3 +187,53 @@ func (m *Manager) RemoveProfile(name string, noBackup bool) error {
 	// Pass m.config (which is now *Config) directly
 	return SaveConfig(m.config)
 }
+
+// GetProfileByUsername returns the first profile (in name order) whose username
+// and platform match, along with its name. Usernames are compared
+// case-insensitively since Git hosting platforms treat them that way.
+func (m *Manager) GetProfileByUsername(username, platform string) (*Profile, string, error) {
+	profiles, names, err := m.GetProfilesByUsername(username, platform)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(profiles) == 0 {
+		return nil, "", fmt.Errorf("❌ no profile found for username '%s' on platform '%s'", username, platform)
+	}
+
+	return profiles[0], names[0], nil
+}
+
+// GetProfilesByUsername returns every profile whose username and platform match,
+// for callers that need to handle several profiles sharing one account
+func (m *Manager) GetProfilesByUsername(username, platform string) ([]*Profile, []string, error) {
+	if m.config == nil {
+		// Load config if not already loaded
+		// Handle errors, ignore validation errors for now in Manager
+		validConfig, _, ioErr := LoadConfig()
+		if ioErr != nil {
+			return nil, nil, ioErr
+		}
+		m.config = &validConfig // Assign address of validConfig
+	}
+
+	// Iterate in name order so "first match" is deterministic
+	var sortedNames []string
+	for name := range m.config.Profiles {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	platform = strings.ToLower(platform)
+
+	var profiles []*Profile
+	var names []string
+	for _, name := range sortedNames {
+		profile := m.config.Profiles[name]
+		if strings.EqualFold(profile.Username, username) && profile.GetPlatform() == platform {
+			profiles = append(profiles, &profile)
+			names = append(names, name)
+		}
+	}
+
+	return profiles, names, nil
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ReadConfigFile reads a gat credentials file from an arbitrary path without
+// creating it or filtering invalid profiles. Encrypted tokens are decrypted with
+// the file's own salt so they can be re-encrypted under a different config.
+func ReadConfigFile(path string) (Config, error) {
+	return readConfigFile(path, "")
+}
+
+// ReadImportFile reads a file for 'gat import': a credentials file or an
+// export written by 'gat export'. Exports carry no salt of their own, so
+// encrypted tokens in them are decrypted with current's encryption secret.
+// Tokens encrypted with an export passphrase are left for
+// DecryptExportTokens.
+func ReadImportFile(path string, current *Config) (Config, error) {
+	return readConfigFile(path, current.EncryptionSecret())
+}
+
+// readConfigFile implements ReadConfigFile, decrypting tokens with
+// fallbackSecret when the file has no salt of its own
+func readConfigFile(path, fallbackSecret string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("❌ could not read config file '%s': %w", path, err)
+	}
+
+	data, err = decodeConfigData(data)
+	if err != nil {
+		return Config{}, fmt.Errorf("❌ could not parse config file '%s': %w", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("❌ could not parse config file '%s': %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Profile)
+	}
+	if cfg.UsePasswordEncryption {
+		if err := cfg.unlockPassword(); err != nil {
+			return Config{}, fmt.Errorf("❌ could not unlock '%s': %w", path, err)
+		}
+	}
+
+	secret := cfg.EncryptionSecret()
+	if cfg.Salt == "" && fallbackSecret != "" {
+		secret = fallbackSecret
+	}
+	for name, err := range loadKeychainTokens(&cfg) {
+		return Config{}, fmt.Errorf("❌ could not read the token of profile '%s' in '%s': %w", name, path, err)
+	}
+	for name, profile := range cfg.Profiles {
+		if strings.HasPrefix(profile.Token, exportTokenPrefix) || profile.tokenInKeychain() {
+			continue
+		}
+		if strings.HasPrefix(profile.Token, "enc:") {
+			decryptedToken, err := DecryptToken(profile.Token, secret)
+			if err != nil {
+				return Config{}, fmt.Errorf("❌ could not decrypt token for profile '%s' in '%s': %w", name, path, err)
+			}
+			profile.rawToken = decryptedToken
+		} else {
+			profile.rawToken = profile.Token
+		}
+		cfg.Profiles[name] = profile
+	}
+
+	return cfg, nil
+}
+
+// MergeConfigs combines a shared base config with a user's overlay config.
+// The result is a copy of the overlay, since it is the user's own file: its
+// aliases, templates, webhooks and security and behaviour settings are kept.
+// Base profiles are layered underneath, so overlay profiles replace base
+// profiles with the same name, and overlay.Current wins when set. The returned
+// slice lists profile names present in both configs with different usernames,
+// so callers can report them.
+func MergeConfigs(base, overlay *Config) (*Config, []string, error) {
+	if base == nil || overlay == nil {
+		return nil, nil, fmt.Errorf("❌ cannot merge a nil config")
+	}
+
+	merged := cloneConfig(*overlay)
+	merged.Current = base.Current
+	merged.Profiles = make(map[string]Profile, len(base.Profiles)+len(overlay.Profiles))
+
+	for name, profile := range base.Profiles {
+		if err := ValidateProfileName(name); err != nil {
+			return nil, nil, fmt.Errorf("❌ base profile '%s': %w", name, err)
+		}
+		if err := ValidateProfile(&profile); err != nil {
+			return nil, nil, fmt.Errorf("❌ base profile '%s': %w", name, err)
+		}
+		merged.Profiles[name] = cloneProfile(profile)
+	}
+
+	var conflicts []string
+	for name, profile := range overlay.Profiles {
+		if baseProfile, exists := merged.Profiles[name]; exists && !strings.EqualFold(baseProfile.Username, profile.Username) {
+			conflicts = append(conflicts, name)
+		}
+		merged.Profiles[name] = cloneProfile(profile)
+	}
+	sort.Strings(conflicts)
+
+	if overlay.Current != "" {
+		merged.Current = overlay.Current
+	}
+
+	return &merged, conflicts, nil
+}
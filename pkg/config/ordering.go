@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"slices"
+	"sort"
+)
+
+// ListProfileNames returns the names of cfg's profiles in display order:
+// those in ProfileOrdering first, in that order, then the rest alphabetically.
+// Names in ProfileOrdering without a profile are skipped.
+func ListProfileNames(cfg *Config) []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	listed := make(map[string]bool, len(cfg.ProfileOrdering))
+	for _, name := range cfg.ProfileOrdering {
+		if _, exists := cfg.Profiles[name]; exists && !listed[name] {
+			listed[name] = true
+			names = append(names, name)
+		}
+	}
+
+	var rest []string
+	for name := range cfg.Profiles {
+		if !listed[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// SetProfileOrdering stores the display order of profiles. Every name must be
+// an existing profile (aliases are resolved) and appear once; profiles left
+// out keep their place after the listed ones.
+func SetProfileOrdering(cfg *Config, names []string) error {
+	ordering := make([]string, 0, len(names))
+	for _, name := range names {
+		name = ResolveAlias(cfg, name)
+		if _, exists := cfg.Profiles[name]; !exists {
+			return utils.Errorf(ErrProfileNotFound, "❌ profile '%s' does not exist", name)
+		}
+		if slices.Contains(ordering, name) {
+			return fmt.Errorf("❌ profile '%s' is listed more than once", name)
+		}
+		ordering = append(ordering, name)
+	}
+	cfg.ProfileOrdering = ordering
+	return nil
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// PasswordEnvVar supplies the encryption password non-interactively
+const PasswordEnvVar = "GAT_PASSWORD"
+
+// readablePasswordWords is the number of words in GenerateReadablePassword
+const readablePasswordWords = 4
+
+// Argon2id parameters for deriving the token key from the password
+// (the RFC 9106 second recommended option)
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+	argon2KeyLen  = 32
+)
+
+// passwordCheckValue is encrypted into Config.PasswordCheck so a wrong
+// password is detected before any token is decrypted
+const passwordCheckValue = "gat-password-check"
+
+// PasswordPrompt asks for the encryption password when GAT_PASSWORD is not
+// set. It is nil unless the CLI runs interactively.
+var PasswordPrompt func() (string, error)
+
+// wordlistData is the EFF large wordlist (7776 words, "<dice rolls>\t<word>"),
+// https://www.eff.org/dice, licensed CC BY 3.0 US
+//
+//go:embed wordlist.txt
+var wordlistData string
+
+// wordlist returns the words of wordlistData in dice-roll order
+var wordlist = sync.OnceValue(func() []string {
+	var words []string
+	for _, line := range strings.Split(strings.TrimSpace(wordlistData), "\n") {
+		if _, word, found := strings.Cut(line, "\t"); found {
+			words = append(words, strings.TrimSpace(word))
+		}
+	}
+	return words
+})
+
+// GenerateReadablePassword returns a diceware-style passphrase of four
+// random words from the EFF large wordlist joined by '-' (about 51 bits of
+// entropy), e.g. "stuffy-unsnap-ravage-cabbage"
+func GenerateReadablePassword() string {
+	words := wordlist()
+	chosen := make([]string, readablePasswordWords)
+	for i := range chosen {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(words))))
+		if err != nil {
+			panic(fmt.Sprintf("could not read random data: %v", err))
+		}
+		chosen[i] = words[n.Int64()]
+	}
+	return strings.Join(chosen, "-")
+}
+
+// derivePasswordSecret derives the token encryption secret from a password
+// and the config salt with Argon2id
+func derivePasswordSecret(password, salt string) string {
+	key := argon2.IDKey([]byte(password), []byte(salt), argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// EncryptionSecret returns the secret tokens are encrypted with: the
+// password-derived key in password mode, otherwise the salt
+func (c *Config) EncryptionSecret() string {
+	if c.UsePasswordEncryption {
+		return c.secret
+	}
+	return c.Salt
+}
+
+// EnablePasswordEncryption encrypts every stored token with a key derived
+// from password (Argon2id) and a fresh salt, replacing any earlier
+// encryption. It returns the sorted names of the profiles whose tokens were
+// encrypted. Call SaveConfig to persist.
+func EnablePasswordEncryption(cfg *Config, password string) ([]string, error) {
+	if password == "" {
+		return nil, fmt.Errorf("❌ the encryption password cannot be empty")
+	}
+
+	newSalt := GenerateSalt()
+	secret := derivePasswordSecret(password, newSalt)
+
+	var affected []string
+	for name, profile := range cfg.Profiles {
+		token := profile.GetToken()
+		if token == "" {
+			continue
+		}
+		if strings.HasPrefix(token, "enc:") {
+			return nil, fmt.Errorf("❌ token for profile '%s' could not be decrypted; re-add it before changing encryption", name)
+		}
+		profile.SetToken(token, true, secret)
+		cfg.Profiles[name] = profile
+		affected = append(affected, name)
+	}
+
+	cfg.Salt = newSalt
+	cfg.StoreEncrypted = true
+	cfg.UsePasswordEncryption = true
+	cfg.PasswordCheck = EncryptToken(passwordCheckValue, secret)
+	cfg.secret = secret
+	sort.Strings(affected)
+	return affected, nil
+}
+
+// unlockPassword derives the encryption secret of a password-protected
+// config from GAT_PASSWORD or PasswordPrompt and checks it against
+// PasswordCheck
+func (c *Config) unlockPassword() error {
+	password := os.Getenv(PasswordEnvVar)
+	if password == "" {
+		if PasswordPrompt == nil {
+			return fmt.Errorf("❌ tokens are protected by a password; set %s or run gat interactively", PasswordEnvVar)
+		}
+		var err error
+		if password, err = PasswordPrompt(); err != nil {
+			return fmt.Errorf("❌ could not read the encryption password: %w", err)
+		}
+	}
+
+	secret := derivePasswordSecret(password, c.Salt)
+	if check, err := DecryptToken(c.PasswordCheck, secret); err != nil || check != passwordCheckValue {
+		return fmt.Errorf("❌ wrong encryption password")
+	}
+	c.secret = secret
+	return nil
+}
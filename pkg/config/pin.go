@@ -0,0 +1,130 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PinFileName is the file 'gat pin' writes to pin a repository to a profile.
+// It holds a line "profile = <name>"; '#' starts a comment.
+const PinFileName = ".gat"
+
+// pinProfileKey is the key of the profile line in a pin file
+const pinProfileKey = "profile"
+
+// FindRepoProfile looks for a pin file in the current directory and its
+// parents, up to the home directory (or the filesystem root outside of it),
+// and returns the pinned profile name and the file's path. Both are empty if
+// no pin file is found. The ~/.gat config directory is not a pin file.
+func FindRepoProfile() (string, string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("❌ could not get current directory: %w", err)
+	}
+	home, _ := os.UserHomeDir()
+
+	for {
+		path := filepath.Join(dir, PinFileName)
+		name, err := ReadPinFile(path)
+		if err != nil {
+			return "", path, err
+		}
+		if name != "" {
+			return name, path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == home || parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// ReadPinFile returns the profile named by a pin file. A missing file, or a
+// directory such as ~/.gat, yields "".
+func ReadPinFile(path string) (string, error) {
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("❌ could not read %s: %w", path, err)
+		}
+		return "", nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("❌ could not read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(key) != pinProfileKey {
+			continue
+		}
+		name := strings.Trim(strings.TrimSpace(value), `"`)
+		if err := ValidateProfileName(name); err != nil {
+			return "", fmt.Errorf("❌ invalid profile name in %s: %w", path, err)
+		}
+		return name, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("❌ could not read %s: %w", path, err)
+	}
+	return "", nil
+}
+
+// WritePinFile pins dir to a profile by writing its pin file, replacing any
+// earlier pin. It returns the file's path.
+func WritePinFile(dir, name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, PinFileName)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return "", fmt.Errorf("❌ %s is a directory", path)
+	}
+
+	content := fmt.Sprintf("# Profile for this repository, written by 'gat pin'\n%s = %s\n", pinProfileKey, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("❌ could not write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// IsActiveProfile reports whether name, or the profile it is an alias for,
+// is the active profile, and returns the active profile's name. It reads
+// the config file without validating profiles or unlocking tokens, for
+// checks that run before every command. A missing config file has no
+// active profile.
+func IsActiveProfile(name string) (bool, string, error) {
+	path, err := ConfigFilePath()
+	if err != nil {
+		return false, "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, "", nil
+	}
+	if err != nil {
+		return false, "", fmt.Errorf("❌ could not read config file: %w", err)
+	}
+	if data, err = decodeConfigData(data); err != nil {
+		return false, "", fmt.Errorf("❌ could not parse config file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return false, "", fmt.Errorf("❌ could not parse config file: %w", err)
+	}
+	return ResolveAlias(&cfg, name) == cfg.Current, cfg.Current, nil
+}
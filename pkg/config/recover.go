@@ -0,0 +1,118 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NoAutoRecoverEnvVar disables loading a backup when creds.json is corrupt
+// (set it to "1")
+const NoAutoRecoverEnvVar = "GAT_NO_AUTO_RECOVER"
+
+// lastLoadBackup is the backup the last successful LoadConfig fell back to
+var (
+	lastLoadBackupMu sync.Mutex
+	lastLoadBackup   string
+)
+
+// LastLoadBackup returns the path of the backup the last successful
+// LoadConfig used because creds.json could not be parsed, or "" if it
+// loaded the primary config file
+func LastLoadBackup() string {
+	lastLoadBackupMu.Lock()
+	defer lastLoadBackupMu.Unlock()
+	return lastLoadBackup
+}
+
+// setLastLoadBackup records which file the last successful load used
+func setLastLoadBackup(path string) {
+	lastLoadBackupMu.Lock()
+	defer lastLoadBackupMu.Unlock()
+	lastLoadBackup = path
+}
+
+// parseConfigData decodes the (possibly compressed) content of a
+// credentials file
+func parseConfigData(data []byte) (Config, error) {
+	data, err := decodeConfigData(data)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// ConfigBackupFiles returns the full config backups written by
+// BackupConfigFile (creds.<timestamp>.json), newest first by mtime
+func ConfigBackupFiles() ([]string, error) {
+	backupDir, err := BackupDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read backup directory: %w", err)
+	}
+
+	type backupFile struct {
+		path    string
+		modTime int64
+	}
+	var backups []backupFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasPrefix(name, "creds.") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // Removed since listing
+		}
+		backups = append(backups, backupFile{filepath.Join(backupDir, name), info.ModTime().UnixNano()})
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].modTime > backups[j].modTime
+	})
+
+	paths := make([]string, len(backups))
+	for i, backup := range backups {
+		paths[i] = backup.path
+	}
+	return paths, nil
+}
+
+// recoverConfigFromBackup parses the config backups newest first and
+// returns the first one that succeeds along with its path
+func recoverConfigFromBackup() (Config, string, error) {
+	if os.Getenv(NoAutoRecoverEnvVar) == "1" {
+		return Config{}, "", fmt.Errorf("automatic recovery disabled by %s", NoAutoRecoverEnvVar)
+	}
+
+	backups, err := ConfigBackupFiles()
+	if err != nil {
+		return Config{}, "", err
+	}
+	for _, path := range backups {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if cfg, err := parseConfigData(data); err == nil {
+			return cfg, path, nil
+		}
+	}
+	return Config{}, "", fmt.Errorf("no readable config backup found")
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"gat/pkg/secret"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Values of Config.SecretBackend
+const (
+	SecretBackendFile     = secret.BackendFile     // Tokens in the credentials file (the default)
+	SecretBackendKeychain = secret.BackendKeychain // Tokens in the OS keychain
+)
+
+// keychainTokenPrefix starts the stored Token of a profile whose token is in
+// the keychain, followed by its key there
+const keychainTokenPrefix = "keychain:"
+
+// keychainStore keeps the tokens of the keychain secret backend
+var keychainStore secret.SecretStore = secret.KeychainStore{}
+
+// SecretKey returns the key a profile's token is stored under outside the
+// credentials file: gat/<profile>
+func SecretKey(name string) string {
+	return "gat/" + name
+}
+
+// tokenInKeychain reports whether the profile's token is kept in the keychain
+func (p *Profile) tokenInKeychain() bool {
+	return strings.HasPrefix(p.Token, keychainTokenPrefix)
+}
+
+// loadKeychainTokens reads the tokens kept in the keychain into the profiles
+// of cfg and records their keys, so SaveConfig can delete the ones it no
+// longer uses. It returns the errors of the profiles whose token could not be
+// read; their token reads as empty.
+func loadKeychainTokens(cfg *Config) map[string]error {
+	errs := make(map[string]error)
+	for name, profile := range cfg.Profiles {
+		if !profile.tokenInKeychain() {
+			continue
+		}
+		key := strings.TrimPrefix(profile.Token, keychainTokenPrefix)
+		cfg.keychainKeys = append(cfg.keychainKeys, key)
+		token, err := keychainStore.Get(key)
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		profile.rawToken = token
+		cfg.Profiles[name] = profile
+	}
+	return errs
+}
+
+// saveKeychainToken stores the token of a profile in the keychain and
+// returns the Token to write to the credentials file in its place
+func saveKeychainToken(name, token string) (string, error) {
+	key := SecretKey(name)
+	if err := keychainStore.Set(key, token); err != nil {
+		return "", err
+	}
+	return keychainTokenPrefix + key, nil
+}
+
+// deleteKeychainTokens deletes the tokens LoadConfig read from the keychain
+// that cfg, as just saved, no longer refers to
+func deleteKeychainTokens(cfg *Config) {
+	used := make(map[string]bool)
+	for _, profile := range cfg.Profiles {
+		if profile.tokenInKeychain() {
+			used[strings.TrimPrefix(profile.Token, keychainTokenPrefix)] = true
+		}
+	}
+	for _, key := range cfg.keychainKeys {
+		if used[key] {
+			continue
+		}
+		if err := keychainStore.Delete(key); err != nil {
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: %v\n"), err)
+		}
+	}
+	var keys []string
+	for key := range used {
+		keys = append(keys, key)
+	}
+	cfg.keychainKeys = keys
+}
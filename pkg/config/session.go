@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SessionDir returns the directory holding session start files (~/.gat/sessions)
+func SessionDir() (string, error) {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configPath, "sessions"), nil
+}
+
+// sessionPath returns the session start file for a profile
+func sessionPath(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	dir, err := SessionDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".session"), nil
+}
+
+// StartSession records now as the start of a session for the profile,
+// replacing any earlier session
+func StartSession(name string) error {
+	path, err := sessionPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("❌ could not create session directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0600); err != nil {
+		return fmt.Errorf("❌ could not write session file: %w", err)
+	}
+	return nil
+}
+
+// SessionStart returns when the profile's session started. The error
+// satisfies os.IsNotExist if no session was recorded.
+func SessionStart(name string) (time.Time, error) {
+	path, err := sessionPath(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	start, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("❌ invalid session file %s: %w", path, err)
+	}
+	return start, nil
+}
+
+// EndSession removes the profile's session file, if any
+func EndSession(name string) error {
+	path, err := sessionPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("❌ could not remove session file: %w", err)
+	}
+	return nil
+}
+
+// SessionRemaining returns how much of the profile's MaxSessionDuration is
+// left for a session that started at start (negative once it has expired)
+func (p *Profile) SessionRemaining(start time.Time) time.Duration {
+	return p.MaxSessionDuration - time.Since(start)
+}
@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Setting describes a config-level option that can be changed with 'gat config set'
+type Setting struct {
+	Key         string
+	Type        string // "bool", "int" or "string"
+	Description string
+
+	get func(cfg *Config) string
+	set func(cfg *Config, value string) error
+}
+
+// Value returns the setting's current value in cfg as a string
+func (s Setting) Value(cfg *Config) string {
+	return s.get(cfg)
+}
+
+var settings = []Setting{
+	{
+		Key:         "auto-switch",
+		Type:        "bool",
+		Description: "Detect the profile to use for the current directory automatically",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.AutoSwitch) },
+		set: func(cfg *Config, value string) error {
+			return parseBoolSetting("auto-switch", value, &cfg.AutoSwitch)
+		},
+	},
+	{
+		Key:         "store-encrypted",
+		Type:        "bool",
+		Description: "Encrypt stored tokens",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.StoreEncrypted) },
+		set: func(cfg *Config, value string) error {
+			return parseBoolSetting("store-encrypted", value, &cfg.StoreEncrypted)
+		},
+	},
+	{
+		Key:         "no-store-tokens",
+		Type:        "bool",
+		Description: "Never write tokens to the credentials file",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.NoStoreTokens) },
+		set: func(cfg *Config, value string) error {
+			return parseBoolSetting("no-store-tokens", value, &cfg.NoStoreTokens)
+		},
+	},
+	{
+		Key:         "secret-backend",
+		Type:        "string",
+		Description: "Where tokens are kept: file (the credentials file) or keychain (the OS keychain)",
+		get: func(cfg *Config) string {
+			if cfg.SecretBackend == "" {
+				return SecretBackendFile
+			}
+			return cfg.SecretBackend
+		},
+		set: func(cfg *Config, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value != SecretBackendFile && value != SecretBackendKeychain {
+				return fmt.Errorf("❌ invalid value '%s' for secret-backend: must be %s or %s", value, SecretBackendFile, SecretBackendKeychain)
+			}
+			cfg.SecretBackend = value
+			return nil
+		},
+	},
+	{
+		Key:         "compress",
+		Type:        "bool",
+		Description: "Write the credentials file as gzip-compressed JSON",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.Compress) },
+		set: func(cfg *Config, value string) error {
+			return parseBoolSetting("compress", value, &cfg.Compress)
+		},
+	},
+	{
+		Key:         "strict-email-validation",
+		Type:        "bool",
+		Description: "Reject profile emails that are not RFC 5321 compliant instead of warning",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.StrictEmailValidation) },
+		set: func(cfg *Config, value string) error {
+			return parseBoolSetting("strict-email-validation", value, &cfg.StrictEmailValidation)
+		},
+	},
+	{
+		Key:         "prefer-local-scope",
+		Type:        "bool",
+		Description: "Set the Git identity in the repository's .git/config on switch, never in ~/.gitconfig",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.PreferLocalScope) },
+		set: func(cfg *Config, value string) error {
+			return parseBoolSetting("prefer-local-scope", value, &cfg.PreferLocalScope)
+		},
+	},
+	{
+		Key:         "max-backups",
+		Type:        "int",
+		Description: "Number of backups kept per profile",
+		get: func(cfg *Config) string {
+			if cfg.MaxBackups < 1 {
+				return "1"
+			}
+			return strconv.Itoa(cfg.MaxBackups)
+		},
+		set: func(cfg *Config, value string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 1 {
+				return fmt.Errorf("❌ invalid value '%s' for max-backups: must be a positive integer", value)
+			}
+			cfg.MaxBackups = n
+			return nil
+		},
+	},
+	{
+		Key:         "default-auth-method",
+		Type:        "string",
+		Description: "Auth method for profiles added without --auth-method (empty: infer from --ssh-identity)",
+		get:         func(cfg *Config) string { return cfg.DefaultAuthMethod },
+		set: func(cfg *Config, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value != "" && value != "ssh" && value != "https" {
+				return fmt.Errorf("❌ invalid value '%s' for default-auth-method: must be ssh, https or empty", value)
+			}
+			cfg.DefaultAuthMethod = value
+			return nil
+		},
+	},
+}
+
+// ListSettings returns all config-level settings in display order
+func ListSettings() []Setting {
+	return settings
+}
+
+// LookupSetting finds a setting by key; secret_backend finds secret-backend
+func LookupSetting(key string) (Setting, error) {
+	for _, s := range settings {
+		if s.Key == strings.ReplaceAll(strings.ToLower(key), "_", "-") {
+			return s, nil
+		}
+	}
+	return Setting{}, fmt.Errorf("❌ unknown setting '%s'. Run 'gat config list' to see available settings", key)
+}
+
+// SetSetting validates value and applies it to cfg. Changing how or where
+// tokens are stored re-applies the policy to existing tokens on the next
+// SaveConfig, which moves them to or from the keychain.
+func SetSetting(cfg *Config, key, value string) error {
+	s, err := LookupSetting(key)
+	if err != nil {
+		return err
+	}
+	if err := s.set(cfg, value); err != nil {
+		return err
+	}
+
+	if s.Key == "store-encrypted" || s.Key == "no-store-tokens" || s.Key == "secret-backend" {
+		for name, profile := range cfg.Profiles {
+			token := profile.GetToken()
+			if token == "" || strings.HasPrefix(token, "enc:") {
+				continue
+			}
+			profile.SetToken(token, cfg.StoreEncrypted, cfg.EncryptionSecret())
+			cfg.Profiles[name] = profile
+		}
+	}
+	return nil
+}
+
+// parseBoolSetting parses a bool setting value into dst
+func parseBoolSetting(key, value string, dst *bool) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("❌ invalid value '%s' for %s: must be true or false", value, key)
+	}
+	*dst = b
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"gat/pkg/utils"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// MaxTagLength is the longest tag ValidateTag accepts
+const MaxTagLength = 32
+
+// validTagRegex matches a profile tag: letters, digits and hyphens
+var validTagRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// ValidateTag checks that a profile tag is made of letters, digits and
+// hyphens and at most MaxTagLength characters long
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return utils.Errorf(ErrInvalidProfile, "❌ tags cannot be empty")
+	}
+	if len(tag) > MaxTagLength {
+		return utils.Errorf(ErrInvalidProfile, "❌ tag '%s' is longer than %d characters", tag, MaxTagLength)
+	}
+	if !validTagRegex.MatchString(tag) {
+		return utils.Errorf(ErrInvalidProfile, "❌ invalid tag '%s': use letters, digits and hyphens", tag)
+	}
+	return nil
+}
+
+// ValidateTags runs ValidateTag on each of a profile's tags
+func ValidateTags(tags []string) error {
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasTags reports whether the profile has every one of tags, ignoring case
+func (p *Profile) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(p.Tags, func(own string) bool { return strings.EqualFold(own, tag) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// ProfilesWithTags returns the names of cfg's profiles that have every one of
+// tags, in display order (see ListProfileNames)
+func ProfilesWithTags(cfg *Config, tags []string) []string {
+	var names []string
+	for _, name := range ListProfileNames(cfg) {
+		profile := cfg.Profiles[name]
+		if profile.HasTags(tags) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ListTags returns the sorted tags used by cfg's profiles, each once
+func ListTags(cfg *Config) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, profile := range cfg.Profiles {
+		for _, tag := range profile.Tags {
+			if !seen[strings.ToLower(tag)] {
+				seen[strings.ToLower(tag)] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TemplateNamePlaceholder is replaced with the profile name when a template is used
+const TemplateNamePlaceholder = "{name}"
+
+// ProfileTemplate holds defaults shared by profiles created with
+// 'gat template use', such as an organization's platform and auth method.
+// SSHIdentityTemplate and TagsTemplate may contain {name}.
+type ProfileTemplate struct {
+	Name                string `json:"name"`
+	Platform            string `json:"platform,omitempty"`
+	AuthMethod          string `json:"auth_method,omitempty"`
+	Host                string `json:"host,omitempty"`
+	SSHIdentityTemplate string `json:"ssh_identity_template,omitempty"`
+
+	// Comma-separated tags of the profiles (see Profile.Tags)
+	TagsTemplate string `json:"tags_template,omitempty"`
+}
+
+// AddTemplate stores a template under its name, replacing any template with
+// the same name. Names follow the profile name rules.
+func AddTemplate(cfg *Config, template ProfileTemplate) error {
+	if err := ValidateProfileName(template.Name); err != nil {
+		return fmt.Errorf("❌ invalid template name: %w", err)
+	}
+	template.AuthMethod = strings.ToLower(template.AuthMethod)
+	if template.AuthMethod != "" && template.AuthMethod != "ssh" && template.AuthMethod != "https" {
+		return fmt.Errorf("❌ invalid auth_method '%s'. Must be 'ssh' or 'https'", template.AuthMethod)
+	}
+	template.Platform = strings.ToLower(template.Platform)
+
+	if cfg.Templates == nil {
+		cfg.Templates = make(map[string]ProfileTemplate)
+	}
+	cfg.Templates[template.Name] = template
+	return nil
+}
+
+// RemoveTemplate deletes a template; profiles created from it are kept
+func RemoveTemplate(cfg *Config, name string) error {
+	if _, exists := cfg.Templates[name]; !exists {
+		return fmt.Errorf("❌ template '%s' does not exist", name)
+	}
+	delete(cfg.Templates, name)
+	return nil
+}
+
+// Expand replaces {name} in a template value with the profile name
+func (t ProfileTemplate) Expand(value, profileName string) string {
+	return strings.ReplaceAll(value, TemplateNamePlaceholder, profileName)
+}
+
+// NewProfile returns a profile for profileName with the template's defaults.
+// Without an auth method the profile uses SSH if the template has an SSH
+// identity and HTTPS otherwise; the platform defaults to github.
+func (t ProfileTemplate) NewProfile(profileName string) Profile {
+	profile := Profile{
+		Platform:   t.Platform,
+		Host:       t.Host,
+		AuthMethod: t.AuthMethod,
+	}
+	profile.SetSSHIdentity(t.Expand(t.SSHIdentityTemplate, profileName))
+	for _, tag := range strings.Split(t.Expand(t.TagsTemplate, profileName), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			profile.Tags = append(profile.Tags, tag)
+		}
+	}
+	if profile.Platform == "" {
+		profile.Platform = "github"
+	}
+	if profile.AuthMethod == "" {
+		profile.AuthMethod = "https"
+		if profile.SSHIdentity() != "" {
+			profile.AuthMethod = "ssh"
+		}
+	}
+	return profile
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenExpiryLayout is the format of token expiry dates, as given to
+// 'gat add --token-expiry' and 'gat token set-expiry'
+const TokenExpiryLayout = "2006-01-02"
+
+// TokenExpiryWarning is how long before its expiry a token counts as
+// expiring soon
+const TokenExpiryWarning = 30 * 24 * time.Hour
+
+// ParseTokenExpiry parses a token expiry date (YYYY-MM-DD) as the start of
+// that day in local time. "" and "none" return nil, for no expiry.
+func ParseTokenExpiry(date string) (*time.Time, error) {
+	date = strings.TrimSpace(date)
+	if date == "" || strings.EqualFold(date, "none") {
+		return nil, nil
+	}
+	expiry, err := time.ParseInLocation(TokenExpiryLayout, date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("❌ invalid token expiry '%s'; use a date like 2025-12-31", date)
+	}
+	return &expiry, nil
+}
+
+// SetTokenExpiry sets when the profile's token expires (nil for never) and
+// updates TokenExpired and TokenExpirySoon
+func (p *Profile) SetTokenExpiry(expiry *time.Time) {
+	p.TokenExpiry = expiry
+	p.updateTokenExpiry(time.Now())
+}
+
+// TokenExpired reports whether the profile's token had expired when the
+// config was loaded or the expiry was last set
+func (p *Profile) TokenExpired() bool {
+	return p.tokenExpired
+}
+
+// TokenExpirySoon reports whether the profile's token expires within
+// TokenExpiryWarning but had not expired yet, like TokenExpired
+func (p *Profile) TokenExpirySoon() bool {
+	return p.tokenExpirySoon
+}
+
+// updateTokenExpiry sets tokenExpired and tokenExpirySoon for the time now
+func (p *Profile) updateTokenExpiry(now time.Time) {
+	p.tokenExpired, p.tokenExpirySoon = false, false
+	if p.TokenExpiry == nil {
+		return
+	}
+	if !now.Before(*p.TokenExpiry) {
+		p.tokenExpired = true
+	} else if p.TokenExpiry.Sub(now) <= TokenExpiryWarning {
+		p.tokenExpirySoon = true
+	}
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// passwordTokenPrefix marks a token encrypted with EncryptTokenWithPassword
+const passwordTokenPrefix = "pwenc:"
+
+// tokenPasswordTTL is how long UnlockProfileToken remembers a password
+const tokenPasswordTTL = 5 * time.Minute
+
+// Upper bounds for the Argon2 parameters read from a token, so a tampered
+// config cannot make decryption allocate unbounded memory
+const (
+	maxArgon2Time    = 16
+	maxArgon2Memory  = 1024 * 1024 // KiB (1 GiB)
+	maxArgon2Threads = 64
+)
+
+// cachedTokenPassword is an entry of tokenPasswords
+type cachedTokenPassword struct {
+	password string
+	expires  time.Time
+}
+
+// tokenPasswords caches token passwords per profile for tokenPasswordTTL
+var tokenPasswords = struct {
+	sync.Mutex
+	entries map[string]cachedTokenPassword
+}{entries: make(map[string]cachedTokenPassword)}
+
+// EncryptTokenWithPassword encrypts a token with AES-256-GCM under a key
+// derived from password and a random per-token salt with Argon2id. The result
+// records the Argon2 parameters and salt, PHC style:
+//
+//	pwenc:argon2id$v=19$m=65536,t=3,p=4$<salt>$<nonce+ciphertext>
+func EncryptTokenWithPassword(token, password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("❌ the token password cannot be empty")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", fmt.Errorf("❌ could not generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+	gcm, err := newTokenGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("❌ could not generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(token), nil)
+
+	return fmt.Sprintf("%sargon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", passwordTokenPrefix, argon2.Version,
+		argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(sealed)), nil
+}
+
+// DecryptTokenWithPassword decrypts a token written by
+// EncryptTokenWithPassword, using the Argon2 parameters stored in it
+func DecryptTokenWithPassword(encrypted, password string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(encrypted, passwordTokenPrefix), "$")
+	if !strings.HasPrefix(encrypted, passwordTokenPrefix) || len(parts) != 5 || parts[0] != "argon2id" {
+		return "", fmt.Errorf("not a password-encrypted token")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[1], "v=%d", &version); err != nil || version != argon2.Version {
+		return "", fmt.Errorf("unsupported argon2 version '%s'", parts[1])
+	}
+	var memory, iterations uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[2], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return "", fmt.Errorf("invalid argon2 parameters '%s'", parts[2])
+	}
+	if iterations == 0 || iterations > maxArgon2Time || memory == 0 || memory > maxArgon2Memory || threads == 0 || threads > maxArgon2Threads {
+		return "", fmt.Errorf("argon2 parameters out of range '%s'", parts[2])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return "", fmt.Errorf("invalid salt: %w", err)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, iterations, memory, threads, argon2KeyLen)
+	gcm, err := newTokenGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("wrong password or corrupted token")
+	}
+	return string(plaintext), nil
+}
+
+// newTokenGCM returns an AES-256-GCM cipher for key
+func newTokenGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not create cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// IsPasswordProtected reports whether the profile's token is encrypted with
+// its own password and has not been unlocked (see UnlockProfileToken)
+func (p *Profile) IsPasswordProtected() bool {
+	return p.rawToken == "" && strings.HasPrefix(p.Token, passwordTokenPrefix)
+}
+
+// SetPasswordProtectedToken encrypts token with EncryptTokenWithPassword and
+// stores it as is, regardless of the config's encryption settings
+func (p *Profile) SetPasswordProtectedToken(token, password string) error {
+	encrypted, err := EncryptTokenWithPassword(token, password)
+	if err != nil {
+		return err
+	}
+	p.Token = encrypted
+	p.rawToken = ""
+	return nil
+}
+
+// UnlockProfileToken decrypts a password-protected token in memory so
+// GetToken returns it; SaveConfig keeps the stored Token encrypted. The
+// password comes from a cached entry (kept for 5 minutes), GAT_PASSWORD or
+// PasswordPrompt.
+func UnlockProfileToken(name string, profile *Profile) error {
+	if !profile.IsPasswordProtected() {
+		return nil
+	}
+
+	tokenPasswords.Lock()
+	cached, ok := tokenPasswords.entries[name]
+	tokenPasswords.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		if token, err := DecryptTokenWithPassword(profile.Token, cached.password); err == nil {
+			profile.rawToken = token
+			return nil
+		}
+	}
+
+	password := os.Getenv(PasswordEnvVar)
+	if password == "" {
+		if PasswordPrompt == nil {
+			return fmt.Errorf("❌ the token of profile '%s' is protected by a password; set %s or run gat interactively", name, PasswordEnvVar)
+		}
+		var err error
+		if password, err = PasswordPrompt(); err != nil {
+			return fmt.Errorf("❌ could not read the token password: %w", err)
+		}
+	}
+
+	token, err := DecryptTokenWithPassword(profile.Token, password)
+	if err != nil {
+		return fmt.Errorf("❌ could not unlock the token of profile '%s': %w", name, err)
+	}
+	profile.rawToken = token
+
+	tokenPasswords.Lock()
+	tokenPasswords.entries[name] = cachedTokenPassword{password: password, expires: time.Now().Add(tokenPasswordTTL)}
+	tokenPasswords.Unlock()
+	return nil
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long WatchConfig waits after the last write before
+// reloading, so an editor's or SaveConfig's burst of events reloads once
+const watchDebounce = 500 * time.Millisecond
+
+// loadCache holds the last result of loadConfigFile, keyed by the file's
+// path, size and modification time
+var loadCache struct {
+	sync.Mutex
+	path    string
+	size    int64
+	modTime time.Time
+	config  Config
+	errors  map[string]error
+}
+
+// WatchConfig calls onChange with the reloaded configuration (valid profiles
+// and validation errors, as returned by LoadConfig) each time the file at
+// configPath is written, debounced by 500ms. The file's directory is watched
+// so that replacing the file is noticed too. WatchConfig blocks until ctx is
+// cancelled; reload failures are printed as warnings and do not stop it.
+func WatchConfig(ctx context.Context, configPath string, onChange func(Config, map[string]error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("❌ could not create config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	configPath = filepath.Clean(configPath)
+	if err := watcher.Add(filepath.Dir(configPath)); err != nil {
+		return fmt.Errorf("❌ could not watch %s: %w", filepath.Dir(configPath), err)
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == configPath && event.Has(fsnotify.Write|fsnotify.Create) {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: config watcher: %v\n"), err)
+		case <-debounce.C:
+			validConfig, validationErrors, err := loadConfigFile(ctx, configPath)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				fmt.Fprintf(WarningOutput, color.YellowString("⚠️ Warning: could not reload %s: %v\n"), configPath, err)
+				continue
+			}
+			onChange(validConfig, validationErrors)
+		}
+	}
+}
+
+// cachedLoad returns a copy of the cached load result if it was read from
+// configPath and the file has not changed since
+func cachedLoad(configPath string, info os.FileInfo) (Config, map[string]error, bool) {
+	loadCache.Lock()
+	defer loadCache.Unlock()
+	if loadCache.path != configPath || loadCache.size != info.Size() || !loadCache.modTime.Equal(info.ModTime()) {
+		return Config{}, nil, false
+	}
+	return cloneConfig(loadCache.config), cloneErrors(loadCache.errors), true
+}
+
+// storeLoad caches a load result for the file described by info
+func storeLoad(configPath string, info os.FileInfo, config Config, validationErrors map[string]error) {
+	loadCache.Lock()
+	defer loadCache.Unlock()
+	loadCache.path = configPath
+	loadCache.size = info.Size()
+	loadCache.modTime = info.ModTime()
+	loadCache.config = cloneConfig(config)
+	loadCache.errors = cloneErrors(validationErrors)
+}
+
+// invalidateLoadCache drops the cached load result, before the file is written
+func invalidateLoadCache() {
+	loadCache.Lock()
+	defer loadCache.Unlock()
+	loadCache.path = ""
+	loadCache.config = Config{}
+	loadCache.errors = nil
+}
+
+// cloneConfig copies a Config's maps, slices and pointers, those of its
+// profiles and webhooks included, so callers can modify the result without
+// touching the original
+func cloneConfig(config Config) Config {
+	profiles := make(map[string]Profile, len(config.Profiles))
+	for name, profile := range config.Profiles {
+		profiles[name] = cloneProfile(profile)
+	}
+	config.Profiles = profiles
+
+	config.Aliases = maps.Clone(config.Aliases)
+	config.ProfileOrdering = slices.Clone(config.ProfileOrdering)
+	config.Templates = maps.Clone(config.Templates)
+	config.keychainKeys = slices.Clone(config.keychainKeys)
+
+	if config.Webhooks != nil {
+		webhooks := make([]WebhookConfig, len(config.Webhooks))
+		for i, webhook := range config.Webhooks {
+			webhook.Headers = maps.Clone(webhook.Headers)
+			webhook.Events = slices.Clone(webhook.Events)
+			webhooks[i] = webhook
+		}
+		config.Webhooks = webhooks
+	}
+	return config
+}
+
+// cloneProfile copies a Profile's slices and pointers
+func cloneProfile(profile Profile) Profile {
+	profile.SSHIdentities = slices.Clone(profile.SSHIdentities)
+	profile.MirrorRemotes = slices.Clone(profile.MirrorRemotes)
+	profile.Tags = slices.Clone(profile.Tags)
+	if profile.TokenExpiry != nil {
+		expiry := *profile.TokenExpiry
+		profile.TokenExpiry = &expiry
+	}
+	if profile.UpdatedAt != nil {
+		updatedAt := *profile.UpdatedAt
+		profile.UpdatedAt = &updatedAt
+	}
+	return profile
+}
+
+// cloneErrors copies a validation error map
+func cloneErrors(validationErrors map[string]error) map[string]error {
+	clone := make(map[string]error, len(validationErrors))
+	for name, err := range validationErrors {
+		clone[name] = err
+	}
+	return clone
+}
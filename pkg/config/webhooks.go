@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Events that trigger webhooks
+const (
+	WebhookEventSwitch = "switch" // 'gat switch' activated a profile
+	WebhookEventAdd    = "add"    // 'gat add' added or updated a profile
+	WebhookEventRemove = "remove" // 'gat remove' removed a profile
+)
+
+// WebhookEvents lists the events webhooks can subscribe to
+var WebhookEvents = []string{WebhookEventSwitch, WebhookEventAdd, WebhookEventRemove}
+
+// WebhookConfig is a URL notified of profile changes, see 'gat webhook'
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method,omitempty"`  // POST (the default) or PUT
+	Headers map[string]string `json:"headers,omitempty"` // Sent with each request, e.g. an Authorization header
+	Events  []string          `json:"events,omitempty"`  // Events to send; empty for all of them
+}
+
+// HTTPMethod returns the method the webhook is called with
+func (w WebhookConfig) HTTPMethod() string {
+	if w.Method == "" {
+		return http.MethodPost
+	}
+	return w.Method
+}
+
+// Handles reports whether the webhook subscribes to event
+func (w WebhookConfig) Handles(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// LoadWebhooks returns the webhooks of the config file without validating
+// its profiles, so loading them prints no warnings. A missing file has none.
+func LoadWebhooks() ([]WebhookConfig, error) {
+	configPath, err := ConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read config file: %w", err)
+	}
+	cfg, err := parseConfigData(data)
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not parse config file: %w", err)
+	}
+	return cfg.Webhooks, nil
+}
+
+// AddWebhook validates a webhook and adds it to cfg, replacing any webhook
+// with the same URL
+func AddWebhook(cfg *Config, hook WebhookConfig) error {
+	parsed, err := url.Parse(hook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("❌ invalid webhook URL '%s'; use an http:// or https:// URL", hook.URL)
+	}
+	hook.Method = strings.ToUpper(hook.Method)
+	if hook.Method != "" && hook.Method != http.MethodPost && hook.Method != http.MethodPut {
+		return fmt.Errorf("❌ invalid webhook method '%s'. Must be 'POST' or 'PUT'", hook.Method)
+	}
+	for i, event := range hook.Events {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("❌ invalid webhook event '%s'. Must be one of: %s", event, strings.Join(WebhookEvents, ", "))
+		}
+		hook.Events[i] = event
+	}
+	for name := range hook.Headers {
+		if name == "" || strings.ContainsAny(name, ": \t\r\n") {
+			return fmt.Errorf("❌ invalid webhook header name '%s'", name)
+		}
+	}
+
+	if i := slices.IndexFunc(cfg.Webhooks, func(w WebhookConfig) bool { return w.URL == hook.URL }); i >= 0 {
+		cfg.Webhooks[i] = hook
+		return nil
+	}
+	cfg.Webhooks = append(cfg.Webhooks, hook)
+	return nil
+}
+
+// RemoveWebhook deletes the webhook with the given URL from cfg
+func RemoveWebhook(cfg *Config, hookURL string) error {
+	i := slices.IndexFunc(cfg.Webhooks, func(w WebhookConfig) bool { return w.URL == hookURL })
+	if i < 0 {
+		return fmt.Errorf("❌ no webhook with URL '%s'", hookURL)
+	}
+	cfg.Webhooks = slices.Delete(cfg.Webhooks, i, i+1)
+	return nil
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CommitIdentity is the author of a commit
+type CommitIdentity struct {
+	Hash  string
+	Name  string
+	Email string
+}
+
+// commitHashPattern matches full or abbreviated commit hashes
+var commitHashPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+
+// GetCommitIdentity returns the author name and email of a commit in the
+// current repository
+func GetCommitIdentity(commitHash string) (string, string, error) {
+	if !commitHashPattern.MatchString(commitHash) {
+		return "", "", fmt.Errorf("❌ invalid commit hash: %s", commitHash)
+	}
+	if !IsInGitRepo() {
+		return "", "", utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+
+	cmd := exec.Command("git", "show", "-s", "--format=%an|%ae", commitHash)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("❌ could not read commit %s: %w", commitHash, err)
+	}
+
+	// Names may contain '|', email addresses do not
+	line := strings.TrimSpace(string(output))
+	i := strings.LastIndex(line, "|")
+	if i < 0 {
+		return "", "", fmt.Errorf("❌ unexpected output for commit %s: %q", commitHash, line)
+	}
+	return line[:i], line[i+1:], nil
+}
+
+// GetRecentCommitIdentities returns the authors of the last n commits
+// reachable from HEAD, newest first. A repository without commits yields none.
+func GetRecentCommitIdentities(n int) ([]CommitIdentity, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("❌ commit count must be positive, got %d", n)
+	}
+	if !IsInGitRepo() {
+		return nil, utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+	return recentCommitIdentities("", n)
+}
+
+// recentCommitIdentities reads the authors of the last n commits of the
+// repository at dir, or of the current directory if dir is empty
+func recentCommitIdentities(dir string, n int) ([]CommitIdentity, error) {
+	// With -z records are NUL-terminated too, so fields and records share one delimiter
+	cmd := exec.Command("git", "log", "-z", "-n", strconv.Itoa(n), "--format=%H%x00%an%x00%ae")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && strings.Contains(string(exitErr.Stderr), "does not have any commits") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("❌ could not read commit history: %w", err)
+	}
+
+	fields := strings.Split(strings.TrimSuffix(string(output), "\x00"), "\x00")
+	if len(fields) < 3 {
+		return nil, nil
+	}
+
+	identities := make([]CommitIdentity, 0, len(fields)/3)
+	for i := 0; i+2 < len(fields); i += 3 {
+		identities = append(identities, CommitIdentity{
+			Hash:  strings.TrimSpace(fields[i]),
+			Name:  fields[i+1],
+			Email: fields[i+2],
+		})
+	}
+	return identities, nil
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
@@ -29,21 +30,30 @@ var validGitHubUsername = regexp.MustCompile(`^[a-zA-Z0-9]$|^[a-zA-Z0-9][a-zA-Z0
 // Validate Git email format
 var validEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
-// SetIdentity sets the user's Git identity in the global Git config
-func SetIdentity(username, email string) error {
+// SetIdentity sets the user's Git identity in the global Git config, or with
+// local in the current repository's .git/config only
+func SetIdentity(username, email string, local bool) error {
 	// Validation now happens primarily in config loading
 	// Basic check remains here for direct calls?
 	// if !validGitHubUsername.MatchString(username) { ... }
 	// if !validEmailRegex.MatchString(email) { ... }
 
+	scope := "--global"
+	if local {
+		if !IsInGitRepo() {
+			return utils.Errorf(ErrNotInRepo, "❌ not in a git repository; the local identity needs one")
+		}
+		scope = "--local"
+	}
+
 	// Set user.name
-	cmdName := exec.Command("git", "config", "--global", "user.name", username)
+	cmdName := exec.Command("git", "config", scope, "user.name", username)
 	if err := cmdName.Run(); err != nil {
 		return fmt.Errorf("❌ could not set git username: %w", err)
 	}
 
 	// Set user.email
-	cmdEmail := exec.Command("git", "config", "--global", "user.email", email)
+	cmdEmail := exec.Command("git", "config", scope, "user.email", email)
 	if err := cmdEmail.Run(); err != nil {
 		return fmt.Errorf("❌ could not set git email: %w", err)
 	}
@@ -51,6 +61,53 @@ func SetIdentity(username, email string) error {
 	return nil
 }
 
+// SetGPGKey configures commit signing in the global Git config, or with
+// local in the current repository's .git/config only: with a key it becomes
+// user.signingkey and commit.gpgsign is enabled, without one commit.gpgsign
+// is disabled
+func SetGPGKey(key string, local bool) error {
+	scope := "--global"
+	if local {
+		if !IsInGitRepo() {
+			return utils.Errorf(ErrNotInRepo, "❌ not in a git repository; the local signing key needs one")
+		}
+		scope = "--local"
+	}
+
+	if key != "" {
+		if output, err := exec.Command("git", "config", scope, "user.signingkey", key).CombinedOutput(); err != nil {
+			return fmt.Errorf("❌ could not set git signing key: %s", strings.TrimSpace(string(output)))
+		}
+	}
+	gpgSign := strconv.FormatBool(key != "")
+	if output, err := exec.Command("git", "config", scope, "commit.gpgsign", gpgSign).CombinedOutput(); err != nil {
+		return fmt.Errorf("❌ could not set commit.gpgsign: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// GPGKeyExists reports whether the secret key for a GPG key ID or
+// fingerprint is in the keyring at gnupgHome (the default keyring if empty),
+// according to 'gpg --list-secret-keys'
+func GPGKeyExists(key, gnupgHome string) (bool, error) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		return false, fmt.Errorf("❌ gpg not found on PATH: %w", err)
+	}
+
+	cmd := exec.Command("gpg", "--batch", "--list-secret-keys", "--", strings.TrimSuffix(key, "!"))
+	if gnupgHome != "" {
+		cmd.Env = append(os.Environ(), "GNUPGHOME="+gnupgHome)
+	}
+	if err := cmd.Run(); err != nil {
+		// gpg exits with 2 when no key matches
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 2 {
+			return false, nil
+		}
+		return false, fmt.Errorf("❌ could not list GPG keys: %w", err)
+	}
+	return true, nil
+}
+
 // IsInGitRepo checks if the current directory is inside a Git repository
 func IsInGitRepo() bool {
 	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
@@ -58,15 +115,47 @@ func IsInGitRepo() bool {
 	return err == nil
 }
 
-// GetCurrentRemoteURL gets the remote URL for the current repository
+// GetRepoRoot returns the top-level directory of the current Git repository.
+// Unlike IsInGitRepo it returns an error when not inside a repository, so callers
+// can target the repo root regardless of the subdirectory they were run from.
+func GetRepoRoot() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+
+	root := strings.TrimSpace(string(output))
+	if root == "" {
+		return "", utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+
+	// git prints forward slashes on Windows; normalize for the host OS
+	return filepath.Clean(root), nil
+}
+
+// GetCurrentRemoteURL gets the URL of the 'origin' remote of the current
+// repository
 func GetCurrentRemoteURL() (string, error) {
+	return GetRemoteURL("origin")
+}
+
+// GetRemoteURL gets the URL of the named remote of the current repository
+func GetRemoteURL(name string) (string, error) {
 	if !IsInGitRepo() {
-		return "", fmt.Errorf("❌ not in a git repository")
+		return "", utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+	if err := ValidateRemoteName(name); err != nil {
+		return "", err
 	}
 
-	cmd := exec.Command("git", "config", "--get", "remote.origin.url")
+	cmd := exec.Command("git", "config", "--get", "remote."+name+".url")
 	output, err := cmd.CombinedOutput() // Use CombinedOutput to get stderr if there's an error
 	if err != nil {
+		// Exit code 1 means the key is unset, i.e. there is no such remote
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", utils.Errorf(ErrNoRemote, "❌ could not get remote URL: no '%s' remote configured", name)
+		}
 		stderr := strings.TrimSpace(string(output))
 		if stderr != "" {
 			return "", fmt.Errorf("❌ could not get remote URL: %s", stderr)
@@ -85,46 +174,24 @@ func IsSSHRemote(url string) bool {
 // IsProfileSSHRemote checks if the remote URL is using a profile-specific SSH format
 func IsProfileSSHRemote(url string) (bool, string, string) {
 	// Format: git@platform-profilename:user/repo.git
-	if strings.HasPrefix(url, "git@") {
-		parts := strings.Split(url, ":")
-		if len(parts) != 2 {
-			return false, "", ""
-		}
-
-		hostParts := strings.Split(parts[0], "@")
-		if len(hostParts) != 2 {
-			return false, "", ""
-		}
-
-		hostAlias := hostParts[1]
-		if !strings.Contains(hostAlias, "-") {
-			return false, "", ""
-		}
-
-		// Split the platform-profile parts
-		aliasParts := strings.SplitN(hostAlias, "-", 2)
-		if len(aliasParts) != 2 {
-			return false, "", ""
-		}
-
-		platformID := aliasParts[0]
-		profileName := aliasParts[1]
-
-		// Validate profile name
-		if err := config.ValidateProfileName(profileName); err != nil {
-			return false, "", ""
-		}
-
-		return true, platformID, profileName
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil || remote.Protocol != platform.ProtocolSSHProfile {
+		return false, "", ""
 	}
 
-	return false, "", ""
+	platformID, profileName := remote.AliasParts()
+	return true, platformID, profileName
 }
 
 // ConvertRemoteToHTTPS converts a remote URL to HTTPS format
 func ConvertRemoteToHTTPS(url string, profile *config.Profile) string {
 	platformID := profile.GetPlatform()
 
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return url // Unable to parse, return as is
+	}
+
 	// Get platform information
 	reg := platform.NewRegistry()
 
@@ -136,68 +203,36 @@ func ConvertRemoteToHTTPS(url string, profile *config.Profile) string {
 	if err == nil {
 		// Use the platform info from registry
 		defaultHost = plat.DefaultHost
-	} else {
+		remote.Path = plat.RepoPath(remote.Path)
+	} else if inferredPlat, inferredErr := reg.GetPlatformByHost(remote.Host); inferredErr == nil {
 		// If platform not found, try to infer it from the URL
-		host, _, urlErr := platform.GetHostAndPath(url)
-		if urlErr == nil {
-			if inferredPlat, inferredErr := reg.GetPlatformByHost(host); inferredErr == nil {
-				defaultHost = inferredPlat.DefaultHost
-			}
-		}
-		// On failure, we keep the GitHub defaults
+		defaultHost = inferredPlat.DefaultHost
+		remote.Path = inferredPlat.RepoPath(remote.Path)
 	}
+	// On failure, we keep the GitHub defaults
 
 	// Use custom host if specified
 	if profile.Host != "" {
 		defaultHost = profile.Host
 	}
 
-	if IsSSHRemote(url) {
-		// Check if it's a profile-specific SSH URL
-		isProfileSSH, _, _ := IsProfileSSHRemote(url)
-		if isProfileSSH {
-			// Extract the user/repo part
-			parts := strings.Split(url, ":")
-			if len(parts) != 2 {
-				return url // Unable to parse, return as is
-			}
-
-			path := parts[1]
-			path = strings.TrimSuffix(path, ".git")
-			return fmt.Sprintf("https://%s/%s", defaultHost, path)
-		}
-
-		// Standard SSH URL
-		// Extract the host and path from SSH URL
-		parts := strings.Split(url, ":")
-		if len(parts) != 2 {
-			// Fallback for ssh:// format
-			if strings.Contains(url, "ssh://") {
-				url = strings.TrimPrefix(url, "ssh://")
-				parts = strings.SplitN(url, "/", 2)
-				if len(parts) != 2 {
-					return url // Unable to parse, return as is
-				}
-				// Use the original host from the URL in this case
-				sshHost := parts[0]
-				sshHost = strings.TrimPrefix(sshHost, "git@")
-				path := parts[1]
-				return fmt.Sprintf("https://%s/%s", sshHost, path)
-			}
-			return url // Unable to parse, return as is
-		}
-
-		// Use the path from the URL but the host from the profile
-		path := parts[1]
-		return fmt.Sprintf("https://%s/%s", defaultHost, path)
+	if remote.Protocol == platform.ProtocolHTTPS {
+		return url // Already HTTPS
 	}
-	return url // Already HTTPS or unknown format
+
+	// Use the path from the URL but the host from the profile
+	return remote.ToHTTPS(defaultHost)
 }
 
 // ConvertRemoteToSSH converts a remote URL to SSH format
 func ConvertRemoteToSSH(url string, profile *config.Profile, profileName string) string {
 	platformID := profile.GetPlatform()
 
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return url // Unable to parse, return as is
+	}
+
 	// Get platform information
 	reg := platform.NewRegistry()
 	sshUser := ""
@@ -207,172 +242,185 @@ func ConvertRemoteToSSH(url string, profile *config.Profile, profileName string)
 	if plat, err := reg.GetPlatform(platformID); err == nil {
 		// Use the platform info from registry
 		sshUser = plat.SSHUser
-	} else {
+		remote.Path = plat.RepoPath(remote.Path)
+	} else if inferredPlat, inferredErr := reg.GetPlatformByHost(remote.Host); inferredErr == nil {
 		// If platform not found, try to infer it from the URL
-		host, _, urlErr := platform.GetHostAndPath(url)
-		if urlErr == nil {
-			if inferredPlat, inferredErr := reg.GetPlatformByHost(host); inferredErr == nil {
-				sshUser = inferredPlat.SSHUser
-			}
-		}
-		// On failure, we keep the default values
+		sshUser = inferredPlat.SSHUser
+		remote.Path = inferredPlat.RepoPath(remote.Path)
 	}
+	// On failure, we keep the default values
 
 	// Default to git user if still empty
 	if sshUser == "" {
 		sshUser = "git"
 	}
 
-	// Process the URL
-	if IsSSHRemote(url) {
-		// Already an SSH URL, check if it needs to be converted to profile format
-		isProfileSSH, currentPlatformID, currentProfile := IsProfileSSHRemote(url)
-		if isProfileSSH && (currentPlatformID != platformID || currentProfile != profileName) {
-			// Need to update the profile in the URL
-			parts := strings.Split(url, ":")
-			if len(parts) == 2 {
-				hostAlias := platform.GetProfileSSHHost(platformID, profileName)
-				return fmt.Sprintf("git@%s:%s", hostAlias, parts[1])
-			}
-		} else if !isProfileSSH {
-			// Check if this is an SSH URL for the same platform
-			_, path, err := platform.GetHostAndPath(url)
-			if err == nil {
-				// Convert standard SSH URL to profile-specific format for this platform
-				hostAlias := platform.GetProfileSSHHost(platformID, profileName)
-				return fmt.Sprintf("git@%s:%s", hostAlias, path)
-			}
+	// Already using this platform+profile's host alias
+	if remote.Protocol == platform.ProtocolSSHProfile {
+		if currentPlatformID, currentProfile := remote.AliasParts(); currentPlatformID == platformID && currentProfile == profileName {
+			return url
 		}
-	} else {
-		// Convert HTTPS to SSH
-		// Extract the host and path from HTTPS URL
-		url = strings.TrimPrefix(url, "https://")
-		parts := strings.SplitN(url, "/", 2)
-		if len(parts) != 2 {
-			return url // Unable to parse, return as is
-		}
-
-		// Use the host alias for this platform+profile combination
-		hostAlias := platform.GetProfileSSHHost(platformID, profileName)
-		path := parts[1]
-
-		// Return the SSH URL with the host alias
-		return fmt.Sprintf("git@%s:%s", hostAlias, path)
 	}
 
-	// If we reach here, the URL is either already in the correct format or we couldn't parse it
-	return url
+	// Use the host alias for this platform+profile combination
+	hostAlias := platform.GetProfileSSHHost(platformID, profileName)
+	return remote.ToSSH(hostAlias, sshUser)
 }
 
-// UpdateRemoteURL updates the remote URL for the current repository
-func UpdateRemoteURL(url string) error {
+// UpdateRemoteURL updates the URL of a remote (e.g. "origin") of the
+// current repository
+func UpdateRemoteURL(name, url string) error {
 	if !IsInGitRepo() {
-		return fmt.Errorf("❌ not in a git repository")
+		return utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
 	}
 
+	if err := ValidateRemoteName(name); err != nil {
+		return err
+	}
 	// Validate URL format for security
 	if !isValidRemoteURL(url) {
 		return fmt.Errorf("❌ invalid remote URL format: %s", url)
 	}
 
 	// Specifically create command with explicit args for security
-	args := []string{"remote", "set-url", "origin", url}
+	args := []string{"remote", "set-url", name, url}
 	cmd := exec.Command("git", args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		stderr := strings.TrimSpace(string(output))
 		if stderr != "" {
-			return fmt.Errorf("❌ could not update remote URL: %s", stderr)
+			return fmt.Errorf("❌ could not update remote '%s' URL: %s", name, stderr)
 		}
-		return fmt.Errorf("❌ could not update remote URL: %w", err)
+		return fmt.Errorf("❌ could not update remote '%s' URL: %w", name, err)
 	}
 
 	return nil
 }
 
-// isValidRemoteURL checks if a URL is a valid Git remote URL
-func isValidRemoteURL(url string) bool {
-	// Check for SSH URLs
-	if IsSSHRemote(url) {
-		// For SSH URLs, check basic structure
-		if strings.HasPrefix(url, "git@") {
-			parts := strings.Split(url, ":")
-			if len(parts) != 2 {
-				return false
-			}
+// validRemoteName matches the remote names gat accepts
+var validRemoteName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
 
-			// Check host component - support any valid Git hosting platform or profile-specific pattern
-			hostPart := parts[0]
-			pathPart := parts[1]
-
-			// Accept standard git@ URLs for known platforms
-			if isValidSSHHostFormat(hostPart) {
-				return strings.Contains(pathPart, "/") &&
-					(strings.HasSuffix(pathPart, ".git") || !strings.Contains(pathPart, " "))
-			}
+// ValidateRemoteName checks that a remote name is safe to pass to git
+func ValidateRemoteName(name string) error {
+	if !validRemoteName.MatchString(name) || strings.HasSuffix(name, ".lock") || strings.Contains(name, "..") {
+		return fmt.Errorf("❌ invalid remote name: %s", name)
+	}
+	return nil
+}
 
-			// Accept profile-specific SSH URLs (e.g. git@github-work:user/repo.git)
-			if strings.HasPrefix(hostPart, "git@") && strings.Contains(hostPart, "-") {
-				platformProfile := strings.TrimPrefix(hostPart, "git@")
-				platformProfileParts := strings.Split(platformProfile, "-")
+// CreateRemote adds a new remote to the current repository, e.g. an
+// 'upstream' remote next to 'origin'
+func CreateRemote(name, url string) error {
+	if !IsInGitRepo() {
+		return utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
 
-				if len(platformProfileParts) == 2 {
-					// Valid platform-profile pattern
-					return strings.Contains(pathPart, "/") &&
-						(strings.HasSuffix(pathPart, ".git") || !strings.Contains(pathPart, " "))
-				}
-			}
+	if err := ValidateRemoteName(name); err != nil {
+		return err
+	}
+	// Validate URL format for security
+	if !isValidRemoteURL(url) {
+		return fmt.Errorf("❌ invalid remote URL format: %s", url)
+	}
 
-			return false
+	cmd := exec.Command("git", "remote", "add", name, url)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		stderr := strings.TrimSpace(string(output))
+		if stderr != "" {
+			return fmt.Errorf("❌ could not add remote '%s': %s", name, stderr)
 		}
-		return false
+		return fmt.Errorf("❌ could not add remote '%s': %w", name, err)
 	}
 
-	// Check for HTTPS URLs
-	if strings.HasPrefix(url, "https://") {
-		// For HTTPS URLs, check basic structure
-		url = strings.TrimPrefix(url, "https://")
-		parts := strings.SplitN(url, "/", 2)
-		if len(parts) != 2 {
-			return false
-		}
+	return nil
+}
 
-		// Check host component - any valid Git hosting platform
-		hostPart := parts[0]
-		pathPart := parts[1]
+// GetRemoteURLs returns the URL of every remote of the current repository,
+// keyed by remote name
+func GetRemoteURLs() (map[string]string, error) {
+	if !IsInGitRepo() {
+		return nil, utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
 
-		// Accept URLs from any known platform
-		if isValidHTTPSHostFormat(hostPart) {
-			return strings.Contains(pathPart, "/") && !strings.Contains(pathPart, " ")
+	cmd := exec.Command("git", "config", "--get-regexp", `^remote\..*\.url$`)
+	output, err := cmd.Output()
+	if err != nil {
+		// Exit code 1 means no remote is configured
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return map[string]string{}, nil
 		}
+		return nil, fmt.Errorf("❌ could not list remotes: %w", err)
+	}
 
-		// Also accept custom hosts from user's platform registry
-		// For security, ensure the host doesn't contain any dangerous characters
-		if !strings.ContainsAny(hostPart, " ;\"'<>|&") {
-			return strings.Contains(pathPart, "/") && !strings.Contains(pathPart, " ")
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		key, url, found := strings.Cut(line, " ")
+		if !found {
+			continue
 		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		remotes[name] = strings.TrimSpace(url)
 	}
+	return remotes, nil
+}
 
-	return false
+// Clone clones a repository into dir (or git's default directory if dir is
+// empty), streaming git's progress output. Options (e.g. "--depth=1") are
+// passed to git clone verbatim, before the URL.
+func Clone(url, dir string, options ...string) error {
+	// Validate URL format for security
+	if !isValidRemoteURL(url) {
+		return fmt.Errorf("❌ invalid remote URL format: %s", url)
+	}
+
+	args := append([]string{"clone"}, options...)
+	args = append(args, "--", url)
+	if dir != "" {
+		args = append(args, dir)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ could not clone %s: %w", url, err)
+	}
+	return nil
 }
 
-// isValidSSHHostFormat checks if a hostname is a valid SSH host format for any platform
-func isValidSSHHostFormat(hostPart string) bool {
-	reg := platform.NewRegistry()
-	for _, p := range reg.ListPlatforms() {
-		expectedPrefix := fmt.Sprintf("git@%s", p.DefaultHost)
-		if strings.HasPrefix(hostPart, expectedPrefix) {
-			return true
-		}
+// isValidRemoteURL checks if a URL is a valid Git remote URL
+func isValidRemoteURL(url string) bool {
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return false
+	}
+
+	// For security, ensure no component contains dangerous characters
+	if strings.ContainsAny(remote.Host+remote.User, " ;\"'<>|&") || strings.Contains(remote.Path, " ") {
+		return false
+	}
+	if !strings.Contains(remote.Path, "/") {
+		return false
+	}
+
+	switch remote.Protocol {
+	case platform.ProtocolSSHProfile:
+		// Profile-specific SSH URLs (e.g. git@github-work:user/repo.git)
+		return true
+	case platform.ProtocolSSH:
+		// Accept standard SSH URLs for known platforms
+		return isValidSSHHostFormat(remote.Host)
+	default:
+		// Accept HTTPS URLs from any known platform or custom host
+		return true
 	}
-	return false
 }
 
-// isValidHTTPSHostFormat checks if a hostname is a valid HTTPS host for any platform
-func isValidHTTPSHostFormat(hostPart string) bool {
+// isValidSSHHostFormat checks if a hostname is a valid SSH host for any platform
+func isValidSSHHostFormat(host string) bool {
 	reg := platform.NewRegistry()
 	for _, p := range reg.ListPlatforms() {
-		if hostPart == p.DefaultHost {
+		if host == p.DefaultHost {
 			return true
 		}
 	}
@@ -383,13 +431,18 @@ func isValidHTTPSHostFormat(hostPart string) bool {
 // It converts the URL if necessary and updates the 'origin' remote.
 // Returns the final URL and any error encountered.
 func RewriteRemote(profile *config.Profile, profileName string) (string, error) {
+	return RewriteNamedRemote(profile, profileName, "origin")
+}
+
+// RewriteNamedRemote is RewriteRemote for the named remote instead of 'origin'
+func RewriteNamedRemote(profile *config.Profile, profileName, remoteName string) (string, error) {
 	// Validate the profile name
 	if err := config.ValidateProfileName(profileName); err != nil {
 		return "", err
 	}
 
 	// Get the current remote URL
-	currentURL, err := GetCurrentRemoteURL()
+	currentURL, err := GetRemoteURL(remoteName)
 	if err != nil {
 		// Not necessarily an error, could just be no remote configured
 		// If we return an error, the switch command might halt prematurely
@@ -429,14 +482,14 @@ func RewriteRemote(profile *config.Profile, profileName string) (string, error)
 
 	// If the URL needs changing, update the remote
 	if targetURL != currentURL {
-		fmt.Printf("🔗 Updating remote origin to use %s (%s)...\n", targetProtocol, targetURL)
-		if err := UpdateRemoteURL(targetURL); err != nil {
+		fmt.Printf("🔗 Updating remote %s to use %s (%s)...\n", remoteName, targetProtocol, targetURL)
+		if err := UpdateRemoteURL(remoteName, targetURL); err != nil {
 			return currentURL, fmt.Errorf("failed to update remote URL: %w", err) // Return current URL on failure
 		}
 		return targetURL, nil // Return the new URL
 	}
 
-	fmt.Printf("🔗 Remote origin already uses correct protocol (%s)\n", targetProtocol)
+	fmt.Printf("🔗 Remote %s already uses correct protocol (%s)\n", remoteName, targetProtocol)
 	return currentURL, nil // Return the existing URL
 }
 
@@ -461,7 +514,7 @@ func UpdateRemoteProtocol(useSSH bool, profile *config.Profile, profileName stri
 	}
 
 	if newURL != url {
-		if err := UpdateRemoteURL(newURL); err != nil {
+		if err := UpdateRemoteURL("origin", newURL); err != nil {
 			return err
 		}
 	}
@@ -469,8 +522,14 @@ func UpdateRemoteProtocol(useSSH bool, profile *config.Profile, profileName stri
 	return nil
 }
 
-// UpdateGitCredentials updates the .git-credentials file with the token
+// UpdateGitCredentials updates the .git-credentials file with the token.
+// Profiles on a platform with a CredentialHelper need no token: Git is set
+// to ask that helper instead and the file is left alone.
 func UpdateGitCredentials(profile *config.Profile) error {
+	if plat, err := platform.NewRegistry().GetPlatform(profile.GetPlatform()); err == nil && plat.CredentialHelper != "" {
+		return SetCredentialHelper(plat.CredentialHelper)
+	}
+
 	token := profile.GetToken()
 	username := profile.Username
 
@@ -506,14 +565,16 @@ func UpdateGitCredentials(profile *config.Profile) error {
 	if err := cmdStore.Run(); err != nil {
 		return fmt.Errorf("❌ could not set credential helper: %w", err)
 	}
+	// Stored credentials have no path, so they only match without UseHttpPath
+	if err := unsetGlobalGitConfig("credential.UseHttpPath"); err != nil {
+		return err
+	}
 
-	homeDir, err := os.UserHomeDir()
+	credFile, err := utils.ExpandHome("~/.git-credentials")
 	if err != nil {
-		return fmt.Errorf("❌ could not find home directory: %w", err)
+		return err
 	}
 
-	credFile := filepath.Join(homeDir, ".git-credentials")
-
 	// Create or truncate the credentials file with secure permissions
 	file, err := os.OpenFile(credFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
 	if err != nil {
@@ -536,6 +597,47 @@ func UpdateGitCredentials(profile *config.Profile) error {
 	return nil
 }
 
+// SetCredentialHelper makes Git get HTTPS credentials from helper, passing
+// it the repository path as well as the host (credential.UseHttpPath), as
+// helpers such as AWS CodeCommit's need
+func SetCredentialHelper(helper string) error {
+	if err := exec.Command("git", "config", "--global", "credential.helper", helper).Run(); err != nil {
+		return fmt.Errorf("❌ could not set credential helper: %w", err)
+	}
+	if err := exec.Command("git", "config", "--global", "credential.UseHttpPath", "true").Run(); err != nil {
+		return fmt.Errorf("❌ could not set credential.UseHttpPath: %w", err)
+	}
+	return nil
+}
+
+// unsetGlobalGitConfig removes a key from Git's global config. A key that is
+// not set is not an error.
+func unsetGlobalGitConfig(key string) error {
+	if err := exec.Command("git", "config", "--global", "--unset", key).Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 5 {
+			return nil // Not set
+		}
+		return fmt.Errorf("❌ could not unset %s: %w", key, err)
+	}
+	return nil
+}
+
+// ClearGitCredentials empties the .git-credentials file written by
+// UpdateGitCredentials. A missing file is not an error.
+func ClearGitCredentials() error {
+	credFile, err := utils.ExpandHome("~/.git-credentials")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(credFile); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.WriteFile(credFile, nil, 0600); err != nil {
+		return fmt.Errorf("❌ could not clear .git-credentials: %w", err)
+	}
+	return nil
+}
+
 // GetGitConfig retrieves a value from Git's global config
 func GetGitConfig(key string) (string, error) {
 	// Validate key to prevent injection
@@ -556,6 +658,57 @@ func GetGitConfig(key string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// ValidateIdentityMatchesProfile checks that Git commits with the profile's
+// email: the user.email of the current repository if it sets one, else the
+// global one. Emails are compared case-insensitively.
+func ValidateIdentityMatchesProfile(profile *config.Profile) error {
+	output, err := exec.Command("git", "config", "--get", "user.email").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			return fmt.Errorf("❌ could not get git config for user.email: %w", err)
+		}
+	}
+	email := strings.TrimSpace(string(output))
+	if email == "" {
+		return fmt.Errorf("❌ git user.email is not set; the profile uses '%s'", profile.Email)
+	}
+	if !strings.EqualFold(email, profile.Email) {
+		return fmt.Errorf("❌ git user.email '%s' does not match the profile's email '%s'", email, profile.Email)
+	}
+	return nil
+}
+
+// ValidateRemoteMatchesProfile checks that a remote URL has the form 'gat
+// switch' gives remotes for the profile: the profile's SSH host alias for SSH
+// profiles, or an HTTPS URL on the profile's host for HTTPS profiles.
+func ValidateRemoteMatchesProfile(url string, profile *config.Profile, profileName string) error {
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return fmt.Errorf("❌ %v", err)
+	}
+
+	if profile.AuthMethod == "ssh" {
+		if expected := ConvertRemoteToSSH(url, profile, profileName); expected != url {
+			return fmt.Errorf("❌ remote '%s' does not use the profile's SSH host alias; expected '%s'", url, expected)
+		}
+		return nil
+	}
+
+	host := profile.Host
+	if host == "" {
+		if plat, err := platform.NewRegistry().GetPlatform(profile.GetPlatform()); err == nil {
+			host = plat.DefaultHost
+		}
+	}
+	if remote.Protocol != platform.ProtocolHTTPS {
+		return fmt.Errorf("❌ remote '%s' is not HTTPS; expected '%s'", url, ConvertRemoteToHTTPS(url, profile))
+	}
+	if !strings.EqualFold(remote.Host, host) {
+		return fmt.Errorf("❌ remote '%s' is not on the profile's host %s", url, host)
+	}
+	return nil
+}
+
 // isValidGitConfigKey validates a git config key for security
 func isValidGitConfigKey(key string) bool {
 	// Only allow specific sections we use
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"gat/pkg/config"
+	"testing"
+)
+
+func TestConvertRemoteSourcehut(t *testing.T) {
+	// Keep custom platforms in the real home directory out of the registry
+	t.Setenv("HOME", t.TempDir())
+	profile := &config.Profile{Username: "user", Email: "user@example.com", Platform: "sourcehut"}
+
+	tests := []struct {
+		name  string
+		url   string
+		https string
+		ssh   string
+	}{
+		{"scp-style", "git@git.sr.ht:~user/repo", "https://git.sr.ht/~user/repo", "git@sourcehut-work:~user/repo"},
+		{"scp-style with .git", "git@git.sr.ht:~user/repo.git", "https://git.sr.ht/~user/repo.git", "git@sourcehut-work:~user/repo.git"},
+		{"https", "https://git.sr.ht/~user/repo", "https://git.sr.ht/~user/repo", "git@sourcehut-work:~user/repo"},
+		{"https with .git", "https://git.sr.ht/~user/repo.git", "https://git.sr.ht/~user/repo.git", "git@sourcehut-work:~user/repo.git"},
+		{"host alias", "git@sourcehut-work:~user/repo", "https://git.sr.ht/~user/repo", "git@sourcehut-work:~user/repo"},
+		{"path without ~", "git@git.sr.ht:user/repo", "https://git.sr.ht/~user/repo", "git@sourcehut-work:~user/repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			https := ConvertRemoteToHTTPS(tt.url, profile)
+			if https != tt.https {
+				t.Errorf("ConvertRemoteToHTTPS(%q) = %q, want %q", tt.url, https, tt.https)
+			}
+			ssh := ConvertRemoteToSSH(tt.url, profile, "work")
+			if ssh != tt.ssh {
+				t.Errorf("ConvertRemoteToSSH(%q) = %q, want %q", tt.url, ssh, tt.ssh)
+			}
+
+			// Converting back must give the same URLs again
+			if got := ConvertRemoteToHTTPS(ssh, profile); got != tt.https {
+				t.Errorf("ConvertRemoteToHTTPS(%q) = %q, want %q", ssh, got, tt.https)
+			}
+			if got := ConvertRemoteToSSH(https, profile, "work"); got != tt.ssh {
+				t.Errorf("ConvertRemoteToSSH(%q) = %q, want %q", https, got, tt.ssh)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package git
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/utils"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Scopes of the hooks managed by InstallHook
+const (
+	HookScopeLocal  = "local"  // The current repository's .git/hooks
+	HookScopeGlobal = "global" // The core.hooksPath of ~/.gitconfig (~/.gat/hooks unless already set)
+)
+
+// Lines around the part of a hook script that InstallHook manages, so other
+// commands in the same hook are kept
+const (
+	hookBlockStart = "# >>> gat >>>"
+	hookBlockEnd   = "# <<< gat <<<"
+)
+
+// HookDir returns the directory hooks of the scope are read from. For the
+// global scope it is "" while core.hooksPath is not set globally.
+func HookDir(scope string) (string, error) {
+	switch scope {
+	case HookScopeLocal:
+		if !IsInGitRepo() {
+			return "", utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+		}
+		// Not --git-path hooks, which follows core.hooksPath
+		output, err := exec.Command("git", "rev-parse", "--git-common-dir").Output()
+		if err != nil {
+			return "", fmt.Errorf("❌ could not find the repository's git directory: %w", err)
+		}
+		gitDir, err := filepath.Abs(strings.TrimSpace(string(output)))
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(gitDir, "hooks"), nil
+	case HookScopeGlobal:
+		hooksPath, _ := exec.Command("git", "config", "--global", "core.hooksPath").Output()
+		if path := strings.TrimSpace(string(hooksPath)); path != "" {
+			return utils.ExpandHome(path)
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("❌ unknown hook scope '%s'", scope)
+	}
+}
+
+// ActiveHookDir returns the directory Git runs the current repository's
+// hooks from, which is core.hooksPath if it is set in any scope
+func ActiveHookDir() (string, error) {
+	if !IsInGitRepo() {
+		return "", utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+	output, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("❌ could not find the repository's hooks directory: %w", err)
+	}
+	path, err := utils.ExpandHome(strings.TrimSpace(string(output)))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(path)
+}
+
+// DefaultGlobalHookDir is the core.hooksPath InstallHook sets for the
+// global scope if none is set: ~/.gat/hooks
+func DefaultGlobalHookDir() (string, error) {
+	configDir, err := config.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "hooks"), nil
+}
+
+// InstallHook writes script into the hook hookName of the scope, between
+// marker lines so that installing again replaces it and commands already in
+// the hook are kept. A new hook file gets a /bin/sh shebang. For the global
+// scope, core.hooksPath is set to DefaultGlobalHookDir if it is not set.
+func InstallHook(scope, hookName, script string) error {
+	dir, err := HookDir(scope)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		if dir, err = DefaultGlobalHookDir(); err != nil {
+			return err
+		}
+		if output, err := exec.Command("git", "config", "--global", "core.hooksPath", dir).CombinedOutput(); err != nil {
+			return fmt.Errorf("❌ could not set core.hooksPath: %s", strings.TrimSpace(string(output)))
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("❌ could not create hooks directory: %w", err)
+	}
+
+	path := filepath.Join(dir, hookName)
+	block := hookBlockStart + "\n" + strings.TrimRight(script, "\n") + "\n" + hookBlockEnd + "\n"
+	data, err := os.ReadFile(path)
+	var content string
+	switch {
+	case os.IsNotExist(err):
+		content = "#!/bin/sh\n" + block
+	case err != nil:
+		return fmt.Errorf("❌ could not read hook %s: %w", path, err)
+	default:
+		rest, _ := removeHookBlock(string(data))
+		if rest != "" && !strings.HasSuffix(rest, "\n") {
+			rest += "\n"
+		}
+		content = rest + block
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		return fmt.Errorf("❌ could not write hook %s: %w", path, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0755); err != nil {
+		return fmt.Errorf("❌ could not make hook %s executable: %w", path, err)
+	}
+	return nil
+}
+
+// UninstallHook removes what InstallHook wrote to the hook hookName of the
+// scope and reports whether there was anything to remove. A hook left with
+// only its shebang is deleted, and so is a global DefaultGlobalHookDir left
+// empty, unsetting core.hooksPath.
+func UninstallHook(scope, hookName string) (bool, error) {
+	dir, err := HookDir(scope)
+	if err != nil || dir == "" {
+		return false, err
+	}
+
+	path := filepath.Join(dir, hookName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("❌ could not read hook %s: %w", path, err)
+	}
+	rest, found := removeHookBlock(string(data))
+	if !found {
+		return false, nil
+	}
+
+	if strings.TrimSpace(rest) == "#!/bin/sh" || strings.TrimSpace(rest) == "" {
+		if err := os.Remove(path); err != nil {
+			return true, fmt.Errorf("❌ could not remove hook %s: %w", path, err)
+		}
+	} else if err := os.WriteFile(path, []byte(rest), 0755); err != nil {
+		return true, fmt.Errorf("❌ could not write hook %s: %w", path, err)
+	}
+
+	if scope == HookScopeGlobal {
+		defaultDir, err := DefaultGlobalHookDir()
+		if err == nil && dir == defaultDir {
+			if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+				os.Remove(dir)
+				exec.Command("git", "config", "--global", "--unset", "core.hooksPath").Run()
+			}
+		}
+	}
+	return true, nil
+}
+
+// removeHookBlock returns a hook script without the block InstallHook
+// manages, and whether it had one
+func removeHookBlock(content string) (string, bool) {
+	start := strings.Index(content, hookBlockStart)
+	if start < 0 {
+		return content, false
+	}
+	end := strings.Index(content[start:], hookBlockEnd)
+	if end < 0 {
+		return content, false
+	}
+	end += start + len(hookBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:], true
+}
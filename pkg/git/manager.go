@@ -43,19 +43,25 @@ func (m *Manager) SwitchProfile(profileName string, useSSH bool, dryRun bool) (m
 		return result, nil
 	}
 
-	// Set up Git identity
-	if err := SetIdentity(profile.Username, profile.Email); err != nil {
+	// Set up Git identity, only in the repository in local scope
+	local := m.configManager.PreferLocalScope()
+	if err := SetIdentity(profile.Username, profile.Email, local); err != nil {
 		return nil, err
 	}
 
-	// Update Git credentials
-	if err := UpdateGitCredentials(&profile); err != nil {
-		return nil, err
+	// Update Git credentials, which are always global
+	if !local {
+		if err := config.UnlockProfileToken(profileName, &profile); err != nil {
+			return nil, err
+		}
+		if err := UpdateGitCredentials(&profile); err != nil {
+			return nil, err
+		}
 	}
 
 	// Set up SSH config if needed
-	if profile.SSHIdentity != "" {
-		sshErr := ssh.ConfigureSSH(profile.GetPlatform(), profileName, profile.SSHIdentity)
+	if len(profile.SSHIdentities) > 0 {
+		sshErr := ssh.ConfigureSSH(profile.GetPlatform(), profileName, profile.SSHIdentities, profile.SSHCertPath)
 		if sshErr != nil {
 			result["ssh_error"] = sshErr.Error()
 		}
@@ -94,8 +100,8 @@ func (m *Manager) AddProfile(name string, profile config.Profile, setupSSH bool,
 	}
 
 	// Set up SSH if requested
-	if setupSSH && profile.SSHIdentity != "" {
-		if err := ssh.ConfigureSSH(profile.GetPlatform(), name, profile.SSHIdentity); err != nil {
+	if setupSSH && len(profile.SSHIdentities) > 0 {
+		if err := ssh.ConfigureSSH(profile.GetPlatform(), name, profile.SSHIdentities, profile.SSHCertPath); err != nil {
 			return err
 		}
 	}
@@ -128,9 +134,11 @@ func validateProfile(profile config.Profile) error {
 
 // Custom error variables
 var (
-	ErrProfileNotFound = Err("profile not found")
+	ErrProfileNotFound = config.ErrProfileNotFound // Shared so callers need only one check
 	ErrInvalidUsername = Err("invalid username format")
 	ErrInvalidEmail    = Err("invalid email format")
+	ErrNotInRepo       = Err("not in a git repository")
+	ErrNoRemote        = Err("no remote configured")
 )
 
 // Err creates a new error
@@ -0,0 +1,134 @@
+package git
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"gat/pkg/utils"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// MirrorRemoteURL converts the URL of a mirror remote to the profile's auth
+// method. The mirror's platform is inferred from its URL: on the profile's
+// platform it is converted like origin (SSH host alias or HTTPS), anywhere
+// else only the protocol changes and the mirror keeps its own host.
+func MirrorRemoteURL(url string, profile *config.Profile, profileName string, reg *platform.Registry) string {
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return url // Unable to parse, return as is
+	}
+
+	plat, platErr := reg.GetPlatformForURL(url)
+	if platErr == nil && plat.ID == profile.GetPlatform() {
+		if profile.AuthMethod == "ssh" {
+			return ConvertRemoteToSSH(url, profile, profileName)
+		}
+		return ConvertRemoteToHTTPS(url, profile)
+	}
+
+	host := remote.Host
+	if remote.Protocol == platform.ProtocolSSHProfile {
+		if platErr != nil {
+			return url // Alias of an unknown platform, no real host to use
+		}
+		host = plat.DefaultHost
+	}
+
+	if profile.AuthMethod == "ssh" {
+		if remote.Protocol != platform.ProtocolHTTPS {
+			return url // Already SSH
+		}
+		sshUser := "git"
+		if platErr == nil && plat.SSHUser != "" {
+			sshUser = plat.SSHUser
+		}
+		return remote.ToSSH(host, sshUser)
+	}
+	if remote.Protocol == platform.ProtocolHTTPS {
+		return url // Already HTTPS
+	}
+	return remote.ToHTTPS(host)
+}
+
+// RewriteMirrorRemotes converts the profile's MirrorRemotes in the current
+// repository with MirrorRemoteURL. It returns the new URLs of the remotes it
+// changed and the names of mirror remotes the repository does not have.
+func RewriteMirrorRemotes(profile *config.Profile, profileName string) (map[string]string, []string, error) {
+	if len(profile.MirrorRemotes) == 0 {
+		return nil, nil, nil
+	}
+
+	remotes, err := GetRemoteURLs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reg := platform.NewRegistry()
+	updated := make(map[string]string)
+	var missing []string
+	for _, name := range profile.MirrorRemotes {
+		url, exists := remotes[name]
+		if !exists {
+			missing = append(missing, name)
+			continue
+		}
+		targetURL := MirrorRemoteURL(url, profile, profileName, reg)
+		if targetURL == url {
+			continue
+		}
+		if err := UpdateRemoteURL(name, targetURL); err != nil {
+			return updated, missing, fmt.Errorf("failed to update remote '%s': %w", name, err)
+		}
+		updated[name] = targetURL
+	}
+	sort.Strings(missing)
+	return updated, missing, nil
+}
+
+// ListRemotes returns the names of the remotes of the current repository, as
+// listed by 'git remote'
+func ListRemotes() ([]string, error) {
+	if !IsInGitRepo() {
+		return nil, utils.Errorf(ErrNotInRepo, "❌ not in a git repository")
+	}
+
+	output, err := exec.Command("git", "remote").Output()
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not list remotes: %w", err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// UpdateAllRemotes converts every remote of the current repository to the
+// profile's auth method with MirrorRemoteURL, so remotes on other platforms
+// keep their own host. It returns the new URLs of the remotes it changed.
+func UpdateAllRemotes(profile *config.Profile, profileName string) (map[string]string, error) {
+	if err := config.ValidateProfileName(profileName); err != nil {
+		return nil, err
+	}
+
+	names, err := ListRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	reg := platform.NewRegistry()
+	updated := make(map[string]string)
+	for _, name := range names {
+		url, err := GetRemoteURL(name)
+		if err != nil {
+			return updated, err
+		}
+		targetURL := MirrorRemoteURL(url, profile, profileName, reg)
+		if targetURL == url {
+			continue
+		}
+		if err := UpdateRemoteURL(name, targetURL); err != nil {
+			return updated, fmt.Errorf("failed to update remote '%s': %w", name, err)
+		}
+		updated[name] = targetURL
+	}
+	return updated, nil
+}
@@ -0,0 +1,20 @@
+package git
+
+import "gat/pkg/platform"
+
+// RemoteURL holds the components of a Git remote URL. It is implemented in
+// pkg/platform so that platform.GetHostAndPath can share the parser.
+type RemoteURL = platform.RemoteURL
+
+// Protocols of a parsed remote URL (see platform.ProtocolHTTPS)
+const (
+	ProtocolHTTPS      = platform.ProtocolHTTPS
+	ProtocolSSH        = platform.ProtocolSSH
+	ProtocolSSHProfile = platform.ProtocolSSHProfile
+)
+
+// ParseRemoteURL splits a remote URL into its components (see
+// platform.ParseRemoteURL)
+func ParseRemoteURL(url string) (RemoteURL, error) {
+	return platform.ParseRemoteURL(url)
+}
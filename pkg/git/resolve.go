@@ -0,0 +1,125 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"gat/pkg/config"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProfileFileName is the per-directory file naming the profile to use for a
+// repository (and, through inheritance, for repositories below it)
+const ProfileFileName = ".gatprofile"
+
+// ProfileEnvVar overrides every other profile source when set
+const ProfileEnvVar = "GAT_PROFILE"
+
+// Sources reported by GetRepoProfile, in order of precedence
+const (
+	ProfileSourceEnv    = "env"    // GAT_PROFILE environment variable
+	ProfileSourceFile   = "file"   // .gatprofile in the repository root
+	ProfileSourceParent = "parent" // .gatprofile in a parent directory
+	ProfileSourcePin    = "pin"    // .gat pin file (see 'gat pin') in the repository root or a parent
+	ProfileSourceGlobal = "global" // The active profile in the config file
+)
+
+// GetRepoProfile resolves the profile to use for the repository at repoRoot
+// and where the choice came from. It checks, in order, the GAT_PROFILE
+// environment variable, a .gatprofile or .gat pin file in repoRoot, then in
+// the nearest parent directory that has one (.gatprofile first within a
+// directory), and finally the active profile. The files are only consulted
+// when the auto-switch setting is on; an empty repoRoot (not in a repository)
+// skips them as well. The returned name is not
+// checked against the configured profiles; it is empty if no source names one.
+func (m *Manager) GetRepoProfile(repoRoot string) (string, string, error) {
+	if name := strings.TrimSpace(os.Getenv(ProfileEnvVar)); name != "" {
+		if err := config.ValidateProfileName(name); err != nil {
+			return "", ProfileSourceEnv, fmt.Errorf("❌ invalid %s: %w", ProfileEnvVar, err)
+		}
+		return name, ProfileSourceEnv, nil
+	}
+
+	if repoRoot != "" && m.configManager.AutoSwitch() {
+		repoRoot, err := filepath.Abs(repoRoot)
+		if err != nil {
+			return "", "", fmt.Errorf("❌ could not resolve repository path: %w", err)
+		}
+
+		source := ProfileSourceFile
+		for dir := repoRoot; ; dir = filepath.Dir(dir) {
+			name, err := readProfileFile(filepath.Join(dir, ProfileFileName))
+			if err != nil {
+				return "", source, err
+			}
+			if name != "" {
+				return name, source, nil
+			}
+			name, err = config.ReadPinFile(filepath.Join(dir, config.PinFileName))
+			if err != nil {
+				return "", ProfileSourcePin, err
+			}
+			if name != "" {
+				return name, ProfileSourcePin, nil
+			}
+
+			if parent := filepath.Dir(dir); parent == dir {
+				break
+			}
+			source = ProfileSourceParent
+		}
+	}
+
+	_, current, err := m.configManager.GetProfiles()
+	if err != nil {
+		return "", ProfileSourceGlobal, err
+	}
+	return current, ProfileSourceGlobal, nil
+}
+
+// DescribeProfileSource returns a short phrase for a GetRepoProfile source,
+// e.g. "from .gatprofile", for messages such as "Using profile 'work' (...)"
+func DescribeProfileSource(source string) string {
+	switch source {
+	case ProfileSourceEnv:
+		return "from " + ProfileEnvVar
+	case ProfileSourceFile:
+		return "from " + ProfileFileName
+	case ProfileSourceParent:
+		return "from a parent " + ProfileFileName
+	case ProfileSourcePin:
+		return "pinned by " + config.PinFileName
+	default:
+		return "active profile"
+	}
+}
+
+// readProfileFile returns the profile named by a .gatprofile file: its first
+// line that is neither empty nor a '#' comment. A missing file yields "".
+func readProfileFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("❌ could not read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := config.ValidateProfileName(line); err != nil {
+			return "", fmt.Errorf("❌ invalid profile name in %s: %w", path, err)
+		}
+		return line, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("❌ could not read %s: %w", path, err)
+	}
+	return "", nil
+}
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"fmt"
+	"gat/pkg/config"
+	"gat/pkg/platform"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// suggestCommitCount is how many recent commits ResolveConflictingIdentity
+// compares with the profiles
+const suggestCommitCount = 20
+
+// Reasons returned by ResolveConflictingIdentity
+const (
+	SuggestReasonCommitEmail    = "matches recent commit author"
+	SuggestReasonCommitUsername = "matches recent commit author's username"
+	SuggestReasonPlatform       = "matches remote platform"
+)
+
+// ResolveConflictingIdentity suggests the profile to use for the repository
+// at repoRoot, for when the Git identity does not match the active profile.
+// Profiles are narrowed to the platform of the origin remote, then the
+// authors of the last commits are matched by email and by username; failing
+// that, the only profile on the remote's platform is suggested. It returns
+// the profile name and the reason, or two empty strings if nothing matches.
+func ResolveConflictingIdentity(repoRoot string) (string, string, error) {
+	configDir, err := config.ConfigPath()
+	if err != nil {
+		return "", "", err
+	}
+	manager := config.NewManager(configDir)
+	profiles, _, err := manager.GetProfiles()
+	if err != nil {
+		return "", "", err
+	}
+
+	// Platform of the origin remote, if it has one gat knows
+	platformID := ""
+	cmd := exec.Command("git", "config", "--get", "remote.origin.url")
+	cmd.Dir = repoRoot
+	if output, err := cmd.Output(); err == nil {
+		if plat, err := platform.NewRegistry().GetPlatformForURL(strings.TrimSpace(string(output))); err == nil {
+			platformID = plat.ID
+		}
+	}
+
+	var candidates []string
+	for name, profile := range profiles {
+		if platformID == "" || profile.GetPlatform() == platformID {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+
+	commits, err := recentCommitIdentities(repoRoot, suggestCommitCount)
+	if err != nil {
+		return "", "", fmt.Errorf("❌ could not read recent commits: %w", err)
+	}
+	for _, commit := range commits {
+		for _, name := range candidates {
+			if strings.EqualFold(profiles[name].Email, commit.Email) {
+				return name, SuggestReasonCommitEmail, nil
+			}
+		}
+		if platformID != "" {
+			if _, name, err := manager.GetProfileByUsername(commit.Name, platformID); err == nil {
+				return name, SuggestReasonCommitUsername, nil
+			}
+		}
+	}
+
+	if platformID != "" && len(candidates) == 1 {
+		return candidates[0], SuggestReasonPlatform, nil
+	}
+	return "", "", nil
+}
+
+// GuessProfileFromRemote returns the name of the profile a remote URL belongs
+// to, or "" if none does. A gat host alias (git@github-work:user/repo.git)
+// names the profile directly; otherwise the profile must be on the remote's
+// platform, or self-hosted on its host, with a username matching the owner of
+// the repository.
+func GuessProfileFromRemote(url string, profiles map[string]config.Profile) string {
+	remote, err := platform.ParseRemoteURL(url)
+	if err != nil {
+		return ""
+	}
+	if platformID, profileName := remote.AliasParts(); profileName != "" {
+		if profile, exists := profiles[profileName]; exists && profile.GetPlatform() == platformID {
+			return profileName
+		}
+	}
+
+	platformID := ""
+	path := remote.Path
+	if plat, err := platform.NewRegistry().GetPlatformForURL(url); err == nil {
+		platformID = plat.ID
+		path = strings.TrimPrefix(path, plat.PathPrefix()) // "~user/repo" on Sourcehut
+	}
+	owner, _, _ := strings.Cut(path, "/")
+	if owner == "" {
+		return ""
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile := profiles[name]
+		onPlatform := profile.Host == "" && platformID != "" && profile.GetPlatform() == platformID
+		onHost := profile.Host != "" && strings.EqualFold(profile.Host, remote.Host)
+		if (onPlatform || onHost) && strings.EqualFold(profile.Username, owner) {
+			return name
+		}
+	}
+	return ""
+}
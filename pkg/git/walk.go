@@ -0,0 +1,86 @@
+package git
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WalkRepos calls fn for every Git repository found under root (including
+// root itself). A directory is a repository if it contains a .git entry.
+// Symlinks are not followed, repositories are not searched for nested
+// repositories, and paths matched by the .gitignore at root (such as
+// vendor/ or node_modules/) are skipped. An error returned by fn stops the walk.
+func WalkRepos(root string, fn func(repoRoot string) error) error {
+	root = filepath.Clean(root)
+	ignorePatterns := loadIgnorePatterns(filepath.Join(root, ".gitignore"))
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than aborting the walk
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() || d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if path != root {
+			rel, err := filepath.Rel(root, path)
+			if err == nil && isIgnored(filepath.ToSlash(rel), d.Name(), ignorePatterns) {
+				return filepath.SkipDir
+			}
+		}
+
+		if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+			if err := fn(path); err != nil {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// loadIgnorePatterns reads the simple (non-negated) patterns of a .gitignore file
+func loadIgnorePatterns(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		patterns = append(patterns, strings.TrimSuffix(line, "/"))
+	}
+	return patterns
+}
+
+// isIgnored reports whether a directory matches any .gitignore pattern.
+// Patterns containing a slash are matched against the path relative to the
+// walk root, others against the directory name at any depth.
+func isIgnored(relPath, name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			if matched, _ := filepath.Match(strings.TrimPrefix(pattern, "/"), relPath); matched {
+				return true
+			}
+		} else if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package output
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image"
+	_ "image/gif" // Decoders for the formats platforms serve avatars in
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// AvatarSize is the width and height in pixels RenderAvatar draws avatars at
+const AvatarSize = 16
+
+// Limits for fetching an avatar image
+const (
+	avatarTimeout  = 5 * time.Second
+	maxAvatarBytes = 2 << 20
+)
+
+// ErrGraphicsUnsupported is returned by RenderAvatar when the writer is not a
+// terminal known to support the kitty or sixel graphics protocols
+var ErrGraphicsUnsupported = errors.New("terminal does not support inline images")
+
+// graphicsProtocol is an inline image protocol a terminal understands
+type graphicsProtocol int
+
+const (
+	graphicsNone graphicsProtocol = iota
+	graphicsKitty
+	graphicsSixel
+)
+
+// RenderAvatar fetches the image at url and draws it inline, scaled to
+// AvatarSize x AvatarSize pixels, using the kitty graphics protocol or sixel.
+// It returns ErrGraphicsUnsupported without fetching anything if w is not a
+// terminal that supports either; see AvatarFallback.
+func RenderAvatar(url string, w io.Writer) error {
+	protocol := detectGraphics(w)
+	if protocol == graphicsNone {
+		return ErrGraphicsUnsupported
+	}
+	if url == "" {
+		return fmt.Errorf("no avatar URL")
+	}
+
+	img, err := fetchImage(url)
+	if err != nil {
+		return err
+	}
+	scaled := scaleImage(img, AvatarSize)
+
+	if protocol == graphicsKitty {
+		return writeKitty(w, scaled)
+	}
+	return writeSixel(w, scaled)
+}
+
+// AvatarFallback returns the first letter of username on a colored block,
+// the color derived from the username so it is stable across runs
+func AvatarFallback(username string) string {
+	letter := "?"
+	if r, _ := utf8.DecodeRuneInString(username); r != utf8.RuneError {
+		letter = string(unicode.ToUpper(r))
+	}
+
+	backgrounds := []color.Attribute{color.BgRed, color.BgGreen, color.BgYellow, color.BgBlue, color.BgMagenta, color.BgCyan}
+	h := fnv.New32a()
+	h.Write([]byte(username))
+	bg := backgrounds[h.Sum32()%uint32(len(backgrounds))]
+	return color.New(bg, color.FgHiWhite, color.Bold).Sprintf(" %s ", letter)
+}
+
+// detectGraphics picks the image protocol for w from the terminal's
+// environment. Terminals answer a kitty graphics query (ESC _Ga=q ESC \) on
+// stdin, which cannot be read back without switching the terminal to raw
+// mode, so only $TERM and related variables are checked.
+func detectGraphics(w io.Writer) graphicsProtocol {
+	f, ok := w.(*os.File)
+	if !ok || !isatty.IsTerminal(f.Fd()) {
+		return graphicsNone
+	}
+
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty",
+		termProgram == "WezTerm", termProgram == "ghostty":
+		return graphicsKitty
+	case strings.Contains(term, "sixel"), term == "mlterm", strings.HasPrefix(term, "foot"),
+		strings.HasPrefix(term, "yaft"), termProgram == "iTerm.app":
+		return graphicsSixel
+	}
+	return graphicsNone
+}
+
+// fetchImage downloads and decodes an image
+func fetchImage(url string) (image.Image, error) {
+	client := &http.Client{Timeout: avatarTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch avatar: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch avatar: HTTP %d", resp.StatusCode)
+	}
+
+	img, _, err := image.Decode(io.LimitReader(resp.Body, maxAvatarBytes))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode avatar: %w", err)
+	}
+	return img, nil
+}
+
+// scaleImage resizes img to size x size pixels (nearest neighbor)
+func scaleImage(img image.Image, size int) *image.NRGBA {
+	bounds := img.Bounds()
+	scaled := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			srcX := bounds.Min.X + x*bounds.Dx()/size
+			srcY := bounds.Min.Y + y*bounds.Dy()/size
+			scaled.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return scaled
+}
+
+// writeKitty draws a scaled avatar with the kitty graphics protocol, as raw
+// RGBA pixels placed over two cells. q=2 keeps the terminal from replying on stdin.
+func writeKitty(w io.Writer, scaled *image.NRGBA) error {
+	payload := base64.StdEncoding.EncodeToString(scaled.Pix)
+	_, err := fmt.Fprintf(w, "\x1b_Ga=T,q=2,f=32,s=%d,v=%d,c=2,r=1;%s\x1b\\", AvatarSize, AvatarSize, payload)
+	return err
+}
+
+// writeSixel draws a scaled avatar as sixel graphics, with colors reduced to
+// a 6x6x6 cube and transparent pixels left as background
+func writeSixel(w io.Writer, scaled *image.NRGBA) error {
+	var b strings.Builder
+
+	// P2=1: pixels not drawn keep the background color
+	b.WriteString("\x1bP0;1;0q")
+	fmt.Fprintf(&b, "\"1;1;%d;%d", AvatarSize, AvatarSize)
+
+	// Map each pixel to a palette index, -1 for transparent
+	indexes := make([]int, AvatarSize*AvatarSize)
+	defined := make(map[int]bool)
+	for i := range indexes {
+		r, g, bl, a := scaled.Pix[i*4], scaled.Pix[i*4+1], scaled.Pix[i*4+2], scaled.Pix[i*4+3]
+		if a < 128 {
+			indexes[i] = -1
+			continue
+		}
+		index := int(r)*6/256*36 + int(g)*6/256*6 + int(bl)*6/256
+		indexes[i] = index
+		if !defined[index] {
+			defined[index] = true
+			// Register colors are percentages
+			fmt.Fprintf(&b, "#%d;2;%d;%d;%d", index, index/36*20, index/6%6*20, index%6*20)
+		}
+	}
+
+	// Each band is six pixel rows; draw it once per color, returning to its start with $
+	for top := 0; top < AvatarSize; top += 6 {
+		used := make(map[int]bool)
+		var order []int
+		for y := top; y < top+6 && y < AvatarSize; y++ {
+			for x := 0; x < AvatarSize; x++ {
+				if index := indexes[y*AvatarSize+x]; index >= 0 && !used[index] {
+					used[index] = true
+					order = append(order, index)
+				}
+			}
+		}
+		for i, index := range order {
+			if i > 0 {
+				b.WriteByte('$')
+			}
+			fmt.Fprintf(&b, "#%d", index)
+			for x := 0; x < AvatarSize; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && top+dy < AvatarSize; dy++ {
+					if indexes[(top+dy)*AvatarSize+x] == index {
+						bits |= 1 << dy
+					}
+				}
+				b.WriteByte(byte('?' + bits))
+			}
+		}
+		if top+6 < AvatarSize {
+			b.WriteByte('-')
+		}
+	}
+	b.WriteString("\x1b\\")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
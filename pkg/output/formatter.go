@@ -0,0 +1,87 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies how command output should be rendered
+type Format string
+
+const (
+	FormatText Format = "text" // Human-readable, colored output (default)
+	FormatJSON Format = "json" // Indented JSON
+	FormatYAML Format = "yaml" // YAML
+)
+
+// ParseFormat validates and normalizes an --output flag value
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatYAML:
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("❌ invalid output format '%s'. Must be 'text', 'json', or 'yaml'", value)
+}
+
+// IsStructured returns true if the format is machine-readable (JSON or YAML)
+func (f Format) IsStructured() bool {
+	return f == FormatJSON || f == FormatYAML
+}
+
+// Write serializes data to w using the given structured format.
+// JSON struct tags are the single source of truth for field names in both
+// formats, so types only need to declare `json` tags to be printable as YAML.
+func Write(w io.Writer, format Format, data interface{}) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("❌ could not encode JSON output: %w", err)
+		}
+		return nil
+	case FormatYAML:
+		return writeYAML(w, data)
+	}
+	return fmt.Errorf("❌ output format '%s' is not a structured format", format)
+}
+
+// writeYAML converts data to YAML by way of its JSON representation,
+// preserving field order and json tag names
+func writeYAML(w io.Writer, data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("❌ could not encode YAML output: %w", err)
+	}
+
+	// JSON is valid YAML, so parse it into a node tree (keeps key order)
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return fmt.Errorf("❌ could not encode YAML output: %w", err)
+	}
+	clearFlowStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return fmt.Errorf("❌ could not encode YAML output: %w", err)
+	}
+	return encoder.Close()
+}
+
+// clearFlowStyle resets the inline JSON-style flags so the YAML encoder
+// emits block-style mappings and sequences and only quotes strings when needed
+func clearFlowStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+	for _, child := range node.Content {
+		clearFlowStyle(child)
+	}
+}
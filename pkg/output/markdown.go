@@ -0,0 +1,150 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// Patterns for the markdown RenderMarkdown understands
+var (
+	mdHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdBullet   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdNumbered = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+	mdRule     = regexp.MustCompile(`^(-\s*){3,}$|^(\*\s*){3,}$|^(_\s*){3,}$`)
+	mdANSI     = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	mdInline   = regexp.MustCompile("`([^`]+)`|\\*\\*([^*]+)\\*\\*|__([^_]+)__|\\*([^*]+)\\*|\\b_([^_]+)_\\b|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)")
+)
+
+// RenderMarkdown renders a small subset of markdown for the terminal:
+// headings, bullet and numbered lists, block quotes, fenced code blocks,
+// horizontal rules, and **bold**, *italic*, `code` and [links](url) inline.
+// Paragraphs are wrapped at width characters; anything else is printed as
+// written. Colors follow color.NoColor, so piped output is plain text.
+func RenderMarkdown(text string, width int) string {
+	if width < 20 {
+		width = 20
+	}
+
+	var out []string
+	var paragraph []string
+	inCode := false
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			out = append(out, wrapMarkdown(strings.Join(paragraph, " "), width, "", "")...)
+			paragraph = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			flush()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, "  "+color.CyanString("%s", line))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+		case mdHeading.MatchString(trimmed):
+			flush()
+			heading := mdHeading.FindStringSubmatch(trimmed)[2]
+			out = append(out, color.New(color.Bold, color.FgHiBlue).Sprint(renderInline(heading)))
+		case mdRule.MatchString(trimmed):
+			flush()
+			out = append(out, strings.Repeat("─", min(width, 40)))
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			bar := color.HiBlackString("│ ")
+			out = append(out, wrapMarkdown(quote, width-2, bar, bar)...)
+		case mdBullet.MatchString(line):
+			flush()
+			m := mdBullet.FindStringSubmatch(line)
+			indent := strings.Repeat(" ", len(m[1]))
+			out = append(out, wrapMarkdown(m[2], width-len(indent)-2, indent+"• ", indent+"  ")...)
+		case mdNumbered.MatchString(line):
+			flush()
+			m := mdNumbered.FindStringSubmatch(line)
+			indent := strings.Repeat(" ", len(m[1]))
+			marker := m[2] + ". "
+			out = append(out, wrapMarkdown(m[3], width-len(indent)-len(marker), indent+marker, indent+strings.Repeat(" ", len(marker)))...)
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// wrapMarkdown styles the inline markdown of text and word-wraps it to width
+// visible characters. The first line starts with first, the rest with rest.
+func wrapMarkdown(text string, width int, first, rest string) []string {
+	if width < 10 {
+		width = 10
+	}
+	var lines []string
+	var current []string
+	length := 0
+	for _, word := range strings.Fields(renderInline(text)) {
+		n := utf8.RuneCountInString(mdANSI.ReplaceAllString(word, ""))
+		if length > 0 && length+1+n > width {
+			lines = append(lines, strings.Join(current, " "))
+			current, length = nil, 0
+		}
+		if length > 0 {
+			length++
+		}
+		current = append(current, word)
+		length += n
+	}
+	if len(current) > 0 {
+		lines = append(lines, strings.Join(current, " "))
+	}
+
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = first + line
+		} else {
+			lines[i] = rest + line
+		}
+	}
+	return lines
+}
+
+// renderInline styles the inline markdown of a single line
+func renderInline(text string) string {
+	return mdInline.ReplaceAllStringFunc(text, func(match string) string {
+		m := mdInline.FindStringSubmatch(match)
+		switch {
+		case m[1] != "":
+			return color.CyanString("%s", m[1])
+		case m[2] != "":
+			return color.New(color.Bold).Sprint(m[2])
+		case m[3] != "":
+			return color.New(color.Bold).Sprint(m[3])
+		case m[4] != "":
+			return color.New(color.Italic).Sprint(m[4])
+		case m[5] != "":
+			return color.New(color.Italic).Sprint(m[5])
+		default:
+			return color.New(color.Underline).Sprint(m[6]) + color.HiBlackString(" (%s)", m[7])
+		}
+	})
+}
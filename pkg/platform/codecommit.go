@@ -0,0 +1,39 @@
+package platform
+
+import (
+	"regexp"
+	"strings"
+)
+
+// awsRegionPattern matches AWS region names such as "eu-west-1"
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+
+// CodeCommitHost returns the Git host of AWS CodeCommit in a region
+func CodeCommitHost(region string) string {
+	return "git-codecommit." + region + ".amazonaws.com"
+}
+
+// ExpandCodeCommitRegion returns the CodeCommit host of host if it is an AWS
+// region, so the host of a CodeCommit profile can be given as just its region
+// ("eu-west-1"). Other hosts are returned as they are.
+func ExpandCodeCommitRegion(host string) string {
+	if awsRegionPattern.MatchString(host) {
+		return CodeCommitHost(host)
+	}
+	return host
+}
+
+// CredentialHelperProgram returns the program Git runs for the platform's
+// CredentialHelper: the first word of a "!" shell command, the path of an
+// absolute helper and git-credential-<name> otherwise. It is "" when the
+// platform has no credential helper.
+func (p *Platform) CredentialHelperProgram() string {
+	fields := strings.Fields(strings.TrimPrefix(p.CredentialHelper, "!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	if strings.HasPrefix(p.CredentialHelper, "!") || strings.HasPrefix(fields[0], "/") {
+		return fields[0]
+	}
+	return "git-credential-" + fields[0]
+}
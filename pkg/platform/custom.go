@@ -0,0 +1,149 @@
+package platform
+
+import (
+	"fmt"
+	"gat/pkg/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validPlatformIDRegex limits platform IDs to lowercase letters, digits and hyphens
+var validPlatformIDRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// ValidatePlatformID checks that a platform ID is safe to use in SSH host
+// aliases and file names
+func ValidatePlatformID(id string) error {
+	if !validPlatformIDRegex.MatchString(id) {
+		return fmt.Errorf("❌ invalid platform ID '%s': use 1-32 lowercase letters, digits or hyphens", id)
+	}
+	return nil
+}
+
+// IsBuiltIn reports whether id is one of the platforms shipped with gat
+func IsBuiltIn(id string) bool {
+	for _, platform := range defaultPlatforms() {
+		if platform.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomPlatformsPath returns the path to ~/.gat/platforms.yaml
+func CustomPlatformsPath() (string, error) {
+	return utils.ExpandHome("~/.gat/platforms.yaml")
+}
+
+// LoadCustomPlatforms reads the user-defined platforms, keyed by ID.
+// A missing file yields an empty map.
+func LoadCustomPlatforms() (map[string]*Platform, error) {
+	platformsPath, err := CustomPlatformsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	customPlatforms := make(map[string]*Platform)
+	data, err := os.ReadFile(platformsPath)
+	if os.IsNotExist(err) {
+		// No custom platforms file, which is fine
+		return customPlatforms, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read platforms file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &customPlatforms); err != nil {
+		return nil, fmt.Errorf("could not parse platforms file: %w", err)
+	}
+	if customPlatforms == nil {
+		customPlatforms = make(map[string]*Platform)
+	}
+	return customPlatforms, nil
+}
+
+// SaveCustomPlatforms writes the user-defined platforms atomically (via a
+// temporary file and rename) so a failed write never truncates the file
+func SaveCustomPlatforms(platforms map[string]*Platform) error {
+	platformsPath, err := CustomPlatformsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(platformsPath), 0755); err != nil {
+		return fmt.Errorf("could not create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(platforms)
+	if err != nil {
+		return fmt.Errorf("could not marshal platforms data: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(platformsPath), "platforms-*.yaml.tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temporary platforms file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("could not write platforms file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("could not write platforms file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("could not set platforms file permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, platformsPath); err != nil {
+		return fmt.Errorf("could not replace platforms file: %w", err)
+	}
+	return nil
+}
+
+// RegisterPlatform adds or replaces a custom platform in the registry
+// without writing it to disk; call PersistCustomPlatforms to save it.
+// Built-in platforms cannot be replaced.
+func (r *Registry) RegisterPlatform(p *Platform) error {
+	if p == nil {
+		return fmt.Errorf("❌ cannot register a nil platform")
+	}
+	if err := ValidatePlatformID(p.ID); err != nil {
+		return err
+	}
+	if IsBuiltIn(p.ID) {
+		return fmt.Errorf("❌ '%s' is a built-in platform and cannot be replaced", p.ID)
+	}
+
+	p.Custom = true
+	r.Platforms[p.ID] = p
+	return nil
+}
+
+// UnregisterPlatform removes a custom platform from the registry and
+// writes the remaining custom platforms to disk
+func (r *Registry) UnregisterPlatform(id string) error {
+	if IsBuiltIn(id) {
+		return fmt.Errorf("❌ '%s' is a built-in platform and cannot be removed", id)
+	}
+	if _, exists := r.Platforms[id]; !exists {
+		return fmt.Errorf("❌ custom platform '%s' not found", id)
+	}
+
+	delete(r.Platforms, id)
+	return r.PersistCustomPlatforms()
+}
+
+// PersistCustomPlatforms writes the registry's custom platforms to
+// ~/.gat/platforms.yaml, replacing its contents
+func (r *Registry) PersistCustomPlatforms() error {
+	customPlatforms := make(map[string]*Platform)
+	for id, platform := range r.Platforms {
+		if platform.Custom {
+			customPlatforms[id] = platform
+		}
+	}
+	return SaveCustomPlatforms(customPlatforms)
+}
@@ -2,23 +2,96 @@ package platform
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
+)
 
-	"gopkg.in/yaml.v3"
+// Values of Platform.APICompatibility
+const (
+	APICompatGitHub    = "github"
+	APICompatGitLab    = "gitlab"
+	APICompatBitbucket = "bitbucket"
+	APICompatGitea     = "gitea" // Gitea and its fork Forgejo
 )
 
 // Platform represents a Git hosting platform's configuration
 type Platform struct {
-	ID             string `yaml:"id"`             // Unique identifier (e.g., "github", "gitlab")
-	Name           string `yaml:"name"`           // Display name (e.g., "GitHub", "GitLab")
-	DefaultHost    string `yaml:"defaultHost"`    // Default hostname (e.g., "github.com")
-	SSHPrefix      string `yaml:"sshPrefix"`      // SSH prefix (e.g., "git@github.com:")
-	HTTPSPrefix    string `yaml:"httpsPrefix"`    // HTTPS prefix (e.g., "https://github.com/")
-	SSHUser        string `yaml:"sshUser"`        // SSH username (typically "git")
-	TokenAuthScope string `yaml:"tokenAuthScope"` // Token authentication scope (e.g., "github.com")
-	Custom         bool   `yaml:"custom"`         // Whether this is a custom user-defined platform
+	ID             string `yaml:"id" json:"id"`                           // Unique identifier (e.g., "github", "gitlab")
+	Name           string `yaml:"name" json:"name"`                       // Display name (e.g., "GitHub", "GitLab")
+	DefaultHost    string `yaml:"defaultHost" json:"default_host"`        // Default hostname (e.g., "github.com")
+	SSHPrefix      string `yaml:"sshPrefix" json:"ssh_prefix"`            // SSH prefix (e.g., "git@github.com:")
+	HTTPSPrefix    string `yaml:"httpsPrefix" json:"https_prefix"`        // HTTPS prefix (e.g., "https://github.com/")
+	SSHUser        string `yaml:"sshUser" json:"ssh_user"`                // SSH username (typically "git")
+	TokenAuthScope string `yaml:"tokenAuthScope" json:"token_auth_scope"` // Token authentication scope (e.g., "github.com")
+	Custom         bool   `yaml:"custom" json:"is_custom"`                // Whether this is a custom user-defined platform
+
+	// Optional token format checks used by 'gat add --token'
+	TokenPrefix    string `yaml:"tokenPrefix,omitempty" json:"token_prefix,omitempty"`        // Accepted token prefixes, comma-separated (e.g., "ghp_,github_pat_")
+	MaxTokenLength int    `yaml:"maxTokenLength,omitempty" json:"max_token_length,omitempty"` // Maximum token length (0 = unlimited)
+
+	// API the platform implements, selecting how VerifyToken talks to it
+	// (one of the APICompat constants, or empty). Gitea-compatible platforms
+	// get "Authorization: token" and, without APIVerifyURL, /api/v1/user.
+	APICompatibility string `yaml:"apiCompatibility,omitempty" json:"api_compatibility,omitempty"`
+
+	// Optional API endpoint used to verify tokens (see VerifyToken)
+	APIVerifyURL     string `yaml:"apiVerifyURL,omitempty" json:"api_verify_url,omitempty"`         // Returns the authenticated user for a Bearer token (e.g., "https://api.github.com/user")
+	APIUsernameField string `yaml:"apiUsernameField,omitempty" json:"api_username_field,omitempty"` // JSON field holding the username in the response (default "username")
+	APIScopesHeader  string `yaml:"apiScopesHeader,omitempty" json:"api_scopes_header,omitempty"`   // Response header listing the token's scopes, comma-separated (e.g., "X-OAuth-Scopes")
+	APIAvatarField   string `yaml:"apiAvatarField,omitempty" json:"api_avatar_field,omitempty"`     // JSON field holding the avatar URL, dot-separated for nested fields (e.g., "links.avatar.href")
+	APIExpiryHeader  string `yaml:"apiExpiryHeader,omitempty" json:"api_expiry_header,omitempty"`   // Response header with the token's expiry date (e.g., "GitHub-Authentication-Token-Expiration")
+
+	// Token scopes gat needs on this platform (see ScopeDescription)
+	OAuthScopes []string `yaml:"oauthScopes,omitempty" json:"oauth_scopes,omitempty"`
+
+	// Git credential helper that authenticates HTTPS profiles instead of a
+	// token in ~/.git-credentials (e.g. "!aws codecommit credential-helper $@").
+	// Profiles on the platform must use HTTPS.
+	CredentialHelper string `yaml:"credentialHelper,omitempty" json:"credential_helper,omitempty"`
+}
+
+// ValidateToken checks a token against the platform's MaxTokenLength, if set
+func (p *Platform) ValidateToken(token string) error {
+	if p.MaxTokenLength > 0 && len(token) > p.MaxTokenLength {
+		return fmt.Errorf("❌ %s tokens are at most %d characters long (got %d); check for an accidental paste", p.Name, p.MaxTokenLength, len(token))
+	}
+	return nil
+}
+
+// CheckTokenPrefix reports a token that starts with none of the platform's
+// TokenPrefix prefixes. It is only a hint: platforms issue other kinds of
+// tokens too (e.g. GitHub's legacy 40-character tokens), so callers warn
+// rather than reject the token.
+func (p *Platform) CheckTokenPrefix(token string) error {
+	prefixes := p.TokenPrefixes()
+	if len(prefixes) == 0 {
+		return nil
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(token, prefix) {
+			return nil
+		}
+	}
+
+	quoted := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		quoted[i] = fmt.Sprintf("'%s'", prefix)
+	}
+	expected := quoted[len(quoted)-1]
+	if len(quoted) > 1 {
+		expected = strings.Join(quoted[:len(quoted)-1], ", ") + " or " + expected
+	}
+	return fmt.Errorf("%s tokens usually start with %s; check that this is the right token", p.Name, expected)
+}
+
+// TokenPrefixes returns the accepted token prefixes parsed from TokenPrefix
+func (p *Platform) TokenPrefixes() []string {
+	var prefixes []string
+	for _, prefix := range strings.Split(p.TokenPrefix, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
 }
 
 // Registry holds all registered Git hosting platforms
@@ -41,12 +114,34 @@ func NewRegistry() *Registry {
 		fmt.Printf("⚠️ Warning: could not load custom platforms: %s\n", err)
 	}
 
+	// Platforms registered for this run take precedence
+	for id, platform := range sessionPlatforms {
+		reg.Platforms[id] = platform
+	}
+
 	return reg
 }
 
+// sessionPlatforms are the platforms registered with RegisterSessionPlatform
+var sessionPlatforms = make(map[string]*Platform)
+
+// RegisterSessionPlatform makes every registry created from now on until gat
+// exits return p for its ID, in place of the built-in or custom platform. It
+// is never saved to ~/.gat/platforms.yaml.
+func RegisterSessionPlatform(p *Platform) {
+	sessionPlatforms[p.ID] = p
+}
+
 // registerDefaults registers the default Git hosting platforms
 func (r *Registry) registerDefaults() {
-	defaults := []*Platform{
+	for _, platform := range defaultPlatforms() {
+		r.Platforms[platform.ID] = platform
+	}
+}
+
+// defaultPlatforms returns fresh copies of the built-in platforms
+func defaultPlatforms() []*Platform {
+	return []*Platform{
 		{
 			ID:             "github",
 			Name:           "GitHub",
@@ -55,6 +150,16 @@ func (r *Registry) registerDefaults() {
 			HTTPSPrefix:    "https://github.com/",
 			SSHUser:        "git",
 			TokenAuthScope: "github.com",
+			TokenPrefix:    "ghp_,github_pat_,gho_,ghu_,ghs_",
+			MaxTokenLength: 255,
+
+			APICompatibility: APICompatGitHub,
+			APIVerifyURL:     "https://api.github.com/user",
+			APIUsernameField: "login",
+			APIScopesHeader:  "X-OAuth-Scopes",
+			APIAvatarField:   "avatar_url",
+			APIExpiryHeader:  "GitHub-Authentication-Token-Expiration",
+			OAuthScopes:      []string{"repo", "read:user"},
 		},
 		{
 			ID:             "gitlab",
@@ -64,6 +169,12 @@ func (r *Registry) registerDefaults() {
 			HTTPSPrefix:    "https://gitlab.com/",
 			SSHUser:        "git",
 			TokenAuthScope: "gitlab.com",
+
+			APICompatibility: APICompatGitLab,
+			APIVerifyURL:     "https://gitlab.com/api/v4/user",
+			APIUsernameField: "username",
+			APIAvatarField:   "avatar_url",
+			OAuthScopes:      []string{"api", "read_user"},
 		},
 		{
 			ID:             "bitbucket",
@@ -73,6 +184,46 @@ func (r *Registry) registerDefaults() {
 			HTTPSPrefix:    "https://bitbucket.org/",
 			SSHUser:        "git",
 			TokenAuthScope: "bitbucket.org",
+
+			APICompatibility: APICompatBitbucket,
+			APIVerifyURL:     "https://api.bitbucket.org/2.0/user",
+			APIUsernameField: "username",
+			APIScopesHeader:  "X-OAuth-Scopes",
+			APIAvatarField:   "links.avatar.href",
+			OAuthScopes:      []string{"repository:write", "account"},
+		},
+		{
+			ID:             "forgejo",
+			Name:           "Forgejo",
+			DefaultHost:    "codeberg.org",
+			SSHPrefix:      "git@codeberg.org:",
+			HTTPSPrefix:    "https://codeberg.org/",
+			SSHUser:        "git",
+			TokenAuthScope: "codeberg.org",
+
+			APICompatibility: APICompatGitea,
+			APIVerifyURL:     "https://codeberg.org/api/v1/user",
+			APIUsernameField: "login",
+			APIAvatarField:   "avatar_url",
+			OAuthScopes:      []string{"write:repository", "read:user"},
+		},
+		{
+			ID:             "sourcehut",
+			Name:           "Sourcehut",
+			DefaultHost:    "git.sr.ht",
+			SSHPrefix:      "git@git.sr.ht:~",
+			HTTPSPrefix:    "https://git.sr.ht/~",
+			SSHUser:        "git",
+			TokenAuthScope: "git.sr.ht",
+		},
+		{
+			ID:               "codecommit",
+			Name:             "AWS CodeCommit",
+			DefaultHost:      CodeCommitHost("us-east-1"),
+			SSHPrefix:        "ssh://" + CodeCommitHost("us-east-1") + "/v1/repos/",
+			HTTPSPrefix:      "https://" + CodeCommitHost("us-east-1") + "/v1/repos/",
+			TokenAuthScope:   CodeCommitHost("us-east-1"),
+			CredentialHelper: "!aws codecommit credential-helper $@",
 		},
 		{
 			ID:             "huggingface",
@@ -82,6 +233,12 @@ func (r *Registry) registerDefaults() {
 			HTTPSPrefix:    "https://huggingface.co/",
 			SSHUser:        "git",
 			TokenAuthScope: "huggingface.co",
+			TokenPrefix:    "hf_",
+
+			APIVerifyURL:     "https://huggingface.co/api/whoami-v2",
+			APIUsernameField: "name",
+			APIAvatarField:   "avatarUrl",
+			OAuthScopes:      []string{"write"},
 		},
 		{
 			ID:             "azuredevops",
@@ -91,41 +248,41 @@ func (r *Registry) registerDefaults() {
 			HTTPSPrefix:    "https://dev.azure.com/",
 			SSHUser:        "git",
 			TokenAuthScope: "dev.azure.com",
+			OAuthScopes:    []string{"vso.code_write"},
 		},
 	}
+}
 
-	for _, platform := range defaults {
-		r.Platforms[platform.ID] = platform
+// GHESPlatform returns the platform of a GitHub Enterprise Server at host
+// (e.g. "github.mycompany.com"). It is the built-in GitHub platform, with its
+// ID, SSH user and token rules, moved to the host and its API at /api/v3.
+func GHESPlatform(host string) *Platform {
+	var ghes Platform
+	for _, platform := range defaultPlatforms() {
+		if platform.ID == "github" {
+			ghes = *platform
+		}
 	}
+	ghes.Name = "GitHub Enterprise Server"
+	ghes.DefaultHost = host
+	ghes.SSHPrefix = ghes.SSHUser + "@" + host + ":"
+	ghes.HTTPSPrefix = "https://" + host + "/"
+	ghes.TokenAuthScope = host
+	ghes.APIVerifyURL = "https://" + host + "/api/v3/user"
+	return &ghes
+}
+
+// IsGHESHost reports whether a GitHub profile with the given host is on a
+// GitHub Enterprise Server rather than github.com
+func IsGHESHost(host string) bool {
+	return host != "" && host != "github.com"
 }
 
 // loadCustomPlatforms loads user-defined platforms from ~/.gat/platforms.yaml
 func (r *Registry) loadCustomPlatforms() error {
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
+	customPlatforms, err := LoadCustomPlatforms()
 	if err != nil {
-		return fmt.Errorf("could not find home directory: %w", err)
-	}
-
-	// Path to custom platforms file
-	platformsPath := filepath.Join(homeDir, ".gat", "platforms.yaml")
-
-	// Check if the file exists
-	if _, err := os.Stat(platformsPath); os.IsNotExist(err) {
-		// No custom platforms file, which is fine
-		return nil
-	}
-
-	// Read the file
-	data, err := os.ReadFile(platformsPath)
-	if err != nil {
-		return fmt.Errorf("could not read platforms file: %w", err)
-	}
-
-	// Parse YAML
-	var customPlatforms map[string]*Platform
-	if err := yaml.Unmarshal(data, &customPlatforms); err != nil {
-		return fmt.Errorf("could not parse platforms file: %w", err)
+		return err
 	}
 
 	// Add custom platforms to registry
@@ -157,6 +314,27 @@ func (r *Registry) GetPlatformByHost(host string) (*Platform, error) {
 	return nil, fmt.Errorf("unknown host: %s", host)
 }
 
+// GetPlatformForURL returns the platform a remote URL points to, by its host
+// or, for gat's SSH host aliases (git@github-work:user/repo.git), by the
+// platform ID in the alias
+func (r *Registry) GetPlatformForURL(url string) (*Platform, error) {
+	remote, err := ParseRemoteURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if platform, err := r.GetPlatformByHost(remote.Host); err == nil {
+		return platform, nil
+	}
+	// Host aliases only appear in SSH URLs
+	if platformID, _, isAlias := strings.Cut(remote.Host, "-"); isAlias && remote.Protocol != ProtocolHTTPS {
+		if platform, exists := r.Platforms[platformID]; exists {
+			return platform, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown host: %s", remote.Host)
+}
+
 // ListPlatforms returns a list of all registered platforms
 func (r *Registry) ListPlatforms() []*Platform {
 	var platforms []*Platform
@@ -171,27 +349,35 @@ func GetProfileSSHHost(platformID, profileName string) string {
 	return fmt.Sprintf("%s-%s", platformID, profileName)
 }
 
-// GetHostAndPath extracts host and path from a URL
+// GetHostAndPath returns the host and repository path of a remote URL (see
+// ParseRemoteURL)
 func GetHostAndPath(url string) (string, string, error) {
-	// HTTPS format: https://github.com/user/repo.git
-	if strings.HasPrefix(url, "https://") {
-		url = strings.TrimPrefix(url, "https://")
-		parts := strings.SplitN(url, "/", 2)
-		if len(parts) != 2 {
-			return "", "", fmt.Errorf("invalid HTTPS URL format: %s", url)
-		}
-		return parts[0], parts[1], nil
+	remote, err := ParseRemoteURL(url)
+	if err != nil {
+		return "", "", err
 	}
+	return remote.Host, remote.Path, nil
+}
 
-	// SSH format: git@github.com:user/repo.git
-	if strings.Contains(url, "@") && strings.Contains(url, ":") {
-		hostPart := strings.Split(url, "@")[1]
-		hostPart = strings.Split(hostPart, ":")[0]
-		pathPart := strings.Split(url, ":")[1]
-		return hostPart, pathPart, nil
+// PathPrefix returns what the platform's repository paths start with: the
+// part of HTTPSPrefix after the host, e.g. "~" on Sourcehut ("~user/repo").
+// It is "" for most platforms.
+func (p *Platform) PathPrefix() string {
+	prefix, ok := strings.CutPrefix(p.HTTPSPrefix, "https://"+p.DefaultHost+"/")
+	if !ok {
+		return ""
 	}
+	return prefix
+}
 
-	return "", "", fmt.Errorf("unsupported URL format: %s", url)
+// RepoPath returns a repository path on the platform, adding PathPrefix if
+// path does not start with it ("user/repo" is "~user/repo" on Sourcehut)
+func (p *Platform) RepoPath(path string) string {
+	prefix := p.PathPrefix()
+	if strings.HasPrefix(path, prefix) {
+		return path
+	}
+	return prefix + path
 }
 
 // GenerateSSHURL generates an SSH URL for the given platform, profile and path
@@ -200,10 +386,41 @@ func GenerateSSHURL(platform *Platform, profileName, path string) string {
 	hostAlias := GetProfileSSHHost(platform.ID, profileName)
 
 	// Return the SSH URL with the host alias
-	return fmt.Sprintf("git@%s:%s", hostAlias, path)
+	return fmt.Sprintf("git@%s:%s", hostAlias, platform.RepoPath(path))
 }
 
 // GenerateHTTPSURL generates an HTTPS URL for the given platform and path
 func GenerateHTTPSURL(platform *Platform, path string) string {
-	return fmt.Sprintf("https://%s/%s", platform.DefaultHost, path)
+	return fmt.Sprintf("https://%s/%s", platform.DefaultHost, platform.RepoPath(path))
+}
+
+// CloneOptions holds optional git clone flags for GenerateCloneCommand
+type CloneOptions struct {
+	Depth  int    // Shallow clone depth (0 = full history)
+	Branch string // Branch to check out (empty = remote default)
+}
+
+// GenerateCloneCommand returns a ready-to-copy git clone command for the given
+// platform, profile and repository path, using the profile's SSH host alias
+// when useSSH is true and the platform's HTTPS URL otherwise
+func GenerateCloneCommand(platform *Platform, profileName, repoPath string, useSSH bool, opts ...CloneOptions) string {
+	var url string
+	if useSSH {
+		url = GenerateSSHURL(platform, profileName, repoPath)
+	} else {
+		url = GenerateHTTPSURL(platform, repoPath)
+	}
+
+	args := []string{"git", "clone"}
+	for _, opt := range opts {
+		if opt.Depth > 0 {
+			args = append(args, "--depth", fmt.Sprintf("%d", opt.Depth))
+		}
+		if opt.Branch != "" {
+			args = append(args, "--branch", opt.Branch)
+		}
+	}
+	args = append(args, url)
+
+	return strings.Join(args, " ")
 }
@@ -0,0 +1,54 @@
+package platform
+
+import "testing"
+
+func TestGetHostAndPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		host string
+		path string
+	}{
+		{"git@git.sr.ht:~user/repo", "git.sr.ht", "~user/repo"},
+		{"git@git.sr.ht:~user/repo.git", "git.sr.ht", "~user/repo.git"},
+		{"https://git.sr.ht/~user/repo", "git.sr.ht", "~user/repo"},
+		{"https://git.sr.ht/~user/repo.git", "git.sr.ht", "~user/repo.git"},
+		{"git@sourcehut-work:~user/repo", "sourcehut-work", "~user/repo"},
+		{"git@github.com:user/repo.git", "github.com", "user/repo.git"},
+		{"ssh://git@github.com:22/user/repo.git", "github.com", "user/repo.git"},
+	}
+	for _, tt := range tests {
+		host, path, err := GetHostAndPath(tt.url)
+		if err != nil {
+			t.Errorf("GetHostAndPath(%q) failed: %v", tt.url, err)
+			continue
+		}
+		if host != tt.host || path != tt.path {
+			t.Errorf("GetHostAndPath(%q) = %q, %q, want %q, %q", tt.url, host, path, tt.host, tt.path)
+		}
+	}
+}
+
+func TestRepoPathSourcehut(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	plat, err := NewRegistry().GetPlatform("sourcehut")
+	if err != nil {
+		t.Fatalf("GetPlatform(sourcehut): %v", err)
+	}
+	if prefix := plat.PathPrefix(); prefix != "~" {
+		t.Errorf("PathPrefix() = %q, want %q", prefix, "~")
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"user/repo", "~user/repo"},
+		{"~user/repo", "~user/repo"},
+		{"~user/repo.git", "~user/repo.git"},
+	}
+	for _, tt := range tests {
+		if got := plat.RepoPath(tt.path); got != tt.want {
+			t.Errorf("RepoPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package platform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Protocols of a parsed remote URL
+const (
+	ProtocolHTTPS      = "https"       // https://github.com/user/repo.git
+	ProtocolSSH        = "ssh"         // git@github.com:user/repo.git or ssh://git@github.com/user/repo.git
+	ProtocolSSHProfile = "ssh-profile" // git@github-work:user/repo.git (host alias from ~/.ssh/gat_config)
+)
+
+// aliasProfilePattern matches the profile part of a host alias written by
+// gat; it accepts the same names as config.ValidateProfileName
+var aliasProfilePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// RemoteURL holds the components of a Git remote URL
+type RemoteURL struct {
+	Protocol     string // ProtocolHTTPS, ProtocolSSH or ProtocolSSHProfile
+	Host         string // Host as written in the URL (the alias for ssh-profile URLs), without port
+	Path         string // Repository path, e.g. "user/repo.git"
+	User         string // SSH user (usually "git"), or the user of an HTTPS URL if present
+	ProfileAlias string // "<platform>-<profile>" host alias, only for ssh-profile URLs
+}
+
+// ParseRemoteURL splits a remote URL into its components. It understands
+// https:// URLs, scp-style SSH URLs (user@host:path) including gat's
+// profile-specific host aliases, and ssh:// URLs.
+func ParseRemoteURL(url string) (RemoteURL, error) {
+	var remote RemoteURL
+
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		remote.Protocol = ProtocolHTTPS
+		hostPart, path, found := strings.Cut(strings.TrimPrefix(url, "https://"), "/")
+		if !found {
+			return RemoteURL{}, fmt.Errorf("invalid HTTPS URL format: %s", url)
+		}
+		if userInfo, host, hasUser := strings.Cut(hostPart, "@"); hasUser {
+			remote.User, _, _ = strings.Cut(userInfo, ":") // Never keep an embedded password
+			hostPart = host
+		}
+		remote.Host = hostPart
+		remote.Path = path
+
+	case strings.HasPrefix(url, "ssh://"):
+		remote.Protocol = ProtocolSSH
+		hostPart, path, found := strings.Cut(strings.TrimPrefix(url, "ssh://"), "/")
+		if !found {
+			return RemoteURL{}, fmt.Errorf("invalid SSH URL format: %s", url)
+		}
+		if user, host, hasUser := strings.Cut(hostPart, "@"); hasUser {
+			remote.User = user
+			hostPart = host
+		}
+		remote.Host, _, _ = strings.Cut(hostPart, ":") // Drop the port
+		remote.Path = path
+
+	case strings.Contains(url, "@") && strings.Contains(url, ":"):
+		remote.Protocol = ProtocolSSH
+		hostPart, path, _ := strings.Cut(url, ":")
+		user, host, found := strings.Cut(hostPart, "@")
+		if !found || strings.Contains(path, ":") {
+			return RemoteURL{}, fmt.Errorf("invalid SSH URL format: %s", url)
+		}
+		remote.User = user
+		remote.Host = host
+		remote.Path = path
+
+		// Host aliases written by gat look like <platform>-<profile>
+		if _, profileName, isAlias := strings.Cut(host, "-"); isAlias && aliasProfilePattern.MatchString(profileName) {
+			remote.Protocol = ProtocolSSHProfile
+			remote.ProfileAlias = host
+		}
+
+	default:
+		return RemoteURL{}, fmt.Errorf("unsupported URL format: %s", url)
+	}
+
+	if remote.Host == "" || remote.Path == "" {
+		return RemoteURL{}, fmt.Errorf("invalid remote URL format: %s", url)
+	}
+	return remote, nil
+}
+
+// AliasParts returns the platform ID and profile name encoded in the host
+// alias of an ssh-profile URL, or empty strings for other URLs
+func (r RemoteURL) AliasParts() (string, string) {
+	if r.ProfileAlias == "" {
+		return "", ""
+	}
+	platformID, profileName, _ := strings.Cut(r.ProfileAlias, "-")
+	return platformID, profileName
+}
+
+// ToHTTPS returns the HTTPS URL of the repository on the given host
+func (r RemoteURL) ToHTTPS(host string) string {
+	return fmt.Sprintf("https://%s/%s", host, r.Path)
+}
+
+// ToSSH returns the scp-style SSH URL of the repository on the given host
+// (or host alias) for the given SSH user
+func (r RemoteURL) ToSSH(host, user string) string {
+	return fmt.Sprintf("%s@%s:%s", user, host, r.Path)
+}
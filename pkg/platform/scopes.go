@@ -0,0 +1,65 @@
+package platform
+
+import "strings"
+
+// scopeDescriptions explains the token scopes listed in the built-in
+// platforms' OAuthScopes, keyed by platform ID and scope
+var scopeDescriptions = map[string]map[string]string{
+	"github": {
+		"repo":      "Push and pull private repositories over HTTPS",
+		"read:user": "Read the account's profile (used to verify the token)",
+	},
+	"gitlab": {
+		"api":       "Push and pull repositories and use the API",
+		"read_user": "Read the account's profile (used to verify the token)",
+	},
+	"bitbucket": {
+		"repository:write": "Push and pull repositories over HTTPS",
+		"account":          "Read the account's profile (used to verify the token)",
+	},
+	"forgejo": {
+		"write:repository": "Push and pull repositories over HTTPS",
+		"read:user":        "Read the account's profile (used to verify the token)",
+	},
+	"huggingface": {
+		"write": "Push and pull model, dataset and Space repositories",
+	},
+	"azuredevops": {
+		"vso.code_write": "Code (Read & write): push and pull repositories",
+	},
+}
+
+// scopeParents maps scopes to a broader scope that includes them, so a token
+// granted the broader scope is not reported as missing the narrower one
+var scopeParents = map[string]map[string]string{
+	"github": {
+		"read:user": "user",
+	},
+	"bitbucket": {
+		"repository:write": "repository:admin",
+	},
+}
+
+// ScopeDescription returns what a scope in OAuthScopes is needed for, or ""
+// if it is not known (e.g. for custom platforms)
+func (p *Platform) ScopeDescription(scope string) string {
+	return scopeDescriptions[p.ID][scope]
+}
+
+// MissingScopes returns the OAuthScopes not present in granted. Scope names
+// are compared case-insensitively.
+func (p *Platform) MissingScopes(granted []string) []string {
+	have := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		have[strings.ToLower(scope)] = true
+	}
+
+	var missing []string
+	for _, scope := range p.OAuthScopes {
+		parent := scopeParents[p.ID][scope]
+		if !have[strings.ToLower(scope)] && (parent == "" || !have[parent]) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
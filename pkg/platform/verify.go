@@ -0,0 +1,166 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VerifyTimeout bounds a single token verification request
+const VerifyTimeout = 10 * time.Second
+
+// ErrTokenRejected is returned (wrapped) when the platform API rejects a token
+var ErrTokenRejected = errors.New("token rejected")
+
+// VerifyURL returns the token verification endpoint for the given host
+// (empty for the platform default). For self-hosted instances the default
+// host in APIVerifyURL is swapped for the custom host; if the URL does not
+// contain it, verification is refused rather than sending the token to the
+// public service.
+func (p *Platform) VerifyURL(host string) (string, error) {
+	apiVerifyURL := p.APIVerifyURL
+	if apiVerifyURL == "" && p.APICompatibility == APICompatGitea && p.DefaultHost != "" {
+		apiVerifyURL = "https://" + p.DefaultHost + "/api/v1/user"
+	}
+	if apiVerifyURL == "" {
+		return "", fmt.Errorf("%s has no token verification URL configured", p.Name)
+	}
+	if host == "" || host == p.DefaultHost {
+		return apiVerifyURL, nil
+	}
+
+	verifyURL, err := url.Parse(apiVerifyURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid verification URL '%s': %w", apiVerifyURL, err)
+	}
+	if verifyURL.Host != p.DefaultHost {
+		return "", fmt.Errorf("token verification is not supported for self-hosted %s instances", p.Name)
+	}
+	verifyURL.Host = host
+	return verifyURL.String(), nil
+}
+
+// TokenInfo is what VerifyToken learns about a token
+type TokenInfo struct {
+	Username  string     // Account the token authenticates as
+	Scopes    []string   // Scopes from APIScopesHeader; nil if the platform did not report them
+	AvatarURL string     // From APIAvatarField; empty if not configured or not reported
+	Expiry    *time.Time // From APIExpiryHeader; nil if the token does not expire or it was not reported
+}
+
+// expiryLayouts are the formats accepted in APIExpiryHeader
+var expiryLayouts = []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700", time.RFC3339}
+
+// parseExpiryHeader parses the token expiry a platform reports in a header,
+// returning nil for an unknown format
+func parseExpiryHeader(value string) *time.Time {
+	for _, layout := range expiryLayouts {
+		if expiry, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return &expiry
+		}
+	}
+	return nil
+}
+
+// VerifyToken calls the platform API with the token as a Bearer credential
+// ("token" for Gitea-compatible platforms)
+// and returns the username the token authenticates as and, where the
+// platform reports them, its scopes and expiry. It makes no changes on the
+// platform.
+func (p *Platform) VerifyToken(ctx context.Context, host, token string) (TokenInfo, error) {
+	verifyURL, err := p.VerifyURL(host)
+	if err != nil {
+		return TokenInfo{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, VerifyTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, verifyURL, nil)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not create verification request: %w", err)
+	}
+	if p.APICompatibility == APICompatGitea {
+		req.Header.Set("Authorization", "token "+token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not reach %s: %w", p.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return TokenInfo{}, fmt.Errorf("%w by %s (HTTP %d)", ErrTokenRejected, p.Name, resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return TokenInfo{}, fmt.Errorf("unexpected response from %s: HTTP %d", p.Name, resp.StatusCode)
+	}
+
+	// Limit the body we are willing to parse
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("could not read response from %s: %w", p.Name, err)
+	}
+
+	var user map[string]interface{}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return TokenInfo{}, fmt.Errorf("could not parse response from %s: %w", p.Name, err)
+	}
+
+	field := p.APIUsernameField
+	if field == "" && p.APICompatibility == APICompatGitea {
+		field = "login"
+	} else if field == "" {
+		field = "username"
+	}
+	username, _ := user[field].(string)
+	if strings.TrimSpace(username) == "" {
+		return TokenInfo{}, fmt.Errorf("response from %s has no '%s' field", p.Name, field)
+	}
+
+	info := TokenInfo{Username: username}
+	if p.APIAvatarField != "" {
+		info.AvatarURL, _ = nestedField(user, p.APIAvatarField).(string)
+	}
+	// Fine-grained tokens don't report scopes, so a missing header means unknown
+	if p.APIScopesHeader != "" {
+		if header, present := resp.Header[http.CanonicalHeaderKey(p.APIScopesHeader)]; present {
+			info.Scopes = []string{}
+			for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					info.Scopes = append(info.Scopes, scope)
+				}
+			}
+		}
+	}
+	if p.APIExpiryHeader != "" {
+		if header := resp.Header.Get(p.APIExpiryHeader); header != "" {
+			info.Expiry = parseExpiryHeader(header)
+		}
+	}
+	return info, nil
+}
+
+// nestedField returns the value at a dot-separated path in a decoded JSON
+// object, or nil if any part of the path is missing
+func nestedField(obj map[string]interface{}, path string) interface{} {
+	var value interface{} = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
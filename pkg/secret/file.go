@@ -0,0 +1,85 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileStore keeps secrets in a JSON object in a file readable by its owner
+// only, the protection the gat credentials file gives tokens
+type FileStore struct {
+	Path string
+}
+
+// Get returns the secret stored under key
+func (s *FileStore) Get(key string) (string, error) {
+	secrets, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	value, exists := secrets[key]
+	if !exists {
+		return "", fmt.Errorf("❌ no secret '%s' in %s: %w", key, s.Path, ErrNotFound)
+	}
+	return value, nil
+}
+
+// Set stores value under key
+func (s *FileStore) Set(key, value string) error {
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = value
+	return s.write(secrets)
+}
+
+// Delete removes the secret stored under key, if any
+func (s *FileStore) Delete(key string) error {
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, exists := secrets[key]; !exists {
+		return nil
+	}
+	delete(secrets, key)
+	return s.write(secrets)
+}
+
+// read returns the secrets of the file, none if it does not exist
+func (s *FileStore) read() (map[string]string, error) {
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read secrets file: %w", err)
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, fmt.Errorf("❌ could not parse secrets file %s: %w", s.Path, err)
+	}
+	return secrets, nil
+}
+
+// write replaces the file with secrets, readable by its owner only
+func (s *FileStore) write(secrets map[string]string) error {
+	data, err := json.MarshalIndent(secrets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("❌ could not marshal secrets: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("❌ could not create secrets directory: %w", err)
+	}
+	if err := os.WriteFile(s.Path, data, 0600); err != nil {
+		return fmt.Errorf("❌ could not write secrets file: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(s.Path, 0600); err != nil {
+		return fmt.Errorf("❌ could not secure secrets file: %w", err)
+	}
+	return nil
+}
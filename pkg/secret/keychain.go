@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keychainUser is the account of every secret gat keeps in the keychain,
+// whose service name is the secret's key
+const keychainUser = "gat"
+
+// KeychainStore keeps secrets in the OS keychain: the macOS Keychain, the
+// Windows Credential Manager, or the Secret Service (GNOME Keyring, KWallet)
+// on Linux
+type KeychainStore struct{}
+
+// Get returns the secret stored under key
+func (KeychainStore) Get(key string) (string, error) {
+	value, err := keyring.Get(key, keychainUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", fmt.Errorf("❌ no secret '%s' in the keychain: %w", key, ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("❌ could not read '%s' from the keychain: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set stores value under key
+func (KeychainStore) Set(key, value string) error {
+	if err := keyring.Set(key, keychainUser, value); err != nil {
+		return fmt.Errorf("❌ could not write '%s' to the keychain: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the secret stored under key, if any
+func (KeychainStore) Delete(key string) error {
+	if err := keyring.Delete(key, keychainUser); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("❌ could not delete '%s' from the keychain: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package secret stores secrets such as profile tokens, in the OS keychain or
+// in a file
+package secret
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Backends that New accepts
+const (
+	BackendFile     = "file"     // A JSON file readable by its owner only, see FileStore
+	BackendKeychain = "keychain" // The OS keychain, see KeychainStore
+)
+
+// ErrNotFound is returned (wrapped) by Get when no secret is stored under a key
+var ErrNotFound = errors.New("secret not found")
+
+// SecretStore keeps secrets by key
+type SecretStore interface {
+	// Get returns the secret stored under key, or an ErrNotFound error
+	Get(key string) (string, error)
+	// Set stores value under key, replacing what was stored before
+	Set(key, value string) error
+	// Delete removes the secret stored under key, if any
+	Delete(key string) error
+}
+
+// New returns the store of a backend. path is the file of BackendFile and is
+// not used by BackendKeychain.
+func New(backend, path string) (SecretStore, error) {
+	switch backend {
+	case BackendFile:
+		return &FileStore{Path: path}, nil
+	case BackendKeychain:
+		return KeychainStore{}, nil
+	default:
+		return nil, fmt.Errorf("❌ unknown secret backend '%s'; use %s or %s", backend, BackendFile, BackendKeychain)
+	}
+}
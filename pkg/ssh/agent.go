@@ -0,0 +1,86 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// TemporaryAgent is an ssh-agent started for a single command, separate from
+// the user's agent (see StartTemporaryAgent)
+type TemporaryAgent struct {
+	Socket string // SSH_AUTH_SOCK of the agent
+	PID    string // SSH_AGENT_PID of the agent
+}
+
+// StartTemporaryAgent starts a new ssh-agent without touching the
+// environment of gat. Stop it with Stop once the command is done.
+func StartTemporaryAgent() (*TemporaryAgent, error) {
+	output, err := exec.Command("ssh-agent", "-s").Output()
+	if err != nil {
+		return nil, fmt.Errorf("❌ failed to start ssh-agent: %w", err)
+	}
+
+	vars := parseAgentOutput(string(output))
+	agent := &TemporaryAgent{Socket: vars["SSH_AUTH_SOCK"], PID: vars["SSH_AGENT_PID"]}
+	if agent.Socket == "" || agent.PID == "" {
+		return nil, fmt.Errorf("❌ failed to parse ssh-agent output")
+	}
+	return agent, nil
+}
+
+// Env returns the environment variables that point SSH clients at the agent
+func (a *TemporaryAgent) Env() []string {
+	return []string{"SSH_AUTH_SOCK=" + a.Socket, "SSH_AGENT_PID=" + a.PID}
+}
+
+// AddIdentity loads SSH identities into the agent. ssh-add asks for the
+// passphrase of each key that has one on the terminal.
+func (a *TemporaryAgent) AddIdentity(identityPaths ...string) error {
+	args := []string{"-q"}
+	for _, identityPath := range identityPaths {
+		path, err := ExpandIdentityPath(identityPath)
+		if err != nil {
+			return err
+		}
+		args = append(args, path)
+	}
+
+	cmd := exec.Command("ssh-add", args...)
+	cmd.Env = append(os.Environ(), a.Env()...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ failed to add SSH identities %s: %w", strings.Join(identityPaths, ", "), err)
+	}
+	return nil
+}
+
+// Stop kills the agent, discarding the identities loaded into it
+func (a *TemporaryAgent) Stop() error {
+	cmd := exec.Command("ssh-agent", "-k")
+	cmd.Env = append(os.Environ(), a.Env()...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("❌ failed to stop ssh-agent (pid %s): %w\nOutput: %s", a.PID, err, string(output))
+	}
+	return nil
+}
+
+// parseAgentOutput reads the variables set by the shell commands 'ssh-agent -s'
+// prints, e.g.:
+//
+//	SSH_AUTH_SOCK=/tmp/ssh-XXXXXXXXXX/agent.pid; export SSH_AUTH_SOCK;
+//	SSH_AGENT_PID=12345; export SSH_AGENT_PID;
+//	echo Agent pid 12345;
+func parseAgentOutput(output string) map[string]string {
+	vars := make(map[string]string)
+	for _, statement := range strings.Split(output, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(statement), "=")
+		if found && (name == "SSH_AUTH_SOCK" || name == "SSH_AGENT_PID") {
+			vars[name] = value
+		}
+	}
+	return vars
+}
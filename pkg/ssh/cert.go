@@ -0,0 +1,77 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// certTimeLayout is the timestamp format of the 'Valid:' line printed by 'ssh-keygen -L'
+const certTimeLayout = "2006-01-02T15:04:05"
+
+// GenerateCertificateAuthority creates a new ed25519 key pair at caKeyPath
+// (and caKeyPath.pub) for signing user keys with 'ssh-keygen -s'. It refuses
+// to overwrite an existing key.
+func GenerateCertificateAuthority(caKeyPath string) error {
+	if caKeyPath == "" {
+		return fmt.Errorf("❌ no CA key path given")
+	}
+
+	// Expand ~ to home directory
+	caKeyPath, err := ExpandIdentityPath(caKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(caKeyPath); err == nil {
+		return fmt.Errorf("❌ CA key already exists: %s", caKeyPath)
+	}
+
+	// The CA key is protected by its file permissions; ssh-keygen writes it as 0600
+	cmd := exec.Command("ssh-keygen", "-t", "ed25519", "-f", caKeyPath, "-N", "", "-C", "gat-ca")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("❌ could not generate CA key: %s", strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// GetCertificateValidity reads the validity period of an SSH certificate
+// from 'ssh-keygen -L'. It returns the period as printed (e.g. "from
+// 2025-01-01T00:00:00 to 2026-01-01T00:00:00" or "forever") and the expiry
+// time, which is zero for certificates that never expire.
+func GetCertificateValidity(certPath string) (string, time.Time, error) {
+	// Expand ~ to home directory
+	certPath, err := ExpandIdentityPath(certPath)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	out, err := exec.Command("ssh-keygen", "-L", "-f", certPath).Output()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("❌ could not read SSH certificate %s: %w", certPath, err)
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		validity, found := strings.CutPrefix(strings.TrimSpace(line), "Valid:")
+		if !found {
+			continue
+		}
+		validity = strings.TrimSpace(validity)
+
+		// "from <start> to <end>"; "forever" and "after <start>" never expire
+		_, end, hasEnd := strings.Cut(validity, " to ")
+		if !hasEnd {
+			return validity, time.Time{}, nil
+		}
+		expires, err := time.ParseInLocation(certTimeLayout, end, time.Local)
+		if err != nil {
+			return validity, time.Time{}, fmt.Errorf("❌ could not parse certificate expiry '%s': %w", end, err)
+		}
+		return validity, expires, nil
+	}
+
+	return "", time.Time{}, fmt.Errorf("❌ no validity period found in SSH certificate %s", certPath)
+}
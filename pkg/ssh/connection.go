@@ -0,0 +1,60 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ConnectionTestTimeout bounds the 'ssh -T' of TestConnection
+const ConnectionTestTimeout = 10 * time.Second
+
+// greetingPatterns capture the username in what Git hosts answer 'ssh -T' with
+var greetingPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`Hi there, ([^!\s]+)! You've successfully authenticated`), // Gitea, Forgejo
+	regexp.MustCompile(`Hi ([^!\s]+)! You've successfully authenticated`),        // GitHub, Sourcehut
+	regexp.MustCompile(`Welcome to GitLab, @([^!\s]+)!`),                         // GitLab
+	regexp.MustCompile(`logged in as ([^\s.]+)`),                                 // Bitbucket
+}
+
+// TestConnection runs 'ssh -T user@host' without prompting (BatchMode) and
+// returns its combined output and exit code. Git hosts refuse the shell, so
+// a non-zero exit code alone does not mean authentication failed; see
+// GreetingUsername. The error is for an ssh that could not run or timed out.
+func TestConnection(user, host string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ConnectionTestTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ssh", "-T",
+		"-o", "BatchMode=yes",
+		"-o", fmt.Sprintf("ConnectTimeout=%d", int(ConnectionTestTimeout.Seconds())),
+		user+"@"+host)
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return string(output), 0, fmt.Errorf("❌ ssh to %s timed out after %s", host, ConnectionTestTimeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(output), exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return string(output), 0, fmt.Errorf("❌ could not run ssh: %w", err)
+	}
+	return string(output), 0, nil
+}
+
+// GreetingUsername returns the username a Git host greeted with in the
+// output of 'ssh -T' (GitHub, GitLab, Bitbucket, Gitea), and false if the
+// output holds no known greeting
+func GreetingUsername(output string) (string, bool) {
+	for _, pattern := range greetingPatterns {
+		if match := pattern.FindStringSubmatch(output); match != nil {
+			return strings.TrimSpace(match[1]), true
+		}
+	}
+	return "", false
+}
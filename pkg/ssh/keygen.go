@@ -0,0 +1,185 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultKeyType is the key type used by 'gat ssh generate'
+const DefaultKeyType = "ed25519"
+
+// KeyOptions configures GenerateKey
+type KeyOptions struct {
+	Path         string // Private key path ('~' is supported); the public key is Path + ".pub"
+	Type         string // Key type passed to 'ssh-keygen -t' (default DefaultKeyType)
+	Comment      string // Key comment
+	Bits         int    // Key size passed to 'ssh-keygen -b' (0 = ssh-keygen's default); not for ed25519
+	Passphrase   string // Passphrase for the key; ssh-keygen prompts if empty and NoPassphrase is unset
+	NoPassphrase bool   // Create the key without a passphrase instead of prompting for one
+}
+
+// ProfileKeyPath returns the default key path for a profile (~/.ssh/gat_<profile>)
+func ProfileKeyPath(profileName string) string {
+	return "~/.ssh/gat_" + profileName
+}
+
+// KeyComment returns the comment gat puts on generated keys: "<email> (gat:<platform>)"
+func KeyComment(email, platformID string) string {
+	return fmt.Sprintf("%s (gat:%s)", email, platformID)
+}
+
+// KeyExists reports whether a private or public key already exists at path
+func KeyExists(path string) bool {
+	path, err := ExpandIdentityPath(path)
+	if err != nil {
+		return false
+	}
+	for _, candidate := range []string{path, path + ".pub"} {
+		if _, err := os.Stat(candidate); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// askpassPassphraseEnvVar carries a given passphrase to ssh-keygen's askpass
+// program, which is gat itself (see ServeAskpass), so it never appears on a
+// command line where other users could read it
+const askpassPassphraseEnvVar = "GAT_SSH_KEYGEN_PASSPHRASE"
+
+// ServeAskpass answers ssh-keygen's passphrase prompts when gat runs as the
+// SSH_ASKPASS program of GenerateKey: it prints the passphrase and reports
+// true, and the caller should exit without running a command.
+func ServeAskpass() bool {
+	passphrase, ok := os.LookupEnv(askpassPassphraseEnvVar)
+	if !ok || os.Getenv("SSH_ASKPASS_REQUIRE") != "force" {
+		return false
+	}
+	fmt.Println(passphrase)
+	return true
+}
+
+// GenerateKey runs ssh-keygen to create a new key pair, replacing any key
+// already at the path. Unless Passphrase or NoPassphrase is set, ssh-keygen
+// prompts for the passphrase on the terminal. A given Passphrase reaches
+// ssh-keygen through SSH_ASKPASS, not its command line. The key is generated
+// in a temporary directory next to the path and only moved into place once
+// ssh-keygen succeeds, so a failed run keeps the existing key.
+func GenerateKey(opts KeyOptions) error {
+	path, err := ExpandIdentityPath(opts.Path)
+	if err != nil {
+		return err
+	}
+	keyType := opts.Type
+	if keyType == "" {
+		keyType = DefaultKeyType
+	}
+	if opts.Bits < 0 || (opts.Bits > 0 && keyType == "ed25519") {
+		return fmt.Errorf("❌ invalid key size %d for %s keys", opts.Bits, keyType)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("❌ could not create SSH directory: %w", err)
+	}
+	tempDir, err := os.MkdirTemp(filepath.Dir(path), ".gat-keygen-")
+	if err != nil {
+		return fmt.Errorf("❌ could not create temporary key directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+	tempPath := filepath.Join(tempDir, filepath.Base(path))
+
+	args := []string{"-t", keyType, "-f", tempPath, "-C", opts.Comment}
+	if opts.Bits > 0 {
+		args = append(args, "-b", strconv.Itoa(opts.Bits))
+	}
+	if opts.NoPassphrase && opts.Passphrase == "" {
+		args = append(args, "-N", "")
+	}
+
+	cmd := exec.Command("ssh-keygen", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if opts.Passphrase != "" {
+		executable, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("❌ could not locate gat to pass the passphrase to ssh-keygen: %w", err)
+		}
+		cmd.Stdin = nil
+		cmd.Env = append(os.Environ(),
+			"SSH_ASKPASS="+executable,
+			"SSH_ASKPASS_REQUIRE=force", // OpenSSH 8.4 and later
+			askpassPassphraseEnvVar+"="+opts.Passphrase,
+		)
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ ssh-keygen failed: %w", err)
+	}
+
+	// The caller has confirmed overwriting any existing key
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("❌ could not move the new key to %s: %w", path, err)
+	}
+	if err := os.Rename(tempPath+".pub", path+".pub"); err != nil {
+		return fmt.Errorf("❌ could not move the new public key to %s.pub: %w", path, err)
+	}
+	return nil
+}
+
+// ListSSHKeyFiles returns the sorted paths of the private keys in dir ('~' is
+// supported): files that don't end in .pub, have a .pub sibling and start
+// with a PEM/OpenSSH "-----BEGIN" header. Unreadable files are skipped.
+func ListSSHKeyFiles(dir string) ([]string, error) {
+	dir, err := ExpandIdentityPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read directory %s: %w", dir, err)
+	}
+
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, ".pub") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path + ".pub"); err != nil {
+			continue
+		}
+		if isPrivateKeyFile(path) {
+			keys = append(keys, path)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// isPrivateKeyFile reports whether the file at path is a regular file that
+// starts with a "-----BEGIN" header
+func isPrivateKeyFile(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	header := make([]byte, 64)
+	n, _ := io.ReadFull(file, header)
+	return bytes.HasPrefix(bytes.TrimSpace(header[:n]), []byte("-----BEGIN"))
+}
@@ -0,0 +1,184 @@
+package ssh
+
+import (
+	"fmt"
+	"gat/pkg/platform"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Strategies for ~/.ssh/gat_config, see 'gat ssh --strategy'
+const (
+	StrategyHostAlias = "host-alias" // One Host block per profile (default)
+	StrategyMatchExec = "match-exec" // One Match exec block for all profiles, see MultiHostConfig
+)
+
+// matchExecMarker is the first line of a gat_config written by MultiHostConfig
+const matchExecMarker = "# gat: match-exec strategy"
+
+// validCheckHost matches the host names 'gat check-host' links identities for
+var validCheckHost = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// MultiHostConfig is the match-exec strategy for ~/.ssh/gat_config. Instead
+// of a Host block per profile it writes one wildcard Host block per platform,
+// mapping profile aliases (github-*) to the platform's host, and a single
+// Match exec block that runs 'gat check-host %n'. That command links the
+// identity of the alias's profile, or of the active profile for a platform's
+// own host, to IdentityDir/<host>, which the block uses as IdentityFile.
+type MultiHostConfig struct {
+	GatBinary   string               // Absolute path of the gat executable
+	IdentityDir string               // Absolute directory of the per-host identity links
+	Platforms   []*platform.Platform // Platforms to map profile aliases for
+}
+
+// Render returns the gat_config content for the match-exec strategy
+func (c MultiHostConfig) Render() string {
+	var b strings.Builder
+	b.WriteString(matchExecMarker + " (managed by gat)\n")
+	b.WriteString("# Regenerate with 'gat ssh --strategy match-exec', revert with 'gat ssh --strategy host-alias'\n")
+
+	for _, plat := range c.Platforms {
+		sshUser := plat.SSHUser
+		if sshUser == "" {
+			sshUser = "git"
+		}
+		fmt.Fprintf(&b, "\n# Profile aliases on %s\nHost %s\n    HostName %s\n    User %s\n",
+			plat.Name, platform.GetProfileSSHHost(plat.ID, "*"), plat.DefaultHost, sshUser)
+	}
+
+	identityDir := strings.TrimSuffix(filepath.ToSlash(c.IdentityDir), "/")
+	fmt.Fprintf(&b, "\n# Identity of the profile for the host, linked by gat check-host\nMatch exec \"'%s' check-host --identity-dir '%s' %%n\"\n", c.GatBinary, identityDir)
+	fmt.Fprintf(&b, "    IdentityFile %s/%%n\n", identityDir)
+	fmt.Fprintf(&b, "    CertificateFile %s/%%n-cert.pub\n", identityDir)
+	b.WriteString("    IdentitiesOnly yes\n")
+	return b.String()
+}
+
+// Write replaces ~/.ssh/gat_config with the rendered config, keeping the
+// previous file as gat_config.bak, and makes sure ~/.ssh/config includes it
+func (c MultiHostConfig) Write() error {
+	if strings.ContainsAny(c.GatBinary+c.IdentityDir, "'\"%") {
+		return fmt.Errorf("❌ paths for the match-exec strategy cannot contain quotes or %%: %s, %s", c.GatBinary, c.IdentityDir)
+	}
+	gatConfigPath, err := getGatConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := EnsureGatInclude(); err != nil {
+		return err
+	}
+	if err := backupGatConfig(gatConfigPath); err != nil {
+		return err
+	}
+	if err := os.WriteFile(gatConfigPath, []byte(c.Render()), 0600); err != nil {
+		return fmt.Errorf("❌ could not write gat SSH config: %w", err)
+	}
+	return nil
+}
+
+// ResetHostAliasConfig empties ~/.ssh/gat_config, keeping the previous file
+// as gat_config.bak, so per-profile Host blocks can be written again
+func ResetHostAliasConfig() error {
+	gatConfigPath, err := getGatConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := backupGatConfig(gatConfigPath); err != nil {
+		return err
+	}
+	if err := os.WriteFile(gatConfigPath, nil, 0600); err != nil {
+		return fmt.Errorf("❌ could not write gat SSH config: %w", err)
+	}
+	return nil
+}
+
+// backupGatConfig copies gat_config to gat_config.bak if it exists
+func backupGatConfig(gatConfigPath string) error {
+	data, err := os.ReadFile(gatConfigPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("❌ could not read gat SSH config: %w", err)
+	}
+	if err := os.WriteFile(gatConfigPath+".bak", data, 0600); err != nil {
+		return fmt.Errorf("❌ could not back up gat SSH config: %w", err)
+	}
+	return nil
+}
+
+// CurrentStrategy reports the strategy ~/.ssh/gat_config was written with
+func CurrentStrategy() (string, error) {
+	gatConfigPath, err := getGatConfigPath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(gatConfigPath)
+	if os.IsNotExist(err) {
+		return StrategyHostAlias, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("❌ could not read gat SSH config: %w", err)
+	}
+	if isMatchExecConfig(string(data)) {
+		return StrategyMatchExec, nil
+	}
+	return StrategyHostAlias, nil
+}
+
+// isMatchExecConfig reports whether gat_config content was written by MultiHostConfig
+func isMatchExecConfig(content string) bool {
+	return strings.HasPrefix(content, matchExecMarker)
+}
+
+// LinkHostIdentity points identityDir/<host> (and <host>-cert.pub) at the
+// given identity and certificate, for the match-exec strategy. An empty
+// certPath removes the certificate link. It returns the expanded identity path.
+func LinkHostIdentity(identityDir, host, sshIdentity, certPath string) (string, error) {
+	if !validCheckHost.MatchString(host) {
+		return "", fmt.Errorf("❌ invalid host name: %s", host)
+	}
+	if err := os.MkdirAll(identityDir, 0700); err != nil {
+		return "", fmt.Errorf("❌ could not create %s: %w", identityDir, err)
+	}
+
+	identity, err := ExpandIdentityPath(sshIdentity)
+	if err != nil {
+		return "", err
+	}
+	if err := replaceSymlink(identity, filepath.Join(identityDir, host)); err != nil {
+		return "", err
+	}
+
+	certLink := filepath.Join(identityDir, host+"-cert.pub")
+	if certPath == "" {
+		if err := os.Remove(certLink); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("❌ could not remove %s: %w", certLink, err)
+		}
+		return identity, nil
+	}
+	cert, err := ExpandIdentityPath(certPath)
+	if err != nil {
+		return "", err
+	}
+	if err := replaceSymlink(cert, certLink); err != nil {
+		return "", err
+	}
+	return identity, nil
+}
+
+// replaceSymlink atomically points link at target
+func replaceSymlink(target, link string) error {
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("❌ could not link %s: %w", link, err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("❌ could not link %s: %w", link, err)
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package ssh
 
 import (
+	"errors"
 	"fmt"
 	"gat/pkg/platform"
+	"gat/pkg/utils"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,31 +14,54 @@ import (
 )
 
 const gatIncludeLine = "Include ~/.ssh/gat_config"
+
 const gatConfigComment = "# Added by gat for identity management"
 
-// UpdateSSHConfig updates the SSH config files to manage Git host identities
-func UpdateSSHConfig(platformID, profileName, sshIdentity string) error {
-	if sshIdentity == "" {
+// ErrIdentityNotFound is returned (wrapped) when an SSH identity file is missing.
+// Match it with errors.Is.
+var ErrIdentityNotFound = errors.New("SSH identity not found")
+
+// UpdateSSHConfig updates the SSH config files to manage Git host identities.
+// Each of sshIdentities gets an IdentityFile line, tried in order; certPath
+// is the CA-signed certificate for the first one, if any.
+func UpdateSSHConfig(platformID, profileName string, sshIdentities []string, certPath string) error {
+	if len(sshIdentities) == 0 {
 		return nil // Skip if no SSH identity provided
 	}
 
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
+	if err := EnsureGatInclude(); err != nil {
+		return err
+	}
+
+	gatConfigPath, err := getGatConfigPath()
 	if err != nil {
-		return fmt.Errorf("❌ could not find home directory: %w", err)
+		return err
+	}
+
+	// Update the gat_config file with the platform-specific host
+	if err := updateGatConfig(gatConfigPath, platformID, profileName, sshIdentities, certPath); err != nil {
+		return err
 	}
 
+	return nil
+}
+
+// EnsureGatInclude creates ~/.ssh/config if needed and makes sure it
+// includes ~/.ssh/gat_config
+func EnsureGatInclude() error {
 	// Path to SSH directory and config files
-	sshDir := filepath.Join(homeDir, ".ssh")
+	sshDir, err := utils.ExpandHome("~/.ssh")
+	if err != nil {
+		return err
+	}
 	mainConfigPath := filepath.Join(sshDir, "config")
-	gatConfigPath := filepath.Join(sshDir, "gat_config")
 
 	// Ensure SSH directory exists
 	if err := os.MkdirAll(sshDir, 0700); err != nil {
 		return fmt.Errorf("❌ could not create SSH directory: %w", err)
 	}
 
-	// 1. Check if main SSH config exists, create if needed
+	// Check if main SSH config exists, create if needed
 	_, err = os.Stat(mainConfigPath)
 	if os.IsNotExist(err) {
 		// Create empty config file with include line
@@ -54,11 +79,6 @@ func UpdateSSHConfig(platformID, profileName, sshIdentity string) error {
 		return fmt.Errorf("❌ could not check SSH config: %w", err)
 	}
 
-	// 2. Update the gat_config file with the platform-specific host
-	if err := updateGatConfig(gatConfigPath, platformID, profileName, sshIdentity); err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -91,9 +111,12 @@ func ensureGatIncludeLine(configPath string) error {
 }
 
 // updateGatConfig updates the gat_config file with the platform-specific host
-func updateGatConfig(configPath, platformID, profileName, sshIdentity string) error {
-	// Format the identity path based on platform
-	formattedIdentity := formatSSHPath(sshIdentity)
+func updateGatConfig(configPath, platformID, profileName string, sshIdentities []string, certPath string) error {
+	// The match-exec strategy covers every profile without per-profile blocks
+	if data, err := os.ReadFile(configPath); err == nil && isMatchExecConfig(string(data)) {
+		fmt.Printf("🔐 SSH configuration covers %s profile %s (match-exec strategy)\n", platformID, profileName)
+		return nil
+	}
 
 	// Generate host alias for this platform+profile combination
 	hostAlias := platform.GetProfileSSHHost(platformID, profileName)
@@ -114,9 +137,26 @@ func updateGatConfig(configPath, platformID, profileName, sshIdentity string) er
 Host %s
     HostName %s
     User %s
-    IdentityFile %s
-    IdentitiesOnly yes
-`, profileName, plat.Name, hostAlias, plat.DefaultHost, plat.SSHUser, formattedIdentity)
+`, profileName, plat.Name, hostAlias, plat.DefaultHost, plat.SSHUser)
+
+	// Expand ~ so the written paths match what gat checks, then format them for the platform
+	for _, identity := range sshIdentities {
+		identity, err := formatSSHPath(identity)
+		if err != nil {
+			return err
+		}
+		hostBlock += fmt.Sprintf("    IdentityFile %s\n", identity)
+	}
+	hostBlock += "    IdentitiesOnly yes\n"
+
+	// Offer the CA-signed certificate alongside the key
+	if certPath != "" {
+		certPath, err := formatSSHPath(certPath)
+		if err != nil {
+			return err
+		}
+		hostBlock += fmt.Sprintf("    CertificateFile %s\n", certPath)
+	}
 
 	// Check if the file exists
 	data, err := os.ReadFile(configPath)
@@ -166,13 +206,86 @@ Host %s
 	return nil
 }
 
-// formatSSHPath formats the SSH identity path based on the current platform
-func formatSSHPath(sshIdentity string) string {
+// RenameProfile renames a profile's host block in ~/.ssh/gat_config: the
+// Host line becomes the new profile's alias and the "# Profile:" comment
+// above it names the new profile. A missing file or block, or a config
+// using the match-exec strategy, is left alone.
+func RenameProfile(oldName, newName, platformID string) error {
+	gatConfigPath, err := getGatConfigPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(gatConfigPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("❌ could not read gat SSH config: %w", err)
+	}
+	content := string(data)
+	if isMatchExecConfig(content) {
+		return nil
+	}
+
+	oldAlias := platform.GetProfileSSHHost(platformID, oldName)
+	newAlias := platform.GetProfileSSHHost(platformID, newName)
+	oldHost := regexp.MustCompile(fmt.Sprintf(`(?m)^Host %s$`, regexp.QuoteMeta(oldAlias)))
+	if !oldHost.MatchString(content) {
+		return nil
+	}
+	if regexp.MustCompile(fmt.Sprintf(`(?m)^Host %s$`, regexp.QuoteMeta(newAlias))).MatchString(content) {
+		return fmt.Errorf("❌ %s already has a Host %s block; remove it and run 'gat switch %s' to recreate it", gatConfigPath, newAlias, newName)
+	}
+
+	content = oldHost.ReplaceAllLiteralString(content, "Host "+newAlias)
+	comment := regexp.MustCompile(fmt.Sprintf(`(?m)^# Profile: %s on (.*) \(managed by gat\)(\r?\n)Host %s$`,
+		regexp.QuoteMeta(oldName), regexp.QuoteMeta(newAlias)))
+	content = comment.ReplaceAllString(content, "# Profile: "+newName+" on ${1} (managed by gat)${2}Host "+newAlias)
+
+	if err := os.WriteFile(gatConfigPath, []byte(content), 0600); err != nil {
+		return fmt.Errorf("❌ could not write gat SSH config: %w", err)
+	}
+	fmt.Printf("🔐 Renamed SSH host %s to %s\n", oldAlias, newAlias)
+	return nil
+}
+
+// ExpandIdentityPath expands a leading ~ in an SSH identity path to the
+// user's home directory. Profiles store the ~ form for portability; it is
+// expanded only when the path is used.
+func ExpandIdentityPath(path string) (string, error) {
+	return utils.ExpandHome(path)
+}
+
+// CollapseIdentityPath rewrites a path inside the user's home directory to
+// the portable ~ form; other paths are returned unchanged
+func CollapseIdentityPath(path string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || path == "" {
+		return path
+	}
+	rel, err := filepath.Rel(homeDir, path)
+	if err != nil || !filepath.IsAbs(path) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	if rel == "." {
+		return "~"
+	}
+	return "~/" + filepath.ToSlash(rel)
+}
+
+// formatSSHPath expands a leading ~ in the SSH identity path and formats it
+// for the current platform
+func formatSSHPath(sshIdentity string) (string, error) {
+	sshIdentity, err := utils.ExpandHome(sshIdentity)
+	if err != nil {
+		return "", err
+	}
+
 	// On Windows, convert backslashes to forward slashes in the SSH config
 	if runtime.GOOS == "windows" {
-		return strings.ReplaceAll(sshIdentity, "\\", "/")
+		return strings.ReplaceAll(sshIdentity, "\\", "/"), nil
 	}
-	return sshIdentity
+	return sshIdentity, nil
 }
 
 // GetSSHCloneURL returns an SSH clone URL for a specific profile
@@ -198,23 +311,21 @@ func extractRepoPath(repoURL string) string {
 	return strings.TrimSuffix(path, ".git")
 }
 
-// CheckSSHIdentity checks if an SSH identity file exists
-func CheckSSHIdentity(sshIdentity string) (bool, error) {
+// CheckSSHIdentity checks if an SSH identity file and its public key exist,
+// and, when certPath is set, that the signed certificate exists too
+func CheckSSHIdentity(sshIdentity, certPath string) (bool, error) {
 	if sshIdentity == "" {
 		return false, nil
 	}
 
 	// Expand ~ to home directory
-	if strings.HasPrefix(sshIdentity, "~") {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return false, fmt.Errorf("❌ could not find home directory: %w", err)
-		}
-		sshIdentity = filepath.Join(homeDir, sshIdentity[1:])
+	sshIdentity, err := ExpandIdentityPath(sshIdentity)
+	if err != nil {
+		return false, err
 	}
 
 	// Check if identity file exists
-	_, err := os.Stat(sshIdentity)
+	_, err = os.Stat(sshIdentity)
 	if os.IsNotExist(err) {
 		return false, nil
 	}
@@ -231,18 +342,157 @@ func CheckSSHIdentity(sshIdentity string) (bool, error) {
 		return false, fmt.Errorf("❌ could not check SSH public key: %w", err)
 	}
 
+	// And the CA-signed certificate, if the profile uses one
+	if certPath != "" {
+		certPath, err = ExpandIdentityPath(certPath)
+		if err != nil {
+			return false, err
+		}
+		_, err = os.Stat(certPath)
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("❌ could not check SSH certificate: %w", err)
+		}
+	}
+
 	return true, nil
 }
 
+// CheckSSHIdentities is CheckSSHIdentity for each of a profile's identities,
+// certPath belonging to the first. It returns the first identity that is
+// missing its file, public key or certificate, or "" if none is.
+func CheckSSHIdentities(sshIdentities []string, certPath string) (string, error) {
+	for i, identity := range sshIdentities {
+		if i > 0 {
+			certPath = ""
+		}
+		exists, err := CheckSSHIdentity(identity, certPath)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return identity, nil
+		}
+	}
+	return "", nil
+}
+
+// ValidateIdentityPermissions checks that an SSH private key is not readable by
+// other users. OpenSSH refuses to use keys with group or world permissions.
+func ValidateIdentityPermissions(sshIdentity string) error {
+	if sshIdentity == "" {
+		return nil
+	}
+
+	// Windows uses ACLs rather than Unix permission bits
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	// Expand ~ to home directory
+	sshIdentity, err := ExpandIdentityPath(sshIdentity)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(sshIdentity)
+	if os.IsNotExist(err) {
+		return utils.Errorf(ErrIdentityNotFound, "❌ SSH identity file not found: %s", sshIdentity)
+	}
+	if err != nil {
+		return fmt.Errorf("❌ could not check SSH identity: %w", err)
+	}
+
+	mode := info.Mode().Perm()
+	if mode&0077 != 0 {
+		return fmt.Errorf("❌ SSH identity permissions are too open (%s). Run 'chmod 600 %s'", mode, sshIdentity)
+	}
+
+	return nil
+}
+
+// SecureIdentityPermissions makes an SSH private key readable by its owner
+// only, as ValidateIdentityPermissions requires
+func SecureIdentityPermissions(sshIdentity string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	sshIdentity, err := ExpandIdentityPath(sshIdentity)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(sshIdentity, 0600); err != nil {
+		return fmt.Errorf("❌ could not set permissions of %s: %w", sshIdentity, err)
+	}
+	return nil
+}
+
+// GetPublicKeyContent reads the public key that pairs with an SSH identity
+// (<identityPath>.pub) and returns its trimmed content
+func GetPublicKeyContent(identityPath string) (string, error) {
+	if identityPath == "" {
+		return "", fmt.Errorf("❌ no SSH identity configured")
+	}
+
+	// Expand ~ to home directory
+	identityPath, err := ExpandIdentityPath(identityPath)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(identityPath + ".pub")
+	if os.IsNotExist(err) {
+		return "", utils.Errorf(ErrIdentityNotFound, "❌ SSH public key not found: %s.pub", identityPath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("❌ could not read SSH public key: %w", err)
+	}
+
+	// Guard against printing a private key or unrelated file
+	content := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(content, "ssh-") && !strings.HasPrefix(content, "ecdsa-") {
+		return "", fmt.Errorf("❌ %s.pub does not look like an SSH public key", identityPath)
+	}
+
+	return content, nil
+}
+
+// GetKeyFingerprint returns the fingerprint of an SSH identity's public key
+// as reported by 'ssh-keygen -lf' (e.g. "256 SHA256:... comment (ED25519)")
+func GetKeyFingerprint(identityPath string) (string, error) {
+	if identityPath == "" {
+		return "", fmt.Errorf("❌ no SSH identity configured")
+	}
+
+	// Expand ~ to home directory
+	identityPath, err := ExpandIdentityPath(identityPath)
+	if err != nil {
+		return "", err
+	}
+
+	pubPath := identityPath + ".pub"
+	if _, err := os.Stat(pubPath); os.IsNotExist(err) {
+		return "", utils.Errorf(ErrIdentityNotFound, "❌ SSH public key not found: %s", pubPath)
+	}
+
+	out, err := exec.Command("ssh-keygen", "-lf", pubPath).Output()
+	if err != nil {
+		return "", fmt.Errorf("❌ could not read key fingerprint: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // CheckSSHHostExists checks if a specific SSH host alias exists in the main or gat SSH config files.
 func CheckSSHHostExists(hostAlias string) (bool, error) {
-	homeDir, err := os.UserHomeDir()
+	sshDir, err := utils.ExpandHome("~/.ssh")
 	if err != nil {
-		return false, fmt.Errorf("❌ could not find home directory: %w", err)
+		return false, err
 	}
 
-	mainConfigPath := filepath.Join(homeDir, ".ssh", "config")
-	gatConfigPath := filepath.Join(homeDir, ".ssh", "gat_config")
+	mainConfigPath := filepath.Join(sshDir, "config")
+	gatConfigPath := filepath.Join(sshDir, "gat_config")
 
 	configContent := ""
 
@@ -255,6 +505,15 @@ func CheckSSHHostExists(hostAlias string) (bool, error) {
 
 	// Read gat_config if it exists
 	if data, err := os.ReadFile(gatConfigPath); err == nil {
+		// The match-exec strategy maps aliases with one wildcard block per platform
+		if isMatchExecConfig(string(data)) {
+			wildcard := regexp.MustCompile(`(?m)^\s*Host\s+(\S+-)\*\s*$`)
+			for _, match := range wildcard.FindAllStringSubmatch(string(data), -1) {
+				if strings.HasPrefix(hostAlias, match[1]) {
+					return true, nil
+				}
+			}
+		}
 		configContent += string(data)
 	} else if !os.IsNotExist(err) {
 		return false, fmt.Errorf("❌ could not read gat SSH config '%s': %w", gatConfigPath, err)
@@ -270,15 +529,13 @@ func CheckSSHHostExists(hostAlias string) (bool, error) {
 
 // CheckSSHSetup checks if the SSH configuration is set up correctly for gat
 func CheckSSHSetup() (bool, error) {
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
+	// Path to SSH config files
+	sshDir, err := utils.ExpandHome("~/.ssh")
 	if err != nil {
-		return false, fmt.Errorf("❌ could not find home directory: %w", err)
+		return false, err
 	}
-
-	// Path to SSH config files
-	mainConfigPath := filepath.Join(homeDir, ".ssh", "config")
-	gatConfigPath := filepath.Join(homeDir, ".ssh", "gat_config")
+	mainConfigPath := filepath.Join(sshDir, "config")
+	gatConfigPath := filepath.Join(sshDir, "gat_config")
 
 	// Check if main SSH config exists
 	_, err = os.Stat(mainConfigPath)
@@ -313,7 +570,7 @@ func CheckSSHSetup() (bool, error) {
 }
 
 // ConfigureSSH configures SSH for a specific profile
-func ConfigureSSH(platformID, profileName, sshIdentity string) error {
+func ConfigureSSH(platformID, profileName string, sshIdentities []string, certPath string) error {
 	// Get config path
 	configPath, err := getGatConfigPath()
 	if err != nil {
@@ -321,19 +578,12 @@ func ConfigureSSH(platformID, profileName, sshIdentity string) error {
 	}
 
 	// Update GAT-specific SSH config
-	return updateGatConfig(configPath, platformID, profileName, sshIdentity)
+	return updateGatConfig(configPath, platformID, profileName, sshIdentities, certPath)
 }
 
 // getGatConfigPath returns the path to the gat SSH config file
 func getGatConfigPath() (string, error) {
-	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("❌ could not find home directory: %w", err)
-	}
-
-	// Return path to gat_config file in .ssh directory
-	return filepath.Join(homeDir, ".ssh", "gat_config"), nil
+	return utils.ExpandHome("~/.ssh/gat_config")
 }
 
 // StartAgent ensures the ssh-agent is running.
@@ -356,25 +606,9 @@ func StartAgent() error {
 		return fmt.Errorf("❌ failed to start ssh-agent: %w\nOutput: %s", err, string(output))
 	}
 
-	// Parse the output to set environment variables (SSH_AUTH_SOCK, SSH_AGENT_PID)
-	// Example output:
-	// SSH_AUTH_SOCK=/tmp/ssh-XXXXXXXXXX/agent.pid; export SSH_AUTH_SOCK;
-	// SSH_AGENT_PID=12345; export SSH_AGENT_PID;
-	// echo Agent pid 12345;
-	lines := strings.Split(string(output), ";")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "SSH_AUTH_SOCK=") {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				os.Setenv("SSH_AUTH_SOCK", parts[1])
-			}
-		} else if strings.HasPrefix(line, "SSH_AGENT_PID=") {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				os.Setenv("SSH_AGENT_PID", parts[1])
-			}
-		}
+	// Set SSH_AUTH_SOCK and SSH_AGENT_PID for gat and the commands it runs
+	for name, value := range parseAgentOutput(string(output)) {
+		os.Setenv(name, value)
 	}
 
 	// Verify agent started by checking env var again
@@ -403,17 +637,29 @@ func ClearIdentities() error {
 	return nil
 }
 
-// AddIdentity adds a specific SSH identity to the ssh-agent.
-func AddIdentity(identityPath string) error {
+// AddIdentity adds SSH identities to the ssh-agent, stopping at the first
+// that cannot be added.
+func AddIdentity(identityPaths ...string) error {
+	for _, identityPath := range identityPaths {
+		if err := addIdentity(identityPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addIdentity adds a single SSH identity to the ssh-agent
+func addIdentity(identityPath string) error {
 	fmt.Printf("➕ Adding SSH identity: %s\n", identityPath)
 
 	// Expand ~ to home directory
-	if strings.HasPrefix(identityPath, "~") {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("❌ could not find home directory: %w", err)
-		}
-		identityPath = filepath.Join(homeDir, identityPath[1:])
+	identityPath, err := ExpandIdentityPath(identityPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(identityPath); os.IsNotExist(err) {
+		return utils.Errorf(ErrIdentityNotFound, "❌ SSH identity file not found: %s", identityPath)
 	}
 
 	cmd := exec.Command("ssh-add", identityPath)
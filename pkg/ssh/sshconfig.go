@@ -0,0 +1,133 @@
+package ssh
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HostBlock is one 'Host' section of an OpenSSH client config file
+type HostBlock struct {
+	Patterns        []string // Host patterns, e.g. ["github-work"] or ["*.example.com"]
+	HostName        string   // HostName, empty if not set (ssh then uses the pattern)
+	User            string
+	IdentityFile    string // First IdentityFile, as written
+	CertificateFile string // First CertificateFile, as written
+	ManagedByGat    bool   // Preceded by the "(managed by gat)" comment written by UpdateSSHConfig
+	Line            int    // 1-based line number of the Host keyword
+}
+
+// IsWildcard reports whether any of the block's patterns is a wildcard or
+// negation, i.e. the block does not describe a single host
+func (b HostBlock) IsWildcard() bool {
+	for _, pattern := range b.Patterns {
+		if strings.ContainsAny(pattern, "*?!") {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedHostName returns HostName, or the first pattern if it is not set
+func (b HostBlock) ResolvedHostName() string {
+	if b.HostName != "" {
+		return b.HostName
+	}
+	if len(b.Patterns) > 0 {
+		return b.Patterns[0]
+	}
+	return ""
+}
+
+// ParseSSHConfig reads the Host blocks of an OpenSSH client config file such
+// as ~/.ssh/config or ~/.ssh/gat_config. Keywords are case-insensitive and
+// may be separated from their value by whitespace or '='. Settings before
+// the first Host and inside Match blocks are ignored, and Include directives
+// are not followed. A missing file yields no blocks.
+func ParseSSHConfig(path string) ([]HostBlock, error) {
+	path, err := ExpandIdentityPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ could not read SSH config '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	var blocks []HostBlock
+	var current *HostBlock
+	managedComment := false
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			managedComment = strings.Contains(line, "(managed by gat)")
+			continue
+		}
+
+		keyword, value := splitSSHConfigLine(line)
+		switch strings.ToLower(keyword) {
+		case "host":
+			blocks = append(blocks, HostBlock{
+				Patterns:     strings.Fields(value),
+				ManagedByGat: managedComment,
+				Line:         lineNumber,
+			})
+			current = &blocks[len(blocks)-1]
+		case "match":
+			current = nil // Conditional blocks don't describe a single host
+		case "hostname":
+			if current != nil {
+				current.HostName = value
+			}
+		case "user":
+			if current != nil {
+				current.User = value
+			}
+		case "identityfile":
+			if current != nil && current.IdentityFile == "" {
+				current.IdentityFile = unquoteSSHValue(value)
+			}
+		case "certificatefile":
+			if current != nil && current.CertificateFile == "" {
+				current.CertificateFile = unquoteSSHValue(value)
+			}
+		}
+		managedComment = false
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("❌ could not read SSH config '%s': %w", path, err)
+	}
+
+	return blocks, nil
+}
+
+// splitSSHConfigLine splits "Keyword value" or "Keyword=value" into its parts
+func splitSSHConfigLine(line string) (string, string) {
+	end := strings.IndexAny(line, " \t=")
+	if end < 0 {
+		return line, ""
+	}
+	keyword := line[:end]
+	value := strings.TrimSpace(line[end:])
+	value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+	return keyword, value
+}
+
+// unquoteSSHValue removes the double quotes around a path containing spaces
+func unquoteSSHValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
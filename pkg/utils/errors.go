@@ -0,0 +1,25 @@
+package utils
+
+import "fmt"
+
+// kindError is an error tagged with a sentinel kind for errors.Is checks
+type kindError struct {
+	kind error
+	err  error
+}
+
+// Error returns the formatted message without the kind's own text
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap exposes both the kind and any error wrapped by the message
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// Errorf formats an error like fmt.Errorf and tags it with a sentinel kind, so
+// callers can match it with errors.Is while users still see the original message
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, err: fmt.Errorf(format, args...)}
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandHome expands a leading ~ ("~", "~/..." or "~\...") to the user's home
+// directory. Other paths, including "~user/...", are returned unchanged.
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("❌ could not find home directory: %w", err)
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
@@ -1,5 +1,7 @@
 package utils
 
+import "fmt"
+
 // Ternary is a helper function that mimics the ternary operator
 // Returns a if condition is true, otherwise returns b
 func Ternary[T any](condition bool, a, b T) T {
@@ -8,3 +10,17 @@ func Ternary[T any](condition bool, a, b T) T {
 	}
 	return b
 }
+
+// FormatBytes formats a byte count in human-readable form (e.g. "512 B", "1.5 KB", "2.0 MB")
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
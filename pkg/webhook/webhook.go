@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gat/pkg/config"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Timeout bounds a single webhook request
+const Timeout = 5 * time.Second
+
+// Payload is the JSON body gat sends to webhooks
+type Payload struct {
+	Event     string    `json:"event"`
+	Profile   string    `json:"profile"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewPayload returns the Payload of event for profile, timestamped now
+func NewPayload(event, profile string) Payload {
+	return Payload{Event: event, Profile: profile, Timestamp: time.Now().UTC()}
+}
+
+// Notify sends payload as JSON to the configured webhooks that subscribe to
+// event, all at once, and waits for them to answer or time out. The error
+// joins those of the webhooks that failed or answered with a non-2xx status.
+func Notify(event string, payload interface{}) error {
+	webhooks, err := config.LoadWebhooks()
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("could not encode webhook payload: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(webhooks))
+	for i, hook := range webhooks {
+		if !hook.Handles(event) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, hook config.WebhookConfig) {
+			defer wg.Done()
+			errs[i] = send(hook, body)
+		}(i, hook)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// send makes the request of one webhook
+func send(hook config.WebhookConfig, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, hook.HTTPMethod(), hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", hook.URL, err)
+	}
+	for name, value := range hook.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gat-webhook")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", hook.URL, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16)) // Lets the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: HTTP %d", hook.URL, resp.StatusCode)
+	}
+	return nil
+}